@@ -0,0 +1,70 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+)
+
+// UserIdFromContext JWT の user_id クレームを取得
+func UserIdFromContext(ctx context.Context) (int64, bool) {
+	return int64Claim(ctx, "user_id")
+}
+
+// OrgIdFromContext JWT の org_id クレーム（組織を指定して発行したトークンのみ）を取得
+func OrgIdFromContext(ctx context.Context) (int64, bool) {
+	return int64Claim(ctx, "org_id")
+}
+
+// ApiKeyIdFromContext API キーで認証されたリクエストのキーID を取得
+// 通常のログインで発行した JWT では false を返す
+func ApiKeyIdFromContext(ctx context.Context) (int64, bool) {
+	return int64Claim(ctx, "api_key_id")
+}
+
+// ScopesFromContext API キーに許可された権限（scopes クレーム）を取得
+func ScopesFromContext(ctx context.Context) []string {
+	values, ok := ctx.Value("scopes").([]any)
+	if !ok {
+		return nil
+	}
+
+	scopes := make([]string, 0, len(values))
+	for _, v := range values {
+		if scope, ok := v.(string); ok {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	return scopes
+}
+
+// int64Claim 数値クレームを取得
+// go-zero は数値クレームを json.Number としてコンテキストに格納する
+func int64Claim(ctx context.Context, key string) (int64, bool) {
+	var value int64
+	switch v := ctx.Value(key).(type) {
+	case int64:
+		value = v
+	case int:
+		value = int64(v)
+	case float64:
+		value = int64(v)
+	case json.Number:
+		n, err := v.Int64()
+		if err != nil {
+			return 0, false
+		}
+		value = n
+	case string:
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return 0, false
+		}
+		value = n
+	default:
+		return 0, false
+	}
+
+	return value, value > 0
+}
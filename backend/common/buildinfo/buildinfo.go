@@ -0,0 +1,131 @@
+// Package buildinfo 各サービス共通のビルド情報
+//
+// バージョン・コミット・ビルド日時は ldflags で埋め込む:
+//
+//	go build -ldflags "-X github.com/winyx/backend/common/buildinfo.version=v1.2.0 \
+//	  -X github.com/winyx/backend/common/buildinfo.commit=$(git rev-parse HEAD) \
+//	  -X github.com/winyx/backend/common/buildinfo.buildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+//
+// 指定が無い項目は runtime/debug.ReadBuildInfo の VCS 情報から補完する。
+package buildinfo
+
+import (
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+// ldflags で上書きされる値
+var (
+	version   string
+	commit    string
+	buildTime string
+)
+
+const unknownVersion = "dev"
+
+// Info ビルド情報
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildTime string `json:"build_time"`
+	Dirty     bool   `json:"dirty"` // 未コミットの変更を含むビルドか
+	GoVersion string `json:"go_version"`
+	Module    string `json:"module"`
+	Deps      []Dep  `json:"deps,omitempty"`
+}
+
+// Dep 依存モジュール
+type Dep struct {
+	Path    string `json:"path"`
+	Version string `json:"version"`
+	Replace string `json:"replace,omitempty"`
+}
+
+var (
+	once sync.Once
+	info Info
+)
+
+// Get ビルド情報を取得（初回のみ読み込み、以降はキャッシュを返す）
+func Get() Info {
+	once.Do(func() {
+		info = read()
+	})
+
+	return info
+}
+
+// Summary 依存モジュールを除いたビルド情報（ヘルスチェックなど頻繁に返す箇所向け）
+func Summary() Info {
+	summary := Get()
+	summary.Deps = nil
+
+	return summary
+}
+
+// ShortCommit 先頭12桁のコミットハッシュ
+func (i Info) ShortCommit() string {
+	if len(i.Commit) > 12 {
+		return i.Commit[:12]
+	}
+
+	return i.Commit
+}
+
+func read() Info {
+	result := Info{
+		Version:   version,
+		Commit:    commit,
+		BuildTime: buildTime,
+		GoVersion: runtime.Version(),
+	}
+
+	bi, ok := debug.ReadBuildInfo()
+	if ok {
+		result.Module = bi.Main.Path
+		if result.Version == "" && bi.Main.Version != "" && bi.Main.Version != "(devel)" {
+			result.Version = bi.Main.Version
+		}
+
+		for _, setting := range bi.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				if result.Commit == "" {
+					result.Commit = setting.Value
+				}
+			case "vcs.time":
+				// ldflags 未指定時はコミット日時で代用する
+				if result.BuildTime == "" {
+					result.BuildTime = setting.Value
+				}
+			case "vcs.modified":
+				result.Dirty = setting.Value == "true"
+			}
+		}
+
+		for _, dep := range bi.Deps {
+			d := Dep{Path: dep.Path, Version: dep.Version}
+			if dep.Replace != nil {
+				d.Replace = dep.Replace.Path
+				if dep.Replace.Version != "" {
+					d.Replace += "@" + dep.Replace.Version
+				}
+			}
+			result.Deps = append(result.Deps, d)
+		}
+	}
+
+	if result.Version == "" {
+		result.Version = unknownVersion
+	}
+	if result.BuildTime != "" {
+		// ldflags / VCS のどちらも RFC3339 を想定し、表示形式を揃える
+		if t, err := time.Parse(time.RFC3339, result.BuildTime); err == nil {
+			result.BuildTime = t.UTC().Format(time.RFC3339)
+		}
+	}
+
+	return result
+}
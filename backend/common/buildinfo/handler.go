@@ -0,0 +1,27 @@
+package buildinfo
+
+import (
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+// Path ビルド情報公開用のパス
+const Path = "/version"
+
+// Handler 依存モジュールを含むビルド情報をJSONで返すハンドラ
+func Handler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		httpx.OkJsonCtx(r.Context(), w, Get())
+	}
+}
+
+// RegisterHandler サーバーに GET /version を登録する
+func RegisterHandler(server *rest.Server) {
+	server.AddRoute(rest.Route{
+		Method:  http.MethodGet,
+		Path:    Path,
+		Handler: Handler(),
+	})
+}
@@ -0,0 +1,159 @@
+package featureflag
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/stores/redis"
+	"github.com/zeromicro/go-zero/core/threading"
+)
+
+// Listener フラグが変更された際に呼び出されるコールバック
+type Listener func(flags map[string]Flag)
+
+// Client Redis からフラグを読み込み、ローカルキャッシュで評価する SDK
+type Client struct {
+	rds     *redis.Redis
+	key     string
+	refresh time.Duration
+
+	mu        sync.RWMutex
+	flags     map[string]Flag
+	version   string
+	loaded    bool
+	listeners []Listener
+
+	stopOnce sync.Once
+	done     chan struct{}
+}
+
+// NewClient 新しいクライアントを作成し、バックグラウンドで変更の監視を開始する
+func NewClient(rds *redis.Redis, c Conf) *Client {
+	refresh := time.Duration(c.RefreshSeconds) * time.Second
+	if refresh <= 0 {
+		refresh = 10 * time.Second
+	}
+
+	client := &Client{
+		rds:     rds,
+		key:     c.Key,
+		refresh: refresh,
+		flags:   make(map[string]Flag),
+		done:    make(chan struct{}),
+	}
+	client.Refresh(context.Background())
+	threading.GoSafe(client.watch)
+
+	return client
+}
+
+// OnChange フラグ変更時の通知先を登録
+func (c *Client) OnChange(listener Listener) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.listeners = append(c.listeners, listener)
+}
+
+// Enabled フラグを評価する（未定義のフラグは無効）
+func (c *Client) Enabled(key string, ec EvalContext) bool {
+	c.mu.RLock()
+	flag, ok := c.flags[key]
+	c.mu.RUnlock()
+
+	return ok && flag.Evaluate(ec)
+}
+
+// Evaluate 全フラグを評価する
+func (c *Client) Evaluate(ec EvalContext) map[string]bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	result := make(map[string]bool, len(c.flags))
+	for key, flag := range c.flags {
+		result[key] = flag.Evaluate(ec)
+	}
+
+	return result
+}
+
+// Flags キャッシュしているフラグ一覧（キー順）
+func (c *Client) Flags() []Flag {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	flags := make([]Flag, 0, len(c.flags))
+	for _, flag := range c.flags {
+		flags = append(flags, flag)
+	}
+	sort.Slice(flags, func(i, j int) bool {
+		return flags[i].Key < flags[j].Key
+	})
+
+	return flags
+}
+
+// Refresh バージョンが変わっていればフラグを再読込し、通知先へ知らせる
+// Redis に接続できない場合は直前のキャッシュを使い続ける
+func (c *Client) Refresh(ctx context.Context) {
+	version, err := c.rds.GetCtx(ctx, versionKey(c.key))
+	if err != nil {
+		logx.WithContext(ctx).Errorf("フィーチャーフラグのバージョン取得に失敗しました: %v", err)
+		return
+	}
+
+	c.mu.RLock()
+	unchanged := c.loaded && c.version == version
+	c.mu.RUnlock()
+	if unchanged {
+		return
+	}
+
+	flags, err := load(ctx, c.rds, c.key)
+	if err != nil {
+		logx.WithContext(ctx).Errorf("フィーチャーフラグの読み込みに失敗しました: %v", err)
+		return
+	}
+
+	snapshot := make(map[string]Flag, len(flags))
+	for _, flag := range flags {
+		snapshot[flag.Key] = flag
+	}
+
+	c.mu.Lock()
+	c.flags = snapshot
+	c.version = version
+	c.loaded = true
+	listeners := append([]Listener(nil), c.listeners...)
+	c.mu.Unlock()
+
+	for _, listener := range listeners {
+		threading.RunSafe(func() {
+			listener(snapshot)
+		})
+	}
+}
+
+// Close 変更の監視を停止
+func (c *Client) Close() {
+	c.stopOnce.Do(func() {
+		close(c.done)
+	})
+}
+
+func (c *Client) watch() {
+	ticker := time.NewTicker(c.refresh)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-ticker.C:
+			c.Refresh(context.Background())
+		}
+	}
+}
@@ -0,0 +1,85 @@
+// Package featureflag フィーチャーフラグの評価と、各サービスが使う SDK
+//
+// フラグの正本は user_service の MySQL にあり、変更の度に Redis へ全件を書き出す（Publish）。
+// 各サービスは Client で Redis からフラグを読み込み、ローカルで評価する。
+package featureflag
+
+import (
+	"hash/fnv"
+	"regexp"
+	"slices"
+	"strconv"
+)
+
+// Flag フィーチャーフラグ
+// Enabled が false の場合は常に無効。有効な場合はターゲティングルールに一致するか、
+// 段階的ロールアウトの対象であれば有効と評価される
+type Flag struct {
+	Key               string `json:"key"`
+	Description       string `json:"description,omitempty"`
+	Enabled           bool   `json:"enabled"`
+	Rules             Rules  `json:"rules"`
+	RolloutPercentage int    `json:"rollout_percentage"` // 0-100（100=ルールに一致しない全員も対象）
+}
+
+// Rules ターゲティングルール（いずれかに一致すれば有効）
+type Rules struct {
+	UserIds []int64  `json:"user_ids,omitempty"`
+	Roles   []string `json:"roles,omitempty"`
+	OrgIds  []int64  `json:"org_ids,omitempty"`
+}
+
+// EvalContext 評価対象のユーザー情報（未ログインの場合は UserId=0）
+type EvalContext struct {
+	UserId int64
+	Roles  []string
+	OrgIds []int64
+}
+
+var keyPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_.-]{0,99}$`)
+
+// ValidKey フラグキーとして使える文字列か（英小文字・数字・_ . -、100文字以内）
+func ValidKey(key string) bool {
+	return keyPattern.MatchString(key)
+}
+
+// Evaluate フラグを評価する
+func (f Flag) Evaluate(ec EvalContext) bool {
+	if !f.Enabled {
+		return false
+	}
+
+	if ec.UserId > 0 && slices.Contains(f.Rules.UserIds, ec.UserId) {
+		return true
+	}
+	for _, role := range ec.Roles {
+		if slices.Contains(f.Rules.Roles, role) {
+			return true
+		}
+	}
+	for _, orgId := range ec.OrgIds {
+		if slices.Contains(f.Rules.OrgIds, orgId) {
+			return true
+		}
+	}
+
+	if f.RolloutPercentage >= 100 {
+		return true
+	}
+	if f.RolloutPercentage <= 0 || ec.UserId <= 0 {
+		return false
+	}
+
+	return bucket(f.Key, ec.UserId) < f.RolloutPercentage
+}
+
+// bucket フラグキーとユーザーIDから 0-99 のバケットを求める
+// 同じユーザーは常に同じバケットになり、ロールアウト率を上げても既存の対象者は外れない
+func bucket(key string, userId int64) int {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	h.Write([]byte{':'})
+	h.Write([]byte(strconv.FormatInt(userId, 10)))
+
+	return int(h.Sum32() % 100)
+}
@@ -0,0 +1,51 @@
+package featureflag
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/zeromicro/go-zero/core/stores/redis"
+)
+
+// Conf SDK の設定
+type Conf struct {
+	Key            string `json:",default=winyx:feature_flags"` // フラグ一覧を保存する Redis キー
+	RefreshSeconds int    `json:",default=10"`                  // 変更の確認間隔
+}
+
+func versionKey(key string) string {
+	return key + ":version"
+}
+
+// Publish フラグ一覧を Redis に書き出し、バージョンを進めて各サービスへ変更を通知する
+func Publish(ctx context.Context, rds *redis.Redis, key string, flags []Flag) error {
+	if flags == nil {
+		flags = []Flag{}
+	}
+
+	val, err := json.Marshal(flags)
+	if err != nil {
+		return err
+	}
+
+	if err := rds.SetCtx(ctx, key, string(val)); err != nil {
+		return err
+	}
+
+	_, err = rds.IncrCtx(ctx, versionKey(key))
+	return err
+}
+
+func load(ctx context.Context, rds *redis.Redis, key string) ([]Flag, error) {
+	val, err := rds.GetCtx(ctx, key)
+	if err != nil || val == "" {
+		return nil, err
+	}
+
+	var flags []Flag
+	if err := json.Unmarshal([]byte(val), &flags); err != nil {
+		return nil, err
+	}
+
+	return flags, nil
+}
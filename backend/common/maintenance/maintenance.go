@@ -0,0 +1,129 @@
+// Package maintenance Redis に保存したメンテナンス期間の管理と、期間中のリクエストを 503 で返すミドルウェア
+package maintenance
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/stores/redis"
+)
+
+const DefaultMessage = "現在メンテナンス中です。しばらくしてから再度お試しください。"
+
+// Conf メンテナンスモードの設定
+type Conf struct {
+	Key               string   `json:",default=winyx:maintenance"` // メンテナンス期間を保存する Redis キー
+	RefreshSeconds    int      `json:",default=5"`                 // ミドルウェアが Redis を再読込する間隔
+	RetryAfterSeconds int      `json:",default=300"`               // 終了時刻未定の場合に返す Retry-After
+	AllowPaths        []string `json:",optional"`                  // メンテナンス中も通過させるパス（前方一致）
+}
+
+// Window メンテナンス期間
+// Enabled が true で、現在時刻が [ScheduledStart, ScheduledEnd) に含まれる間メンテナンス中となる
+// ScheduledStart / ScheduledEnd が 0 の場合はそれぞれ即時開始 / 終了時刻未定を表す
+type Window struct {
+	Enabled        bool   `json:"enabled"`
+	Message        string `json:"message,omitempty"`
+	ScheduledStart int64  `json:"scheduled_start,omitempty"`
+	ScheduledEnd   int64  `json:"scheduled_end,omitempty"`
+	UpdatedAt      int64  `json:"updated_at,omitempty"`
+}
+
+var ErrInvalidWindow = errors.New("scheduled_end must be after scheduled_start")
+
+// Validate 期間の整合性をチェック
+func (w Window) Validate() error {
+	if w.ScheduledStart > 0 && w.ScheduledEnd > 0 && w.ScheduledEnd <= w.ScheduledStart {
+		return ErrInvalidWindow
+	}
+
+	return nil
+}
+
+// ActiveAt 指定時刻がメンテナンス期間中か
+func (w Window) ActiveAt(t time.Time) bool {
+	if !w.Enabled {
+		return false
+	}
+
+	now := t.Unix()
+	if w.ScheduledStart > 0 && now < w.ScheduledStart {
+		return false
+	}
+	if w.ScheduledEnd > 0 && now >= w.ScheduledEnd {
+		return false
+	}
+
+	return true
+}
+
+// DisplayMessage 利用者に返すメッセージ（未設定の場合は既定の文言）
+func (w Window) DisplayMessage() string {
+	if w.Message == "" {
+		return DefaultMessage
+	}
+
+	return w.Message
+}
+
+// Store メンテナンス期間の Redis ストア
+type Store struct {
+	rds *redis.Redis
+	key string
+}
+
+// NewStore 新しいストアを作成
+func NewStore(rds *redis.Redis, key string) *Store {
+	return &Store{
+		rds: rds,
+		key: key,
+	}
+}
+
+// Get 保存されているメンテナンス期間を取得（未設定の場合はゼロ値）
+func (s *Store) Get(ctx context.Context) (Window, error) {
+	var w Window
+
+	val, err := s.rds.GetCtx(ctx, s.key)
+	if err != nil || val == "" {
+		return w, err
+	}
+
+	if err := json.Unmarshal([]byte(val), &w); err != nil {
+		return Window{}, err
+	}
+
+	return w, nil
+}
+
+// Save メンテナンス期間を保存
+// 終了時刻が設定されている場合は、終了時刻で自動的に消えるよう有効期限を付ける
+func (s *Store) Save(ctx context.Context, w Window) error {
+	if err := w.Validate(); err != nil {
+		return err
+	}
+
+	w.UpdatedAt = time.Now().Unix()
+	val, err := json.Marshal(w)
+	if err != nil {
+		return err
+	}
+
+	if w.ScheduledEnd > 0 {
+		ttl := w.ScheduledEnd - w.UpdatedAt
+		if ttl <= 0 {
+			return s.Clear(ctx)
+		}
+		return s.rds.SetexCtx(ctx, s.key, string(val), int(ttl))
+	}
+
+	return s.rds.SetCtx(ctx, s.key, string(val))
+}
+
+// Clear メンテナンス期間を削除
+func (s *Store) Clear(ctx context.Context) error {
+	_, err := s.rds.DelCtx(ctx, s.key)
+	return err
+}
@@ -0,0 +1,114 @@
+package maintenance
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+// ヘルスチェック・メトリクス収集・バージョン確認は常に通過させる
+var defaultAllowPaths = []string{"/health", "/metrics", "/version"}
+
+// BypassFunc メンテナンス中でも通過させるリクエストか（管理者判定など）
+type BypassFunc func(r *http.Request) bool
+
+// Middleware メンテナンス期間中のリクエストを 503 で返すミドルウェア
+type Middleware struct {
+	store      *Store
+	conf       Conf
+	bypass     BypassFunc
+	allowPaths []string
+
+	mu        sync.Mutex
+	window    Window
+	fetchedAt time.Time
+}
+
+// NewMiddleware 新しいミドルウェアを作成
+// bypass が nil の場合は許可パス以外を全て遮断する
+func NewMiddleware(store *Store, c Conf, bypass BypassFunc) *Middleware {
+	return &Middleware{
+		store:      store,
+		conf:       c,
+		bypass:     bypass,
+		allowPaths: append(append([]string(nil), defaultAllowPaths...), c.AllowPaths...),
+	}
+}
+
+// maintenanceRes メンテナンス中のレスポンス
+type maintenanceRes struct {
+	Code         int    `json:"code"`
+	Message      string `json:"message"`
+	ScheduledEnd int64  `json:"scheduled_end,omitempty"`
+}
+
+// Handle rest.Middleware として server.Use に渡す
+func (m *Middleware) Handle(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if m.allowed(r.URL.Path) {
+			next(w, r)
+			return
+		}
+
+		now := time.Now()
+		window := m.current(r)
+		if !window.ActiveAt(now) || (m.bypass != nil && m.bypass(r)) {
+			next(w, r)
+			return
+		}
+
+		w.Header().Set("Retry-After", strconv.Itoa(m.retryAfter(window, now)))
+		httpx.WriteJsonCtx(r.Context(), w, http.StatusServiceUnavailable, maintenanceRes{
+			Code:         http.StatusServiceUnavailable,
+			Message:      window.DisplayMessage(),
+			ScheduledEnd: window.ScheduledEnd,
+		})
+	}
+}
+
+// current メンテナンス期間を取得（RefreshSeconds の間はローカルにキャッシュ）
+// Redis の取得に失敗した場合は直前の値を使い続ける
+func (m *Middleware) current(r *http.Request) Window {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	refresh := time.Duration(m.conf.RefreshSeconds) * time.Second
+	if !m.fetchedAt.IsZero() && time.Since(m.fetchedAt) < refresh {
+		return m.window
+	}
+
+	window, err := m.store.Get(r.Context())
+	if err != nil {
+		logx.WithContext(r.Context()).Errorf("メンテナンス情報の取得に失敗しました: %v", err)
+	} else {
+		m.window = window
+	}
+	m.fetchedAt = time.Now()
+
+	return m.window
+}
+
+func (m *Middleware) allowed(path string) bool {
+	for _, prefix := range m.allowPaths {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *Middleware) retryAfter(window Window, now time.Time) int {
+	if window.ScheduledEnd > 0 {
+		remaining := time.Unix(window.ScheduledEnd, 0).Sub(now).Seconds()
+		return max(int(math.Ceil(remaining)), 1)
+	}
+
+	return max(m.conf.RetryAfterSeconds, 1)
+}
@@ -0,0 +1,72 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/stores/cache"
+	"github.com/zeromicro/go-zero/core/syncx"
+)
+
+var singleFlights = syncx.NewSingleFlight()
+
+// NewCache sqlc.CachedConn 用のキャッシュを作成し、ヒット/ミスを name ラベルで記録する
+// sqlc.NewConnWithCache と組み合わせて使う
+func NewCache(name string, c cache.CacheConf, opts ...cache.Option) cache.Cache {
+	return &instrumentedCache{
+		Cache: cache.New(c, singleFlights, cache.NewStat(name), sql.ErrNoRows, opts...),
+		name:  name,
+	}
+}
+
+// instrumentedCache Take/Get の結果からヒット/ミスを判定する
+// Take 系はクエリ関数が呼ばれた場合をミスとみなす
+type instrumentedCache struct {
+	cache.Cache
+	name string
+}
+
+func (c *instrumentedCache) Get(key string, val any) error {
+	return c.GetCtx(context.Background(), key, val)
+}
+
+func (c *instrumentedCache) GetCtx(ctx context.Context, key string, val any) error {
+	err := c.Cache.GetCtx(ctx, key, val)
+	if err == nil || c.IsNotFound(err) {
+		ObserveCache(c.name, err == nil)
+	}
+
+	return err
+}
+
+func (c *instrumentedCache) Take(val any, key string, query func(val any) error) error {
+	return c.TakeCtx(context.Background(), val, key, query)
+}
+
+func (c *instrumentedCache) TakeCtx(ctx context.Context, val any, key string, query func(val any) error) error {
+	var queried bool
+	err := c.Cache.TakeCtx(ctx, val, key, func(v any) error {
+		queried = true
+		return query(v)
+	})
+	ObserveCache(c.name, !queried)
+
+	return err
+}
+
+func (c *instrumentedCache) TakeWithExpire(val any, key string, query func(val any, expire time.Duration) error) error {
+	return c.TakeWithExpireCtx(context.Background(), val, key, query)
+}
+
+func (c *instrumentedCache) TakeWithExpireCtx(ctx context.Context, val any, key string,
+	query func(val any, expire time.Duration) error) error {
+	var queried bool
+	err := c.Cache.TakeWithExpireCtx(ctx, val, key, func(v any, expire time.Duration) error {
+		queried = true
+		return query(v, expire)
+	})
+	ObserveCache(c.name, !queried)
+
+	return err
+}
@@ -0,0 +1,50 @@
+package metrics
+
+const (
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+
+	// サービスの状態を記録した箇所（レジストリ / ダッシュボードの監視）
+	SourceRegistry  = "registry"
+	SourceDashboard = "dashboard"
+)
+
+// ObserveCache キャッシュのヒット/ミスを記録
+func ObserveCache(cache string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	cacheRequests.Inc(cache, result)
+}
+
+// LoginSucceeded ログイン成功を記録
+func LoginSucceeded() {
+	loginAttempts.Inc(ResultSuccess, "")
+}
+
+// LoginFailed ログイン失敗を理由（not_found / inactive / bad_password / error）付きで記録
+func LoginFailed(reason string) {
+	loginAttempts.Inc(ResultFailure, reason)
+}
+
+// SetServiceHealth サービスのヘルス状態を記録
+// status は healthy/up を 1、degraded を 0.5、それ以外を 0 として扱う
+func SetServiceHealth(source, service, status string, latencyMs int64) {
+	var value float64
+	switch status {
+	case "healthy", "up":
+		value = 1
+	case "degraded":
+		value = 0.5
+	}
+
+	serviceUp.WithLabelValues(source, service).Set(value)
+	serviceLatency.WithLabelValues(source, service).Set(float64(latencyMs))
+}
+
+// DeleteServiceHealth 登録解除したサービスの系列を削除
+func DeleteServiceHealth(source, service string) {
+	serviceUp.DeleteLabelValues(source, service)
+	serviceLatency.DeleteLabelValues(source, service)
+}
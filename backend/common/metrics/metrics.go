@@ -0,0 +1,75 @@
+// Package metrics 各サービス共通の Prometheus メトリクス定義
+//
+// HTTP リクエスト（http_server_requests_*）と DB コネクションプール（sql_client_*）は
+// go-zero 組み込みのメトリクスをそのまま利用し、それ以外は winyx_ 名前空間で定義する。
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/zeromicro/go-zero/core/metric"
+	zprometheus "github.com/zeromicro/go-zero/core/prometheus"
+	"github.com/zeromicro/go-zero/rest"
+)
+
+const (
+	Namespace = "winyx"
+
+	// Path メトリクス公開用のパス
+	Path = "/metrics"
+)
+
+var (
+	cacheRequests = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: Namespace,
+		Subsystem: "cache",
+		Name:      "requests_total",
+		Help:      "model cache lookups by result (hit/miss).",
+		Labels:    []string{"cache", "result"},
+	})
+
+	loginAttempts = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: Namespace,
+		Subsystem: "auth",
+		Name:      "login_total",
+		Help:      "login attempts by result and failure reason.",
+		Labels:    []string{"result", "reason"},
+	})
+
+	// 登録解除したサービスの系列を削除できるよう、ヘルス系は client_golang を直接使う
+	serviceUp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Subsystem: "service",
+		Name:      "up",
+		Help:      "service health state (1=healthy, 0.5=degraded, 0=unhealthy).",
+	}, []string{"source", "service"})
+
+	serviceLatency = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Subsystem: "service",
+		Name:      "check_latency_ms",
+		Help:      "latency of the latest health check in milliseconds.",
+	}, []string{"source", "service"})
+)
+
+func init() {
+	prometheus.MustRegister(serviceUp, serviceLatency)
+}
+
+// Handler go-zero のメトリクス収集を有効化し、テキスト形式で出力するハンドラを返す
+func Handler() http.HandlerFunc {
+	zprometheus.Enable()
+
+	return promhttp.Handler().ServeHTTP
+}
+
+// RegisterHandler サーバーに GET /metrics を登録する
+func RegisterHandler(server *rest.Server) {
+	server.AddRoute(rest.Route{
+		Method:  http.MethodGet,
+		Path:    Path,
+		Handler: Handler(),
+	})
+}
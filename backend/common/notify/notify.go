@@ -0,0 +1,52 @@
+// Package notify 招待メールなど利用者宛ての通知を送る差し替え可能な通知チャネル
+package notify
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// Conf 通知チャネルの設定
+type Conf struct {
+	Type string `json:",default=log,options=log"` // log: 送信内容をログに出力する（開発・検証用）
+}
+
+// Message 1件の通知
+type Message struct {
+	To      string // 宛先（メールアドレス）
+	Subject string
+	Body    string
+}
+
+// Notifier 通知の送信先
+type Notifier interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// NewNotifier 設定から通知チャネルを作成
+func NewNotifier(c Conf) (Notifier, error) {
+	switch c.Type {
+	case "", "log":
+		return LogNotifier{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported notifier type %q", c.Type)
+	}
+}
+
+// MustNewNotifier NewNotifier の失敗時に終了する版
+func MustNewNotifier(c Conf) Notifier {
+	n, err := NewNotifier(c)
+	logx.Must(err)
+
+	return n
+}
+
+// LogNotifier 通知を送信せずログに書き出す
+type LogNotifier struct{}
+
+func (LogNotifier) Send(ctx context.Context, msg Message) error {
+	logx.WithContext(ctx).Infof("Notification to %s: %s\n%s", msg.To, msg.Subject, msg.Body)
+	return nil
+}
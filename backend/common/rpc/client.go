@@ -7,6 +7,7 @@ import (
     "crypto/sha256"
     "encoding/hex"
     "encoding/json"
+    "errors"
     "fmt"
     "io"
     "net/http"
@@ -14,7 +15,6 @@ import (
     
     "github.com/zeromicro/go-zero/core/breaker"
     "github.com/zeromicro/go-zero/core/logx"
-    "github.com/zeromicro/go-zero/core/retry"
 )
 
 // ServiceClient マイクロサービス間通信用クライアント
@@ -44,12 +44,32 @@ func NewServiceClient(serviceName, baseURL, secret string) *ServiceClient {
     }
 }
 
+// maxRetries サービス呼び出しの最大試行回数
+const maxRetries = 3
+
 // CallService サービスを呼び出し
 func (c *ServiceClient) CallService(ctx context.Context, method, path string, request, response interface{}) error {
-    // リトライポリシー
-    return retry.Do(ctx, retry.WithMax(3), func(ctx context.Context, attempt int) error {
-        return c.doCall(ctx, method, path, request, response)
-    })
+    // リトライポリシー（4xxはリトライしない）
+    var err error
+    for attempt := 1; attempt <= maxRetries; attempt++ {
+        err = c.doCall(ctx, method, path, request, response)
+        if err == nil {
+            return nil
+        }
+
+        var svcErr *ServiceError
+        if errors.As(err, &svcErr) && svcErr.Code < http.StatusInternalServerError {
+            return err
+        }
+
+        select {
+        case <-ctx.Done():
+            return ctx.Err()
+        case <-time.After(time.Duration(attempt) * 100 * time.Millisecond):
+        }
+    }
+
+    return err
 }
 
 // doCall 実際のサービス呼び出し
@@ -68,7 +88,7 @@ func (c *ServiceClient) doCall(ctx context.Context, method, path string, request
     
     // サーキットブレーカーでラップ
     var respData []byte
-    err := c.breaker.Do(url, func() error {
+    err := c.breaker.Do(func() error {
         req, err := http.NewRequestWithContext(ctx, method, url, body)
         if err != nil {
             return err
@@ -130,8 +150,13 @@ func (c *ServiceClient) setHeaders(req *http.Request) {
 
 // generateSignature HMAC署名を生成
 func (c *ServiceClient) generateSignature(timestamp string) string {
-    h := hmac.New(sha256.New, []byte(c.secret))
-    h.Write([]byte(c.serviceName + ":" + timestamp))
+    return sign(c.secret, c.serviceName, timestamp)
+}
+
+// sign サービス名とタイムスタンプに対する HMAC-SHA256 署名
+func sign(secret, serviceName, timestamp string) string {
+    h := hmac.New(sha256.New, []byte(secret))
+    h.Write([]byte(serviceName + ":" + timestamp))
     return hex.EncodeToString(h.Sum(nil))
 }
 
@@ -205,6 +230,23 @@ func (c *UserServiceClient) CheckPermission(ctx context.Context, userID int64, r
     return &resp, nil
 }
 
+// CheckOrgPermission 組織スコープの権限をチェック（permission は "resource:action"）
+func (c *UserServiceClient) CheckOrgPermission(ctx context.Context, userID, orgID int64, permission string) (*CheckPermissionResponse, error) {
+    req := &CheckOrgPermissionRequest{
+        UserID:     userID,
+        OrgID:      orgID,
+        Permission: permission,
+    }
+
+    var resp CheckPermissionResponse
+    err := c.CallService(ctx, http.MethodPost, "/internal/v1/orgs/permission", req, &resp)
+    if err != nil {
+        return nil, err
+    }
+
+    return &resp, nil
+}
+
 // リクエスト/レスポンス型定義
 
 type ValidateUserRequest struct {
@@ -219,11 +261,20 @@ type ValidateUserResponse struct {
 }
 
 type UserBasicInfo struct {
-    UserID int64    `json:"user_id"`
-    Name   string   `json:"name"`
-    Email  string   `json:"email"`
-    Status string   `json:"status"`
-    Roles  []string `json:"roles"`
+    UserID int64               `json:"user_id"`
+    Name   string              `json:"name"`
+    Email  string              `json:"email"`
+    Status string              `json:"status"`
+    Roles  []string            `json:"roles"`
+    Orgs   []OrgMembershipInfo `json:"orgs"`
+}
+
+// OrgMembershipInfo 所属組織と組織内ロール
+type OrgMembershipInfo struct {
+    OrgID       int64    `json:"org_id"`
+    OrgName     string   `json:"org_name"`
+    Role        string   `json:"role"`
+    Permissions []string `json:"permissions"`
 }
 
 type CheckPermissionRequest struct {
@@ -235,4 +286,10 @@ type CheckPermissionRequest struct {
 type CheckPermissionResponse struct {
     Allowed bool   `json:"allowed"`
     Reason  string `json:"reason,omitempty"`
-}
\ No newline at end of file
+}
+
+type CheckOrgPermissionRequest struct {
+    UserID     int64  `json:"user_id"`
+    OrgID      int64  `json:"org_id"`
+    Permission string `json:"permission"`
+}
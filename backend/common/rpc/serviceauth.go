@@ -0,0 +1,85 @@
+package rpc
+
+import (
+	"crypto/hmac"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+var (
+	ErrMissingServiceAuth = errors.New("missing service auth headers")
+	ErrInvalidSignature   = errors.New("invalid service signature")
+	ErrTimestampSkew      = errors.New("service auth timestamp out of range")
+)
+
+// ServiceAuthConf サービス間認証（/internal/v1 を提供する側）の設定
+type ServiceAuthConf struct {
+	Secret         string
+	MaxSkewSeconds int `json:",default=300"` // 署名時刻と受信時刻の許容差
+}
+
+// VerifyRequest ServiceClient が付与した X-Service-* ヘッダーの署名と時刻を検証
+func VerifyRequest(r *http.Request, secret string, maxSkew time.Duration) error {
+	serviceName := r.Header.Get("X-Service-Name")
+	timestamp := r.Header.Get("X-Timestamp")
+	signature := r.Header.Get("X-Service-Auth")
+	if serviceName == "" || timestamp == "" || signature == "" {
+		return ErrMissingServiceAuth
+	}
+
+	signedAt, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		return ErrTimestampSkew
+	}
+	if skew := time.Since(signedAt); skew > maxSkew || skew < -maxSkew {
+		return ErrTimestampSkew
+	}
+
+	if !hmac.Equal([]byte(signature), []byte(sign(secret, serviceName, timestamp))) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+// ServiceAuth 署名を検証できないリクエストを 401 で拒否するミドルウェア
+type ServiceAuth struct {
+	secret  string
+	maxSkew time.Duration
+}
+
+// NewServiceAuth 新しいサービス間認証ミドルウェアを作成
+func NewServiceAuth(c ServiceAuthConf) *ServiceAuth {
+	return &ServiceAuth{
+		secret:  c.Secret,
+		maxSkew: time.Duration(c.MaxSkewSeconds) * time.Second,
+	}
+}
+
+// Handle rest.Middleware として利用する
+// シークレット未設定の場合は全ての内部リクエストを拒否する
+func (m *ServiceAuth) Handle(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		err := ErrInvalidSignature
+		if m.secret != "" {
+			err = VerifyRequest(r, m.secret, m.maxSkew)
+		} else {
+			logx.WithContext(r.Context()).Error("Service auth secret is not configured")
+		}
+
+		if err != nil {
+			logx.WithContext(r.Context()).Infof("Rejected internal request from %q: %v", r.Header.Get("X-Service-Name"), err)
+			httpx.WriteJsonCtx(r.Context(), w, http.StatusUnauthorized, &ServiceError{
+				Code:    http.StatusUnauthorized,
+				Message: err.Error(),
+			})
+			return
+		}
+
+		next(w, r)
+	}
+}
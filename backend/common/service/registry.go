@@ -3,9 +3,12 @@ package service
 import (
     "context"
     "fmt"
+    "net/http"
     "sync"
     "time"
     
+    "github.com/winyx/backend/common/metrics"
+    
     "github.com/zeromicro/go-zero/core/logx"
 )
 
@@ -54,6 +57,7 @@ func (r *ServiceRegistry) Register(service *ServiceInfo) error {
     service.LastCheck = time.Now()
     
     r.services[service.Name] = service
+    metrics.SetServiceHealth(metrics.SourceRegistry, service.Name, service.Status, 0)
     logx.Infof("Service registered: %s at %s:%d", service.Name, service.Host, service.Port)
     
     return nil
@@ -69,6 +73,7 @@ func (r *ServiceRegistry) Deregister(serviceName string) error {
     }
     
     delete(r.services, serviceName)
+    metrics.DeleteServiceHealth(metrics.SourceRegistry, serviceName)
     logx.Infof("Service deregistered: %s", serviceName)
     
     return nil
@@ -148,7 +153,9 @@ func (r *ServiceRegistry) checkService(service *ServiceInfo) {
     ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
     defer cancel()
     
+    start := time.Now()
     healthy := r.checker.Check(ctx, service)
+    latency := time.Since(start)
     
     r.mutex.Lock()
     defer r.mutex.Unlock()
@@ -161,6 +168,7 @@ func (r *ServiceRegistry) checkService(service *ServiceInfo) {
             s.Status = "unhealthy"
             logx.Errorf("Service %s is unhealthy", service.Name)
         }
+        metrics.SetServiceHealth(metrics.SourceRegistry, s.Name, s.Status, latency.Milliseconds())
     }
 }
 
@@ -0,0 +1,80 @@
+package tenant
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/winyx/backend/common/auth"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+// MemberFunc ユーザーが組織のメンバーであれば組織内ロールを返す
+// メンバーでない場合は ErrNotMember を返す
+type MemberFunc func(ctx context.Context, orgId, userId int64) (role string, err error)
+
+// Middleware リクエストで指定された組織を操作対象として解決し、所属を確認してからコンテキストに格納する
+// JWT 認証の後段で使う。組織の指定が無い・所属していないリクエストは handler に渡さない
+type Middleware struct {
+	member MemberFunc
+}
+
+// NewMiddleware 新しいミドルウェアを作成
+func NewMiddleware(member MemberFunc) *Middleware {
+	return &Middleware{member: member}
+}
+
+// errorRes テナント解決エラーのレスポンス
+type errorRes struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Handle rest.WithMiddlewares に渡す
+func (m *Middleware) Handle(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userId, ok := auth.UserIdFromContext(r.Context())
+		if !ok {
+			writeError(w, r, http.StatusUnauthorized, "認証情報が不正です")
+			return
+		}
+
+		orgId, err := ResolveOrgId(r)
+		switch {
+		case errors.Is(err, ErrNotMember):
+			writeError(w, r, http.StatusForbidden, err.Error())
+			return
+		case err != nil:
+			writeError(w, r, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		role, err := m.member(r.Context(), orgId, userId)
+		switch {
+		case errors.Is(err, ErrNotMember):
+			logx.WithContext(r.Context()).Infof("Tenant access denied: user %d is not a member of org %d", userId, orgId)
+			writeError(w, r, http.StatusForbidden, ErrNotMember.Error())
+			return
+		case err != nil:
+			logx.WithContext(r.Context()).Errorf("Failed to resolve tenant org %d for user %d: %v", orgId, userId, err)
+			writeError(w, r, http.StatusServiceUnavailable, "組織情報の取得に失敗しました")
+			return
+		}
+
+		ctx := NewContext(r.Context(), Tenant{
+			OrgId:  orgId,
+			UserId: userId,
+			Role:   role,
+		})
+		next(w, r.WithContext(ctx))
+	}
+}
+
+func writeError(w http.ResponseWriter, r *http.Request, code int, message string) {
+	httpx.WriteJsonCtx(r.Context(), w, code, errorRes{
+		Code:    code,
+		Message: message,
+	})
+}
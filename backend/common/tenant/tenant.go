@@ -0,0 +1,79 @@
+// Package tenant リクエスト毎の操作対象組織（テナント）をコンテキストで受け渡す
+package tenant
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/winyx/backend/common/auth"
+)
+
+// HeaderOrgId 操作対象の組織を指定するヘッダー（JWT の org_id クレームより優先）
+const HeaderOrgId = "X-Org-Id"
+
+var (
+	ErrOrgRequired = errors.New("操作対象の組織が指定されていません")
+	ErrInvalidOrg  = errors.New("組織IDが不正です")
+	// ErrNotMember 組織が存在しない・削除済み・所属していないのいずれか（区別せずに拒否する）
+	ErrNotMember = errors.New("この組織へのアクセス権限がありません")
+)
+
+// Tenant 認証済みユーザーと、所属を確認済みの操作対象組織
+type Tenant struct {
+	OrgId  int64
+	UserId int64
+	Role   string // 組織内ロール（owner / admin / member / viewer）
+}
+
+type contextKey struct{}
+
+// NewContext テナントを格納したコンテキストを返す
+func NewContext(ctx context.Context, t Tenant) context.Context {
+	return context.WithValue(ctx, contextKey{}, t)
+}
+
+// FromContext テナントミドルウェアが格納したテナントを取得
+func FromContext(ctx context.Context) (Tenant, bool) {
+	t, ok := ctx.Value(contextKey{}).(Tenant)
+	return t, ok && t.OrgId > 0
+}
+
+// OrgIdFromContext 操作対象の組織IDを取得
+func OrgIdFromContext(ctx context.Context) (int64, bool) {
+	t, ok := FromContext(ctx)
+	return t.OrgId, ok
+}
+
+// ResolveOrgId リクエストで指定された組織IDを取得（所属の確認は行わない）
+// X-Org-Id ヘッダーを優先し、無ければ JWT の org_id クレームを使う
+// API キーで認証されたリクエストはキーの組織に固定され、別の組織を指定すると ErrNotMember を返す
+func ResolveOrgId(r *http.Request) (int64, error) {
+	if _, ok := auth.ApiKeyIdFromContext(r.Context()); ok {
+		orgId, ok := auth.OrgIdFromContext(r.Context())
+		if !ok {
+			return 0, ErrNotMember
+		}
+		header := strings.TrimSpace(r.Header.Get(HeaderOrgId))
+		if header != "" && header != strconv.FormatInt(orgId, 10) {
+			return 0, ErrNotMember
+		}
+		return orgId, nil
+	}
+
+	if header := strings.TrimSpace(r.Header.Get(HeaderOrgId)); header != "" {
+		orgId, err := strconv.ParseInt(header, 10, 64)
+		if err != nil || orgId <= 0 {
+			return 0, ErrInvalidOrg
+		}
+		return orgId, nil
+	}
+
+	if orgId, ok := auth.OrgIdFromContext(r.Context()); ok {
+		return orgId, nil
+	}
+
+	return 0, ErrOrgRequired
+}
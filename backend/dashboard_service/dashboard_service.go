@@ -4,6 +4,8 @@ import (
 	"flag"
 	"fmt"
 
+	"github.com/winyx/backend/common/buildinfo"
+	"github.com/winyx/backend/common/metrics"
 	"github.com/winyx/backend/dashboard_service/internal/config"
 	"github.com/winyx/backend/dashboard_service/internal/handler"
 	"github.com/winyx/backend/dashboard_service/internal/svc"
@@ -24,8 +26,13 @@ func main() {
 	defer server.Stop()
 
 	ctx := svc.NewServiceContext(c)
+	ctx.Start()
+	defer ctx.Stop()
 	handler.RegisterHandlers(server, ctx)
+	metrics.RegisterHandler(server)
+	buildinfo.RegisterHandler(server)
 
-	fmt.Printf("Starting server at %s:%d...\n", c.Host, c.Port)
+	build := buildinfo.Get()
+	fmt.Printf("Starting server at %s:%d (version %s, commit %s)...\n", c.Host, c.Port, build.Version, build.ShortCommit())
 	server.Start()
 }
@@ -1,31 +0,0 @@
-package main
-
-import (
-	"flag"
-	"fmt"
-
-	"github.com/winyx/backend/dashboard_service/internal/config"
-	"github.com/winyx/backend/dashboard_service/internal/handler"
-	"github.com/winyx/backend/dashboard_service/internal/svc"
-
-	"github.com/zeromicro/go-zero/core/conf"
-	"github.com/zeromicro/go-zero/rest"
-)
-
-var configFile = flag.String("f", "etc/dashboardgateway-api.yaml", "the config file")
-
-func main() {
-	flag.Parse()
-
-	var c config.Config
-	conf.MustLoad(*configFile, &c)
-
-	server := rest.MustNewServer(c.RestConf)
-	defer server.Stop()
-
-	ctx := svc.NewServiceContext(c)
-	handler.RegisterHandlers(server, ctx)
-
-	fmt.Printf("Starting server at %s:%d...\n", c.Host, c.Port)
-	server.Start()
-}
@@ -0,0 +1,252 @@
+package alert
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/winyx/backend/dashboard_service/internal/config"
+	"github.com/winyx/backend/dashboard_service/internal/sampler"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/threading"
+)
+
+const (
+	StatePending  = "pending"
+	StateFiring   = "firing"
+	StateResolved = "resolved"
+)
+
+// Alert ルールとメトリクスの組み合わせ毎のアラート状態
+type Alert struct {
+	Rule        string    `json:"rule"`
+	Metric      string    `json:"metric"`
+	State       string    `json:"state"`
+	Severity    string    `json:"severity"`
+	Value       float64   `json:"value"`
+	Operator    string    `json:"operator"`
+	Threshold   float64   `json:"threshold"`
+	Description string    `json:"description,omitempty"`
+	ActiveAt    time.Time `json:"active_at"`
+	FiredAt     time.Time `json:"fired_at,omitzero"`
+	ResolvedAt  time.Time `json:"resolved_at,omitzero"`
+
+	lastNotified time.Time
+}
+
+// Key 重複排除に使うアラートの識別子
+func (a *Alert) Key() string {
+	return a.Rule + "/" + a.Metric
+}
+
+// Summary 通知用の1行サマリ
+func (a *Alert) Summary() string {
+	return fmt.Sprintf("[%s] %s %s: %s = %g (%s %g)",
+		a.Severity, a.Rule, a.State, a.Metric, a.Value, a.Operator, a.Threshold)
+}
+
+type rule struct {
+	config.AlertRule
+	compare        func(value, threshold float64) bool
+	forDuration    time.Duration
+	repeatInterval time.Duration
+}
+
+// Evaluator サンプラーのスナップショットに対してルールを評価する
+type Evaluator struct {
+	rules     []rule
+	notifiers map[string]Notifier
+
+	mu     sync.Mutex
+	active map[string]*Alert
+}
+
+// NewEvaluator 設定からアラート評価器を作成
+func NewEvaluator(c config.AlertingConf) (*Evaluator, error) {
+	notifiers := make(map[string]Notifier, len(c.Channels))
+	for _, channel := range c.Channels {
+		if _, ok := notifiers[channel.Name]; ok {
+			return nil, fmt.Errorf("duplicate alert channel: %s", channel.Name)
+		}
+		notifier, err := NewNotifier(channel)
+		if err != nil {
+			return nil, err
+		}
+		notifiers[channel.Name] = notifier
+	}
+
+	rules := make([]rule, 0, len(c.Rules))
+	for _, r := range c.Rules {
+		if r.Name == "" || r.Metric == "" {
+			return nil, fmt.Errorf("alert rule requires name and metric: %+v", r)
+		}
+		if _, err := path.Match(r.Metric, ""); err != nil {
+			return nil, fmt.Errorf("alert rule %s: invalid metric pattern: %w", r.Name, err)
+		}
+		if r.Operator == "" {
+			r.Operator = ">"
+		}
+		compare, ok := operators[r.Operator]
+		if !ok {
+			return nil, fmt.Errorf("alert rule %s: unknown operator %q", r.Name, r.Operator)
+		}
+		for _, name := range r.Channels {
+			if _, ok := notifiers[name]; !ok {
+				return nil, fmt.Errorf("alert rule %s: unknown channel %s", r.Name, name)
+			}
+		}
+
+		rules = append(rules, rule{
+			AlertRule:      r,
+			compare:        compare,
+			forDuration:    time.Duration(r.ForSeconds) * time.Second,
+			repeatInterval: time.Duration(r.RepeatIntervalSeconds) * time.Second,
+		})
+	}
+
+	return &Evaluator{
+		rules:     rules,
+		notifiers: notifiers,
+		active:    make(map[string]*Alert),
+	}, nil
+}
+
+var operators = map[string]func(value, threshold float64) bool{
+	">":  func(v, t float64) bool { return v > t },
+	">=": func(v, t float64) bool { return v >= t },
+	"<":  func(v, t float64) bool { return v < t },
+	"<=": func(v, t float64) bool { return v <= t },
+	"==": func(v, t float64) bool { return v == t },
+	"!=": func(v, t float64) bool { return v != t },
+}
+
+// Evaluate スナップショットを評価し、状態遷移に応じて通知する
+// sampler.Listener として登録して使う
+func (e *Evaluator) Evaluate(snapshot sampler.Snapshot) {
+	now := snapshot.Time
+
+	e.mu.Lock()
+	var notifications []notification
+	for _, r := range e.rules {
+		matched := make(map[string]bool)
+		for metric, value := range snapshot.Metrics {
+			if ok, _ := path.Match(r.Metric, metric); !ok {
+				continue
+			}
+			matched[metric] = true
+			if n, ok := e.evaluateLocked(r, metric, value, r.compare(value, r.Threshold), now); ok {
+				notifications = append(notifications, n)
+			}
+		}
+
+		// 今回のスナップショットに含まれないメトリクスは条件不成立として扱う
+		for key, a := range e.active {
+			if a.Rule == r.Name && !matched[a.Metric] {
+				if n, ok := e.evaluateLocked(r, a.Metric, a.Value, false, now); ok {
+					notifications = append(notifications, n)
+				}
+				delete(e.active, key)
+			}
+		}
+	}
+	e.mu.Unlock()
+
+	for _, n := range notifications {
+		e.dispatch(n)
+	}
+}
+
+type notification struct {
+	alert    Alert
+	channels []string
+}
+
+func (e *Evaluator) evaluateLocked(r rule, metric string, value float64, breached bool, now time.Time) (notification, bool) {
+	key := r.Name + "/" + metric
+	a, exists := e.active[key]
+
+	if !breached {
+		if !exists {
+			return notification{}, false
+		}
+		delete(e.active, key)
+		if a.State != StateFiring {
+			// pending のまま回復した場合は通知しない
+			return notification{}, false
+		}
+		a.State = StateResolved
+		a.Value = value
+		a.ResolvedAt = now
+		return notification{alert: *a, channels: r.Channels}, true
+	}
+
+	if !exists {
+		a = &Alert{
+			Rule:        r.Name,
+			Metric:      metric,
+			State:       StatePending,
+			Severity:    r.Severity,
+			Operator:    r.Operator,
+			Threshold:   r.Threshold,
+			Description: r.Description,
+			ActiveAt:    now,
+		}
+		e.active[key] = a
+	}
+	a.Value = value
+
+	switch a.State {
+	case StatePending:
+		if now.Sub(a.ActiveAt) < r.forDuration {
+			return notification{}, false
+		}
+		a.State = StateFiring
+		a.FiredAt = now
+	case StateFiring:
+		if r.repeatInterval <= 0 || now.Sub(a.lastNotified) < r.repeatInterval {
+			return notification{}, false
+		}
+	}
+	a.lastNotified = now
+
+	return notification{alert: *a, channels: r.Channels}, true
+}
+
+func (e *Evaluator) dispatch(n notification) {
+	channels := n.channels
+	if len(channels) == 0 {
+		for name := range e.notifiers {
+			channels = append(channels, name)
+		}
+	}
+
+	logx.Infof("Alert %s", n.alert.Summary())
+	for _, name := range channels {
+		notifier := e.notifiers[name]
+		threading.GoSafe(func() {
+			if err := notifier.Notify(context.Background(), n.alert); err != nil {
+				logx.Errorf("Failed to send alert %s via %s: %v", n.alert.Key(), name, err)
+			}
+		})
+	}
+}
+
+// Active pending / firing 中のアラート一覧
+func (e *Evaluator) Active() []Alert {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	alerts := make([]Alert, 0, len(e.active))
+	for _, a := range e.active {
+		alerts = append(alerts, *a)
+	}
+	sort.Slice(alerts, func(i, j int) bool {
+		return alerts[i].Key() < alerts[j].Key()
+	})
+
+	return alerts
+}
@@ -0,0 +1,145 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/winyx/backend/dashboard_service/internal/config"
+)
+
+// Notifier アラート通知チャネル
+type Notifier interface {
+	Notify(ctx context.Context, alert Alert) error
+}
+
+// NewNotifier チャネル設定から通知チャネルを作成
+func NewNotifier(c config.AlertChannel) (Notifier, error) {
+	timeout := time.Duration(c.TimeoutMs) * time.Millisecond
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+
+	switch c.Type {
+	case "webhook", "slack":
+		if c.Url == "" {
+			return nil, fmt.Errorf("alert channel %s: url is required", c.Name)
+		}
+		return &webhookNotifier{
+			url:    c.Url,
+			slack:  c.Type == "slack",
+			client: &http.Client{Timeout: timeout},
+		}, nil
+	case "email":
+		if c.Smtp.Host == "" || c.Smtp.From == "" || len(c.Smtp.To) == 0 {
+			return nil, fmt.Errorf("alert channel %s: smtp host, from and to are required", c.Name)
+		}
+		return &emailNotifier{conf: c.Smtp, timeout: timeout}, nil
+	default:
+		return nil, fmt.Errorf("alert channel %s: unsupported type %q", c.Name, c.Type)
+	}
+}
+
+// webhookNotifier 汎用Webhook（アラートをそのままJSONでPOST）とSlack互換Webhook
+type webhookNotifier struct {
+	url    string
+	slack  bool
+	client *http.Client
+}
+
+func (n *webhookNotifier) Notify(ctx context.Context, alert Alert) error {
+	var payload any = alert
+	if n.slack {
+		payload = map[string]string{"text": slackText(alert)}
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func slackText(alert Alert) string {
+	icon := ":warning:"
+	switch {
+	case alert.State == StateResolved:
+		icon = ":white_check_mark:"
+	case alert.Severity == "critical":
+		icon = ":rotating_light:"
+	}
+
+	text := icon + " " + alert.Summary()
+	if alert.Description != "" {
+		text += "\n" + alert.Description
+	}
+
+	return text
+}
+
+// emailNotifier SMTP経由のメール通知
+type emailNotifier struct {
+	conf    config.SmtpConf
+	timeout time.Duration
+}
+
+func (n *emailNotifier) Notify(ctx context.Context, alert Alert) error {
+	addr := net.JoinHostPort(n.conf.Host, strconv.Itoa(n.conf.Port))
+
+	var auth smtp.Auth
+	if n.conf.Username != "" {
+		auth = smtp.PlainAuth("", n.conf.Username, n.conf.Password, n.conf.Host)
+	}
+
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", n.conf.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(n.conf.To, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", alert.Summary())
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	fmt.Fprintf(&msg, "Rule: %s\r\nMetric: %s\r\nState: %s\r\nSeverity: %s\r\nValue: %g\r\nThreshold: %s %g\r\n",
+		alert.Rule, alert.Metric, alert.State, alert.Severity, alert.Value, alert.Operator, alert.Threshold)
+	if alert.Description != "" {
+		fmt.Fprintf(&msg, "\r\n%s\r\n", alert.Description)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, n.timeout)
+	defer cancel()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- smtp.SendMail(addr, auth, n.conf.From, n.conf.To, []byte(msg.String()))
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
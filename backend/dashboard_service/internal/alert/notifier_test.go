@@ -0,0 +1,182 @@
+package alert
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/winyx/backend/dashboard_service/internal/config"
+)
+
+// received スタブが受け取ったリクエスト
+type received struct {
+	method      string
+	contentType string
+	body        []byte
+}
+
+// newStub 受け取ったリクエストを記録し、status を返すローカルの Webhook
+func newStub(t *testing.T, status int) (*httptest.Server, <-chan received) {
+	t.Helper()
+	ch := make(chan received, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		ch <- received{method: r.Method, contentType: r.Header.Get("Content-Type"), body: body}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, ch
+}
+
+func newTestNotifier(t *testing.T, c config.AlertChannel) Notifier {
+	t.Helper()
+	n, err := NewNotifier(c)
+	if err != nil {
+		t.Fatalf("NewNotifier: %v", err)
+	}
+	return n
+}
+
+func testAlert() Alert {
+	return Alert{
+		Rule:        "high_error_rate",
+		Metric:      "user_service.error_rate",
+		State:       StateFiring,
+		Severity:    "critical",
+		Value:       0.25,
+		Operator:    ">",
+		Threshold:   0.1,
+		Description: "エラー率が上昇しています",
+		ActiveAt:    time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+}
+
+func TestWebhookNotifierPostsAlertJSON(t *testing.T) {
+	srv, ch := newStub(t, http.StatusOK)
+	n := newTestNotifier(t, config.AlertChannel{Name: "hook", Type: "webhook", Url: srv.URL, TimeoutMs: 1000})
+
+	alert := testAlert()
+	if err := n.Notify(context.Background(), alert); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+
+	req := <-ch
+	if req.method != http.MethodPost {
+		t.Errorf("method = %s, want POST", req.method)
+	}
+	if req.contentType != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", req.contentType)
+	}
+	var got Alert
+	if err := json.Unmarshal(req.body, &got); err != nil {
+		t.Fatalf("payload is not an alert: %v (%s)", err, req.body)
+	}
+	if got.Rule != alert.Rule || got.Metric != alert.Metric || got.State != alert.State ||
+		got.Severity != alert.Severity || got.Value != alert.Value || got.Threshold != alert.Threshold ||
+		got.Description != alert.Description || !got.ActiveAt.Equal(alert.ActiveAt) {
+		t.Errorf("payload = %+v, want %+v", got, alert)
+	}
+}
+
+func TestSlackNotifierPostsText(t *testing.T) {
+	tests := []struct {
+		name  string
+		alert func(a *Alert)
+		icon  string
+	}{
+		{name: "critical", alert: func(a *Alert) {}, icon: ":rotating_light:"},
+		{name: "warning", alert: func(a *Alert) { a.Severity = "warning" }, icon: ":warning:"},
+		{name: "resolved", alert: func(a *Alert) { a.State = StateResolved }, icon: ":white_check_mark:"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, ch := newStub(t, http.StatusOK)
+			n := newTestNotifier(t, config.AlertChannel{Name: "slack", Type: "slack", Url: srv.URL, TimeoutMs: 1000})
+
+			alert := testAlert()
+			tt.alert(&alert)
+			if err := n.Notify(context.Background(), alert); err != nil {
+				t.Fatalf("Notify: %v", err)
+			}
+
+			var payload map[string]string
+			if err := json.Unmarshal((<-ch).body, &payload); err != nil {
+				t.Fatalf("payload is not JSON: %v", err)
+			}
+			if len(payload) != 1 {
+				t.Errorf("payload has keys other than text: %v", payload)
+			}
+			want := tt.icon + " " + alert.Summary() + "\n" + alert.Description
+			if payload["text"] != want {
+				t.Errorf("text = %q, want %q", payload["text"], want)
+			}
+		})
+	}
+}
+
+func TestWebhookNotifierErrorStatus(t *testing.T) {
+	srv, ch := newStub(t, http.StatusInternalServerError)
+	n := newTestNotifier(t, config.AlertChannel{Name: "hook", Type: "webhook", Url: srv.URL, TimeoutMs: 1000})
+
+	err := n.Notify(context.Background(), testAlert())
+	if err == nil || !strings.Contains(err.Error(), "500") {
+		t.Fatalf("Notify error = %v, want status 500", err)
+	}
+	<-ch
+}
+
+func TestWebhookNotifierTimeout(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	t.Cleanup(srv.Close)
+	t.Cleanup(func() { close(release) })
+	n := newTestNotifier(t, config.AlertChannel{Name: "hook", Type: "webhook", Url: srv.URL, TimeoutMs: 50})
+
+	start := time.Now()
+	if err := n.Notify(context.Background(), testAlert()); err == nil {
+		t.Fatal("Notify succeeded against a stalled webhook")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Notify took %s, want it to give up after the 50ms timeout", elapsed)
+	}
+}
+
+func TestWebhookNotifierUnreachable(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	url := srv.URL
+	srv.Close()
+	n := newTestNotifier(t, config.AlertChannel{Name: "hook", Type: "webhook", Url: url, TimeoutMs: 1000})
+
+	if err := n.Notify(context.Background(), testAlert()); err == nil {
+		t.Fatal("Notify succeeded against a closed server")
+	}
+}
+
+func TestNewNotifierValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		conf config.AlertChannel
+	}{
+		{name: "webhook without url", conf: config.AlertChannel{Name: "hook", Type: "webhook"}},
+		{name: "slack without url", conf: config.AlertChannel{Name: "slack", Type: "slack"}},
+		{name: "email without smtp", conf: config.AlertChannel{Name: "mail", Type: "email"}},
+		{name: "unsupported type", conf: config.AlertChannel{Name: "pager", Type: "pager", Url: "http://localhost"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewNotifier(tt.conf); err == nil {
+				t.Error("NewNotifier accepted an invalid channel")
+			}
+		})
+	}
+}
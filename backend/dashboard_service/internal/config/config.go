@@ -1,14 +1,38 @@
 package config
 
-import "github.com/zeromicro/go-zero/rest"
+import (
+	"github.com/winyx/backend/common/featureflag"
+	"github.com/winyx/backend/common/maintenance"
+
+	"github.com/zeromicro/go-zero/rest"
+)
 
 type Config struct {
 	rest.RestConf
-	DataSource       string                `json:",optional"`
-	Redis            RedisConf             `json:",optional"`
-	MonitoringTargets []MonitoringTarget   `json:",optional"`
-	Metrics          MetricsConf          `json:",optional"`
-	System           SystemConf           `json:",optional"`
+	Auth              AuthConf
+	UserService       UserServiceConf    `json:",optional"`
+	DataSource        string             `json:",optional"`
+	Redis             RedisConf          `json:",optional"`
+	MonitoringTargets []MonitoringTarget `json:",optional"`
+	HealthCheck       HealthCheckConf    `json:",optional"`
+	Metrics           MetricsConf        `json:",optional"`
+	Alerting          AlertingConf       `json:",optional"`
+	Stream            StreamConf         `json:",optional"`
+	Maintenance       maintenance.Conf   `json:",optional"`
+	FeatureFlags      featureflag.Conf   `json:",optional"`
+	System            SystemConf         `json:",optional"`
+}
+
+// AuthConf user_service が発行した JWT を検証するための設定（user_service の Auth と同じ値）
+type AuthConf struct {
+	AccessSecret string
+}
+
+// UserServiceConf 権限確認（/internal/v1/users/permission）の呼び出し先
+type UserServiceConf struct {
+	Url                    string `json:",default=http://localhost:8888"`
+	Secret                 string `json:",optional"`   // user_service の ServiceAuth.Secret と同じ値
+	PermissionCacheSeconds int    `json:",default=60"` // 権限確認結果のキャッシュ期間
 }
 
 type RedisConf struct {
@@ -16,10 +40,21 @@ type RedisConf struct {
 	Type string `json:",default=node"`
 }
 
+// MonitoringTarget 監視対象サービスの設定
+// Type はプローブ方式を表す: rest（HTTP GET）/ rpc・grpc（gRPC Health v1）/ tcp（接続確認のみ）
 type MonitoringTarget struct {
-	Name string `json:",optional"`
-	Url  string `json:",optional"`
-	Type string `json:",optional"`
+	Name            string `json:",optional"`
+	Url             string `json:",optional"`
+	Type            string `json:",default=rest,options=rest|rpc|grpc|tcp"`
+	HealthPath      string `json:",default=/health"` // rest: ヘルスチェック用パス
+	GrpcService     string `json:",optional"`        // grpc: Health/Check に渡すサービス名（空=サーバー全体）
+	TimeoutMs       int64  `json:",default=3000"`    // ターゲット単位のタイムアウト
+	ExpectedVersion string `json:",optional"`        // 期待バージョン（不一致の場合 degraded）
+}
+
+// HealthCheckConf ヘルスチェック全体の設定
+type HealthCheckConf struct {
+	DeadlineMs int64 `json:",default=5000"` // 全ターゲットのチェックに掛けられる最大時間
 }
 
 type MetricsConf struct {
@@ -27,7 +62,54 @@ type MetricsConf struct {
 	SampleIntervalSeconds int `json:",default=60"`
 }
 
+// AlertingConf アラートルールと通知チャネルの設定
+type AlertingConf struct {
+	Rules    []AlertRule    `json:",optional"`
+	Channels []AlertChannel `json:",optional"`
+}
+
+// AlertRule 宣言的なアラートルール
+// Metric はサンプラーのメトリクス名で、"service.*.up" のようなワイルドカードも指定できる
+type AlertRule struct {
+	Name                  string
+	Metric                string
+	Operator              string   `json:",optional"` // > / >= / < / <= / == / !=（省略時は >）
+	Threshold             float64  `json:",optional"`
+	ForSeconds            int64    `json:",optional"` // 条件が継続してから firing にするまでの時間
+	Severity              string   `json:",default=warning,options=info|warning|critical"`
+	Channels              []string `json:",optional"` // 空の場合は全チャネルへ通知
+	RepeatIntervalSeconds int64    `json:",optional"` // firing 中の再通知間隔（0=再通知しない）
+	Description           string   `json:",optional"`
+}
+
+// AlertChannel アラート通知チャネル
+type AlertChannel struct {
+	Name      string
+	Type      string   `json:",options=webhook|slack|email"`
+	Url       string   `json:",optional"` // webhook / slack の送信先
+	TimeoutMs int64    `json:",default=5000"`
+	Smtp      SmtpConf `json:",optional"` // email の送信設定
+}
+
+// SmtpConf メール通知用のSMTP設定
+type SmtpConf struct {
+	Host     string   `json:",optional"`
+	Port     int      `json:",default=25"`
+	Username string   `json:",optional"`
+	Password string   `json:",optional"`
+	From     string   `json:",optional"`
+	To       []string `json:",optional"`
+}
+
+// StreamConf SSE / WebSocket によるメトリクス配信の設定
+type StreamConf struct {
+	MaxConnections   int `json:",default=100"` // 同時接続数の上限（0=無制限）
+	HeartbeatSeconds int `json:",default=15"`  // ハートビート間隔
+	BufferSize       int `json:",default=16"`  // クライアント毎の送信待ちイベント数
+	MaxDroppedEvents int `json:",default=32"`  // 破棄したイベントがこの数に達した遅いクライアントは切断する
+}
+
+// SystemConf バージョン・ビルド日時は common/buildinfo から取得する
 type SystemConf struct {
-	Version     string `json:",default=1.0.0"`
 	Environment string `json:",default=development"`
 }
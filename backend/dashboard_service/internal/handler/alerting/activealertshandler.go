@@ -1,18 +1,18 @@
-package config
+package alerting
 
 import (
 	"net/http"
 
-	"github.com/winyx/backend/dashboard_service/internal/logic/config"
+	"github.com/winyx/backend/dashboard_service/internal/logic/alerting"
 	"github.com/winyx/backend/dashboard_service/internal/svc"
 	"github.com/zeromicro/go-zero/rest/httpx"
 )
 
-// システム設定情報の取得
-func ConfigInfoHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+// 発生中アラートの取得
+func ActiveAlertsHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		l := config.NewConfigInfoLogic(r.Context(), svcCtx)
-		resp, err := l.ConfigInfo()
+		l := alerting.NewActiveAlertsLogic(r.Context(), svcCtx)
+		resp, err := l.ActiveAlerts()
 		if err != nil {
 			httpx.ErrorCtx(r.Context(), w, err)
 		} else {
@@ -8,11 +8,11 @@ import (
 	"github.com/zeromicro/go-zero/rest/httpx"
 )
 
-// システム全体のヘルスチェック
-func SystemHealthHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+// 認証不要の死活監視（監視対象・DBの状態は含めない）
+func LivenessHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		l := health.NewSystemHealthLogic(r.Context(), svcCtx)
-		resp, err := l.SystemHealth()
+		l := health.NewLivenessLogic(r.Context(), svcCtx)
+		resp, err := l.Liveness()
 		if err != nil {
 			httpx.ErrorCtx(r.Context(), w, err)
 		} else {
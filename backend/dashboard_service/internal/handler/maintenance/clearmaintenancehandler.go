@@ -0,0 +1,22 @@
+package maintenance
+
+import (
+	"net/http"
+
+	"github.com/winyx/backend/dashboard_service/internal/logic/maintenance"
+	"github.com/winyx/backend/dashboard_service/internal/svc"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+// メンテナンス期間の解除
+func ClearMaintenanceHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		l := maintenance.NewClearMaintenanceLogic(r.Context(), svcCtx)
+		resp, err := l.ClearMaintenance()
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.OkJsonCtx(r.Context(), w, resp)
+		}
+	}
+}
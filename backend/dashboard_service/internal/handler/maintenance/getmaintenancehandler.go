@@ -0,0 +1,22 @@
+package maintenance
+
+import (
+	"net/http"
+
+	"github.com/winyx/backend/dashboard_service/internal/logic/maintenance"
+	"github.com/winyx/backend/dashboard_service/internal/svc"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+// メンテナンス設定の取得
+func GetMaintenanceHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		l := maintenance.NewGetMaintenanceLogic(r.Context(), svcCtx)
+		resp, err := l.GetMaintenance()
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.OkJsonCtx(r.Context(), w, resp)
+		}
+	}
+}
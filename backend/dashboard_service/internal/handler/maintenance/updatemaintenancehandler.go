@@ -1,25 +1,25 @@
-package stats
+package maintenance
 
 import (
 	"net/http"
 
-	"github.com/winyx/backend/dashboard_service/internal/logic/stats"
+	"github.com/winyx/backend/dashboard_service/internal/logic/maintenance"
 	"github.com/winyx/backend/dashboard_service/internal/svc"
 	"github.com/winyx/backend/dashboard_service/internal/types"
 	"github.com/zeromicro/go-zero/rest/httpx"
 )
 
-// API使用統計の取得
-func ApiStatsHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+// メンテナンス期間の登録・切り替え
+func UpdateMaintenanceHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		var req types.ApiStatsReq
+		var req types.MaintenanceReq
 		if err := httpx.Parse(r, &req); err != nil {
 			httpx.ErrorCtx(r.Context(), w, err)
 			return
 		}
 
-		l := stats.NewApiStatsLogic(r.Context(), svcCtx)
-		resp, err := l.ApiStats(&req)
+		l := maintenance.NewUpdateMaintenanceLogic(r.Context(), svcCtx)
+		resp, err := l.UpdateMaintenance(&req)
 		if err != nil {
 			httpx.ErrorCtx(r.Context(), w, err)
 		} else {
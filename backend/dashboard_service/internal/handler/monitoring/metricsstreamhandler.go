@@ -0,0 +1,56 @@
+package monitoring
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/winyx/backend/dashboard_service/internal/logic/monitoring"
+	"github.com/winyx/backend/dashboard_service/internal/stream"
+	"github.com/winyx/backend/dashboard_service/internal/svc"
+	"github.com/winyx/backend/dashboard_service/internal/types"
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+// メトリクス・ヘルス変化のストリーミング配信（SSE）
+func MetricsStreamHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return serveMetricsStream(svcCtx, stream.ServeSSE)
+}
+
+// メトリクス・ヘルス変化のストリーミング配信（WebSocket）
+func MetricsStreamWsHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return serveMetricsStream(svcCtx, stream.ServeWebSocket)
+}
+
+type streamWriter func(w http.ResponseWriter, r *http.Request, sub *stream.Subscription, heartbeat time.Duration) error
+
+func serveMetricsStream(svcCtx *svc.ServiceContext, write streamWriter) http.HandlerFunc {
+	heartbeat := time.Duration(max(svcCtx.Config.Stream.HeartbeatSeconds, 1)) * time.Second
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.MetricsStreamReq
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		l := monitoring.NewMetricsStreamLogic(r.Context(), svcCtx)
+		sub, err := l.MetricsStream(&req)
+		if errors.Is(err, stream.ErrTooManyConnections) {
+			w.Header().Set("Retry-After", "30")
+			httpx.WriteJsonCtx(r.Context(), w, http.StatusServiceUnavailable, map[string]string{
+				"message": err.Error(),
+			})
+			return
+		} else if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+		defer sub.Close()
+
+		if err := write(w, r, sub, heartbeat); err != nil {
+			logx.WithContext(r.Context()).Infof("Stream client disconnected: %v", err)
+		}
+	}
+}
@@ -6,8 +6,10 @@ package handler
 import (
 	"net/http"
 
+	alerting "github.com/winyx/backend/dashboard_service/internal/handler/alerting"
 	config "github.com/winyx/backend/dashboard_service/internal/handler/config"
 	health "github.com/winyx/backend/dashboard_service/internal/handler/health"
+	maintenance "github.com/winyx/backend/dashboard_service/internal/handler/maintenance"
 	monitoring "github.com/winyx/backend/dashboard_service/internal/handler/monitoring"
 	stats "github.com/winyx/backend/dashboard_service/internal/handler/stats"
 	"github.com/winyx/backend/dashboard_service/internal/svc"
@@ -19,48 +21,153 @@ func RegisterHandlers(server *rest.Server, serverCtx *svc.ServiceContext) {
 	server.AddRoutes(
 		[]rest.Route{
 			{
-				// システム設定情報の取得
+				// 認証不要の死活監視（監視対象・DBの状態は含めない）
 				Method:  http.MethodGet,
-				Path:    "/config",
-				Handler: config.ConfigInfoHandler(serverCtx),
+				Path:    "/health",
+				Handler: health.LivenessHandler(serverCtx),
 			},
 		},
+	)
+
+	server.AddRoutes(
+		rest.WithMiddlewares(
+			[]rest.Middleware{serverCtx.Authz},
+			[]rest.Route{
+				{
+					// 発生中アラートの取得
+					Method:  http.MethodGet,
+					Path:    "/alerts",
+					Handler: alerting.ActiveAlertsHandler(serverCtx),
+				},
+			}...,
+		),
+		rest.WithJwt(serverCtx.Config.Auth.AccessSecret),
 		rest.WithPrefix("/api/dashboard"),
 	)
 
 	server.AddRoutes(
-		[]rest.Route{
-			{
-				// システム全体のヘルスチェック
-				Method:  http.MethodGet,
-				Path:    "/health",
-				Handler: health.SystemHealthHandler(serverCtx),
-			},
-		},
+		rest.WithMiddlewares(
+			[]rest.Middleware{serverCtx.Authz},
+			[]rest.Route{
+				{
+					// システム設定情報の取得
+					Method:  http.MethodGet,
+					Path:    "/config",
+					Handler: config.ConfigInfoHandler(serverCtx),
+				},
+			}...,
+		),
+		rest.WithJwt(serverCtx.Config.Auth.AccessSecret),
 		rest.WithPrefix("/api/dashboard"),
 	)
 
 	server.AddRoutes(
-		[]rest.Route{
-			{
-				// リアルタイムメトリクスの取得
-				Method:  http.MethodGet,
-				Path:    "/metrics/realtime",
-				Handler: monitoring.RealtimeMetricsHandler(serverCtx),
-			},
-		},
+		rest.WithMiddlewares(
+			[]rest.Middleware{serverCtx.Authz},
+			[]rest.Route{
+				{
+					// システム全体のヘルスチェック
+					Method:  http.MethodGet,
+					Path:    "/health",
+					Handler: health.SystemHealthHandler(serverCtx),
+				},
+			}...,
+		),
+		rest.WithJwt(serverCtx.Config.Auth.AccessSecret),
 		rest.WithPrefix("/api/dashboard"),
 	)
 
 	server.AddRoutes(
-		[]rest.Route{
-			{
-				// API使用統計の取得
-				Method:  http.MethodGet,
-				Path:    "/stats",
-				Handler: stats.ApiStatsHandler(serverCtx),
-			},
-		},
+		rest.WithMiddlewares(
+			[]rest.Middleware{serverCtx.Authz},
+			[]rest.Route{
+				{
+					// リアルタイムメトリクスの取得
+					Method:  http.MethodGet,
+					Path:    "/metrics/realtime",
+					Handler: monitoring.RealtimeMetricsHandler(serverCtx),
+				},
+			}...,
+		),
+		rest.WithJwt(serverCtx.Config.Auth.AccessSecret),
+		rest.WithPrefix("/api/dashboard"),
+	)
+
+	server.AddRoutes(
+		rest.WithMiddlewares(
+			[]rest.Middleware{serverCtx.Authz},
+			[]rest.Route{
+				{
+					// メンテナンス設定の取得
+					Method:  http.MethodGet,
+					Path:    "/maintenance",
+					Handler: maintenance.GetMaintenanceHandler(serverCtx),
+				},
+				{
+					// メンテナンス期間の登録・切り替え
+					Method:  http.MethodPut,
+					Path:    "/maintenance",
+					Handler: maintenance.UpdateMaintenanceHandler(serverCtx),
+				},
+				{
+					// メンテナンス期間の解除
+					Method:  http.MethodDelete,
+					Path:    "/maintenance",
+					Handler: maintenance.ClearMaintenanceHandler(serverCtx),
+				},
+			}...,
+		),
+		rest.WithJwt(serverCtx.Config.Auth.AccessSecret),
+		rest.WithPrefix("/api/dashboard"),
+	)
+
+	server.AddRoutes(
+		rest.WithMiddlewares(
+			[]rest.Middleware{serverCtx.Authz},
+			[]rest.Route{
+				{
+					// メトリクス・ヘルス変化のストリーミング配信（SSE）
+					Method:  http.MethodGet,
+					Path:    "/stream",
+					Handler: monitoring.MetricsStreamHandler(serverCtx),
+				},
+			}...,
+		),
+		rest.WithJwt(serverCtx.Config.Auth.AccessSecret),
+		rest.WithPrefix("/api/dashboard"),
+		rest.WithSSE(),
+	)
+
+	server.AddRoutes(
+		rest.WithMiddlewares(
+			[]rest.Middleware{serverCtx.Authz},
+			[]rest.Route{
+				{
+					// メトリクス・ヘルス変化のストリーミング配信（WebSocket）
+					Method:  http.MethodGet,
+					Path:    "/stream/ws",
+					Handler: monitoring.MetricsStreamWsHandler(serverCtx),
+				},
+			}...,
+		),
+		rest.WithJwt(serverCtx.Config.Auth.AccessSecret),
+		rest.WithPrefix("/api/dashboard"),
+		rest.WithTimeout(0),
+	)
+
+	server.AddRoutes(
+		rest.WithMiddlewares(
+			[]rest.Middleware{serverCtx.Authz},
+			[]rest.Route{
+				{
+					// API使用統計の取得
+					Method:  http.MethodGet,
+					Path:    "/stats",
+					Handler: stats.ApiStatsHandler(serverCtx),
+				},
+			}...,
+		),
+		rest.WithJwt(serverCtx.Config.Auth.AccessSecret),
 		rest.WithPrefix("/api/dashboard"),
 	)
 }
@@ -0,0 +1,54 @@
+package alerting
+
+import (
+	"context"
+	"time"
+
+	"github.com/winyx/backend/dashboard_service/internal/svc"
+	"github.com/winyx/backend/dashboard_service/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ActiveAlertsLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// 発生中アラートの取得
+func NewActiveAlertsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ActiveAlertsLogic {
+	return &ActiveAlertsLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ActiveAlertsLogic) ActiveAlerts() (resp *types.ActiveAlertsRes, err error) {
+	active := l.svcCtx.Alerts.Active()
+
+	alerts := make([]types.AlertInfo, 0, len(active))
+	for _, a := range active {
+		info := types.AlertInfo{
+			Rule:        a.Rule,
+			Metric:      a.Metric,
+			State:       a.State,
+			Severity:    a.Severity,
+			Value:       a.Value,
+			Operator:    a.Operator,
+			Threshold:   a.Threshold,
+			Description: a.Description,
+			ActiveAt:    a.ActiveAt.Unix(),
+		}
+		if !a.FiredAt.IsZero() {
+			info.FiredAt = a.FiredAt.Unix()
+		}
+		alerts = append(alerts, info)
+	}
+
+	return &types.ActiveAlertsRes{
+		Timestamp: time.Now().Unix(),
+		Alerts:    alerts,
+	}, nil
+}
@@ -2,9 +2,9 @@ package config
 
 import (
 	"context"
-	"runtime"
-	"time"
-
+	"github.com/winyx/backend/common/buildinfo"
+	"github.com/winyx/backend/common/featureflag"
+	"github.com/winyx/backend/dashboard_service/internal/logic/maintenance"
 	"github.com/winyx/backend/dashboard_service/internal/svc"
 	"github.com/winyx/backend/dashboard_service/internal/types"
 
@@ -27,20 +27,23 @@ func NewConfigInfoLogic(ctx context.Context, svcCtx *svc.ServiceContext) *Config
 }
 
 func (l *ConfigInfoLogic) ConfigInfo() (resp *types.ConfigRes, err error) {
+	// メンテナンス情報は Redis から取得（取得できない場合は無効として返す）
+	maintenanceInfo, err := maintenance.NewGetMaintenanceLogic(l.ctx, l.svcCtx).GetMaintenance()
+	if err != nil {
+		maintenanceInfo = &types.MaintenanceInfo{}
+	}
+
+	build := buildinfo.Summary()
+
 	return &types.ConfigRes{
 		Environment: l.svcCtx.Config.System.Environment,
-		Version:     l.svcCtx.Config.System.Version,
-		GoVersion:   runtime.Version(),
-		BuildTime:   time.Now().Format("2006-01-02 15:04:05"), // TODO: ビルド時の実際の時刻
-		Features: map[string]bool{
-			"monitoring":    true,
-			"metrics":       true,
-			"health_check":  true,
-			"api_stats":     true,
-		},
-		Maintenance: types.MaintenanceInfo{
-			Enabled: false,
-			Message: "",
-		},
+		Version:     build.Version,
+		Commit:      build.Commit,
+		Dirty:       build.Dirty,
+		GoVersion:   build.GoVersion,
+		BuildTime:   build.BuildTime,
+		// ダッシュボード全体の機能フラグはユーザー固有の条件なしで評価する
+		Features:    l.svcCtx.FeatureFlags.Evaluate(featureflag.EvalContext{}),
+		Maintenance: *maintenanceInfo,
 	}, nil
 }
@@ -0,0 +1,38 @@
+package health
+
+import (
+	"context"
+	"time"
+
+	"github.com/winyx/backend/common/buildinfo"
+	"github.com/winyx/backend/dashboard_service/internal/svc"
+	"github.com/winyx/backend/dashboard_service/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type LivenessLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// 認証不要の死活監視（監視対象・DBの状態は含めない）
+func NewLivenessLogic(ctx context.Context, svcCtx *svc.ServiceContext) *LivenessLogic {
+	return &LivenessLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *LivenessLogic) Liveness() (resp *types.LivenessRes, err error) {
+	build := buildinfo.Summary()
+
+	return &types.LivenessRes{
+		Status:    "ok",
+		Version:   build.Version,
+		Commit:    build.Commit,
+		Timestamp: time.Now().Unix(),
+	}, nil
+}
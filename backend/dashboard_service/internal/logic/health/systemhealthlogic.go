@@ -2,11 +2,11 @@ package health
 
 import (
 	"context"
-	"net/http"
 	"runtime"
 	"time"
 
-	"github.com/winyx/backend/dashboard_service/internal/config"
+	"github.com/winyx/backend/common/buildinfo"
+	"github.com/winyx/backend/dashboard_service/internal/probe"
 	"github.com/winyx/backend/dashboard_service/internal/svc"
 	"github.com/winyx/backend/dashboard_service/internal/types"
 
@@ -32,7 +32,8 @@ func (l *SystemHealthLogic) SystemHealth() (resp *types.SystemHealthRes, err err
 	startTime := time.Now()
 	
 	// サービス状態をチェック
-	services := l.checkServices()
+	results := l.svcCtx.Checker.CheckAll(l.ctx)
+	services := l.checkServices(results)
 	
 	// データベース状態をチェック
 	database := l.checkDatabase()
@@ -56,6 +57,7 @@ func (l *SystemHealthLogic) SystemHealth() (resp *types.SystemHealthRes, err err
 	}
 	
 	responseTime := time.Since(startTime).Milliseconds()
+	build := buildinfo.Summary()
 	
 	return &types.SystemHealthRes{
 		Status:       status,
@@ -64,54 +66,28 @@ func (l *SystemHealthLogic) SystemHealth() (resp *types.SystemHealthRes, err err
 		Database:     database,
 		Memory:       memory,
 		ResponseTime: responseTime,
+		Version:      build.Version,
+		Commit:       build.Commit,
+		VersionSkew:  probe.HasVersionSkew(build, results),
 	}, nil
 }
 
-func (l *SystemHealthLogic) checkServices() []types.ServiceStatus {
-	var services []types.ServiceStatus
-	
-	for _, target := range l.svcCtx.Config.MonitoringTargets {
-		status := l.checkSingleService(target)
-		services = append(services, status)
+func (l *SystemHealthLogic) checkServices(results []probe.Result) []types.ServiceStatus {
+	services := make([]types.ServiceStatus, 0, len(results))
+	for _, result := range results {
+		services = append(services, types.ServiceStatus{
+			Name:         result.Target.Name,
+			Status:       result.Status,
+			Port:         result.Port,
+			LastCheck:    result.CheckedAt.Unix(),
+			ResponseTime: result.Latency.Milliseconds(),
+			ErrorCount:   result.ErrorCount,
+			Version:      result.Version,
+			Commit:       result.Commit,
+		})
 	}
-	
-	return services
-}
 
-func (l *SystemHealthLogic) checkSingleService(target config.MonitoringTarget) types.ServiceStatus {
-	startTime := time.Now()
-	status := "up"
-	
-	client := &http.Client{
-		Timeout: 5 * time.Second,
-	}
-	
-	healthUrl := target.Url
-	if target.Type == "rest" {
-		healthUrl += "/health"
-	}
-	
-	resp, err := client.Get(healthUrl)
-	responseTime := time.Since(startTime).Milliseconds()
-	
-	if err != nil {
-		status = "down"
-	} else if resp.StatusCode >= 400 {
-		status = "degraded"
-	}
-	
-	if resp != nil {
-		resp.Body.Close()
-	}
-	
-	return types.ServiceStatus{
-		Name:         target.Name,
-		Status:       status,
-		LastCheck:    time.Now().Unix(),
-		ResponseTime: responseTime,
-		ErrorCount:   0, // TODO: Redisから取得
-		Version:      "1.0.0", // TODO: 実際のバージョンを取得
-	}
+	return services
 }
 
 func (l *SystemHealthLogic) checkDatabase() types.DatabaseStatus {
@@ -0,0 +1,38 @@
+package maintenance
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/winyx/backend/common/maintenance"
+	"github.com/winyx/backend/dashboard_service/internal/svc"
+	"github.com/winyx/backend/dashboard_service/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ClearMaintenanceLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// メンテナンス期間の解除
+func NewClearMaintenanceLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ClearMaintenanceLogic {
+	return &ClearMaintenanceLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ClearMaintenanceLogic) ClearMaintenance() (resp *types.MaintenanceInfo, err error) {
+	if err := l.svcCtx.Maintenance.Clear(l.ctx); err != nil {
+		l.Errorf("Failed to clear maintenance window: %v", err)
+		return nil, fmt.Errorf("メンテナンス情報の削除に失敗しました")
+	}
+
+	l.Infof("Maintenance window cleared")
+
+	return toMaintenanceInfo(maintenance.Window{}), nil
+}
@@ -0,0 +1,48 @@
+package maintenance
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/winyx/backend/common/maintenance"
+	"github.com/winyx/backend/dashboard_service/internal/svc"
+	"github.com/winyx/backend/dashboard_service/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetMaintenanceLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// メンテナンス設定の取得
+func NewGetMaintenanceLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetMaintenanceLogic {
+	return &GetMaintenanceLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *GetMaintenanceLogic) GetMaintenance() (resp *types.MaintenanceInfo, err error) {
+	window, err := l.svcCtx.Maintenance.Get(l.ctx)
+	if err != nil {
+		l.Errorf("Failed to load maintenance window: %v", err)
+		return nil, fmt.Errorf("メンテナンス情報の取得に失敗しました")
+	}
+
+	return toMaintenanceInfo(window), nil
+}
+
+func toMaintenanceInfo(window maintenance.Window) *types.MaintenanceInfo {
+	return &types.MaintenanceInfo{
+		Enabled:        window.Enabled,
+		Active:         window.ActiveAt(time.Now()),
+		Message:        window.Message,
+		ScheduledStart: window.ScheduledStart,
+		ScheduledEnd:   window.ScheduledEnd,
+	}
+}
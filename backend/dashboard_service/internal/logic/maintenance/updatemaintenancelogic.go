@@ -0,0 +1,50 @@
+package maintenance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/winyx/backend/common/maintenance"
+	"github.com/winyx/backend/dashboard_service/internal/svc"
+	"github.com/winyx/backend/dashboard_service/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type UpdateMaintenanceLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// メンテナンス期間の登録・切り替え
+func NewUpdateMaintenanceLogic(ctx context.Context, svcCtx *svc.ServiceContext) *UpdateMaintenanceLogic {
+	return &UpdateMaintenanceLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *UpdateMaintenanceLogic) UpdateMaintenance(req *types.MaintenanceReq) (resp *types.MaintenanceInfo, err error) {
+	window := maintenance.Window{
+		Enabled:        req.Enabled,
+		Message:        req.Message,
+		ScheduledStart: req.ScheduledStart,
+		ScheduledEnd:   req.ScheduledEnd,
+	}
+
+	if err := l.svcCtx.Maintenance.Save(l.ctx, window); err != nil {
+		if errors.Is(err, maintenance.ErrInvalidWindow) {
+			return nil, fmt.Errorf("終了時刻は開始時刻より後に設定してください")
+		}
+		l.Errorf("Failed to save maintenance window: %v", err)
+		return nil, fmt.Errorf("メンテナンス情報の保存に失敗しました")
+	}
+
+	l.Infof("Maintenance window updated: enabled=%t start=%d end=%d",
+		window.Enabled, window.ScheduledStart, window.ScheduledEnd)
+
+	return toMaintenanceInfo(window), nil
+}
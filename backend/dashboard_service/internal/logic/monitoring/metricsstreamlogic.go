@@ -0,0 +1,46 @@
+package monitoring
+
+import (
+	"context"
+
+	"github.com/winyx/backend/dashboard_service/internal/sampler"
+	"github.com/winyx/backend/dashboard_service/internal/stream"
+	"github.com/winyx/backend/dashboard_service/internal/svc"
+	"github.com/winyx/backend/dashboard_service/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type MetricsStreamLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// メトリクス・ヘルス変化のストリーミング配信
+func NewMetricsStreamLogic(ctx context.Context, svcCtx *svc.ServiceContext) *MetricsStreamLogic {
+	return &MetricsStreamLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// MetricsStream 配信ハブへ購読を登録する
+// 最新のスナップショットがあれば接続直後に初期値として送信される
+func (l *MetricsStreamLogic) MetricsStream(req *types.MetricsStreamReq) (*stream.Subscription, error) {
+	var latest *sampler.Snapshot
+	if snapshot, ok := l.svcCtx.Sampler.Latest(); ok {
+		latest = &snapshot
+	}
+
+	sub, err := l.svcCtx.Stream.Subscribe(stream.ParseFilter(req.Topics, req.Services), latest)
+	if err != nil {
+		l.Errorf("ストリーミング接続の登録に失敗しました: %v", err)
+		return nil, err
+	}
+
+	l.Infof("Stream client connected (%d connections)", l.svcCtx.Stream.Connections())
+
+	return sub, nil
+}
@@ -0,0 +1,110 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/winyx/backend/common/auth"
+	"github.com/winyx/backend/common/rpc"
+	"github.com/winyx/backend/dashboard_service/internal/config"
+
+	"github.com/zeromicro/go-zero/core/collection"
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+const (
+	// permissionResource ダッシュボードの権限は schema_dashboard_permissions.sql で定義
+	permissionResource = "dashboard"
+	actionView         = "view"
+	actionManage       = "manage"
+
+	checkTimeout = 3 * time.Second
+)
+
+// errorRes 認可エラーのレスポンス
+type errorRes struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// AuthzMiddleware JWT のユーザーがダッシュボードの権限を持つか user_service に確認する
+// 参照系（GET）は dashboard:view、更新系は dashboard:manage を要求する
+type AuthzMiddleware struct {
+	client *rpc.UserServiceClient
+	cache  *collection.Cache
+}
+
+// NewAuthzMiddleware 権限確認結果を PermissionCacheSeconds の間キャッシュするミドルウェアを作成
+func NewAuthzMiddleware(client *rpc.UserServiceClient, c config.UserServiceConf) *AuthzMiddleware {
+	cache, err := collection.NewCache(time.Duration(c.PermissionCacheSeconds)*time.Second,
+		collection.WithName("dashboard-permission"))
+	logx.Must(err)
+
+	return &AuthzMiddleware{
+		client: client,
+		cache:  cache,
+	}
+}
+
+func (m *AuthzMiddleware) Handle(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userId, ok := auth.UserIdFromContext(r.Context())
+		if !ok {
+			writeError(w, r, http.StatusUnauthorized, "認証情報が不正です")
+			return
+		}
+
+		action := actionView
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			action = actionManage
+		}
+
+		allowed, err := m.allowed(r.Context(), userId, action)
+		if err != nil {
+			logx.WithContext(r.Context()).Errorf("Failed to check %s:%s for user %d: %v",
+				permissionResource, action, userId, err)
+			writeError(w, r, http.StatusServiceUnavailable, "権限の確認に失敗しました")
+			return
+		}
+		if !allowed {
+			writeError(w, r, http.StatusForbidden, "ダッシュボードへのアクセス権限がありません")
+			return
+		}
+
+		next(w, r)
+	}
+}
+
+// allowed 権限確認の結果はキャッシュするが、呼び出しに失敗した場合はキャッシュしない
+func (m *AuthzMiddleware) allowed(ctx context.Context, userId int64, action string) (bool, error) {
+	key := fmt.Sprintf("%d:%s:%s", userId, permissionResource, action)
+	val, err := m.cache.Take(key, func() (any, error) {
+		ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+		defer cancel()
+
+		resp, err := m.client.CheckPermission(ctx, userId, permissionResource, action)
+		if err != nil {
+			return nil, err
+		}
+		if !resp.Allowed {
+			logx.WithContext(ctx).Infof("Dashboard access denied for user %d (%s): %s", userId, action, resp.Reason)
+		}
+
+		return resp.Allowed, nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return val.(bool), nil
+}
+
+func writeError(w http.ResponseWriter, r *http.Request, code int, message string) {
+	httpx.WriteJsonCtx(r.Context(), w, code, errorRes{
+		Code:    code,
+		Message: message,
+	})
+}
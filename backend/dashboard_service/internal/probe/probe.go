@@ -0,0 +1,304 @@
+package probe
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/winyx/backend/dashboard_service/internal/config"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	StatusUp       = "up"
+	StatusDown     = "down"
+	StatusDegraded = "degraded"
+
+	defaultTimeout  = 3 * time.Second
+	defaultDeadline = 5 * time.Second
+
+	// maxPayloadBytes ヘルスペイロードとして読み込む最大サイズ
+	maxPayloadBytes = 64 * 1024
+)
+
+var errDeadlineExceeded = errors.New("health check deadline exceeded")
+
+// Result 単一ターゲットのチェック結果
+type Result struct {
+	Target     config.MonitoringTarget
+	Status     string
+	Port       int
+	Latency    time.Duration
+	CheckedAt  time.Time
+	Version    string
+	Commit     string
+	ErrorCount int
+	Err        error
+}
+
+// healthPayload 監視対象が /health で返すJSON（HealthCheckRes互換）
+type healthPayload struct {
+	Status     string `json:"status"`
+	Version    string `json:"version"`
+	Commit     string `json:"commit"`
+	ErrorCount int    `json:"error_count"`
+}
+
+// Checker 監視対象サービスのヘルスチェッカー
+type Checker struct {
+	targets  []config.MonitoringTarget
+	deadline time.Duration
+	client   *http.Client
+
+	mu        sync.Mutex
+	grpcConns map[string]*grpc.ClientConn
+}
+
+// NewChecker 設定からヘルスチェッカーを作成
+func NewChecker(c config.Config) *Checker {
+	deadline := time.Duration(c.HealthCheck.DeadlineMs) * time.Millisecond
+	if deadline <= 0 {
+		deadline = defaultDeadline
+	}
+
+	return &Checker{
+		targets:  c.MonitoringTargets,
+		deadline: deadline,
+		// タイムアウトはリクエスト毎のcontextで制御する
+		client:    &http.Client{},
+		grpcConns: make(map[string]*grpc.ClientConn),
+	}
+}
+
+// Targets 監視対象の一覧
+func (c *Checker) Targets() []config.MonitoringTarget {
+	return c.targets
+}
+
+// CheckAll 全ターゲットを並列にチェックする
+// 全体のdeadlineを超えたターゲットは down として扱い、結果は設定順で返す
+func (c *Checker) CheckAll(ctx context.Context) []Result {
+	ctx, cancel := context.WithTimeout(ctx, c.deadline)
+	defer cancel()
+
+	results := make([]Result, len(c.targets))
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	finished := make([]bool, len(c.targets))
+
+	for i, target := range c.targets {
+		wg.Add(1)
+		go func(i int, target config.MonitoringTarget) {
+			defer wg.Done()
+			result := c.Check(ctx, target)
+			mu.Lock()
+			results[i] = result
+			finished[i] = true
+			mu.Unlock()
+		}(i, target)
+	}
+
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	for i, target := range c.targets {
+		if !finished[i] {
+			results[i] = Result{
+				Target:    target,
+				Status:    StatusDown,
+				Port:      portOf(target.Url),
+				Latency:   c.deadline,
+				CheckedAt: time.Now(),
+				Err:       errDeadlineExceeded,
+			}
+		}
+	}
+
+	return append([]Result(nil), results...)
+}
+
+// Check 単一ターゲットをチェックする
+func (c *Checker) Check(ctx context.Context, target config.MonitoringTarget) Result {
+	timeout := time.Duration(target.TimeoutMs) * time.Millisecond
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	var result Result
+	switch strings.ToLower(target.Type) {
+	case "rpc", "grpc":
+		result = c.checkGrpc(ctx, target)
+	case "tcp":
+		result = c.checkTcp(ctx, target)
+	default:
+		result = c.checkRest(ctx, target)
+	}
+
+	result.Target = target
+	result.Port = portOf(target.Url)
+	result.Latency = time.Since(start)
+	result.CheckedAt = time.Now()
+
+	if result.Status == StatusUp && target.ExpectedVersion != "" && result.Version != "" &&
+		result.Version != target.ExpectedVersion {
+		result.Status = StatusDegraded
+		result.Err = fmt.Errorf("version mismatch: expected %s, got %s", target.ExpectedVersion, result.Version)
+	}
+
+	if result.Err != nil {
+		logx.WithContext(ctx).Infof("Health check %s (%s) => %s: %v", target.Name, target.Type, result.Status, result.Err)
+	}
+
+	return result
+}
+
+// Close 保持しているgRPC接続を閉じる
+func (c *Checker) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for addr, conn := range c.grpcConns {
+		conn.Close()
+		delete(c.grpcConns, addr)
+	}
+}
+
+func (c *Checker) checkRest(ctx context.Context, target config.MonitoringTarget) Result {
+	healthUrl := strings.TrimRight(target.Url, "/") + target.HealthPath
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, healthUrl, nil)
+	if err != nil {
+		return Result{Status: StatusDown, Err: err}
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return Result{Status: StatusDown, Err: err}
+	}
+	defer resp.Body.Close()
+
+	result := Result{Status: StatusUp}
+	if resp.StatusCode >= http.StatusBadRequest {
+		result.Status = StatusDegraded
+		result.Err = fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	// ペイロードが無い/JSONでない場合もステータスコードのみで判定する
+	var payload healthPayload
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxPayloadBytes))
+	if err == nil && json.Unmarshal(body, &payload) == nil {
+		result.Version = payload.Version
+		result.Commit = payload.Commit
+		result.ErrorCount = payload.ErrorCount
+		switch strings.ToLower(payload.Status) {
+		case "degraded":
+			result.Status = StatusDegraded
+		case "unhealthy", "down":
+			result.Status = StatusDown
+		}
+	}
+
+	return result
+}
+
+func (c *Checker) checkGrpc(ctx context.Context, target config.MonitoringTarget) Result {
+	conn, err := c.grpcConn(hostPort(target.Url))
+	if err != nil {
+		return Result{Status: StatusDown, Err: err}
+	}
+
+	resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{
+		Service: target.GrpcService,
+	})
+	if err != nil {
+		return Result{Status: StatusDown, Err: err}
+	}
+
+	switch resp.GetStatus() {
+	case healthpb.HealthCheckResponse_SERVING:
+		return Result{Status: StatusUp}
+	case healthpb.HealthCheckResponse_NOT_SERVING:
+		return Result{Status: StatusDown, Err: errors.New("not serving")}
+	default:
+		return Result{Status: StatusDegraded, Err: fmt.Errorf("serving status %s", resp.GetStatus())}
+	}
+}
+
+func (c *Checker) checkTcp(ctx context.Context, target config.MonitoringTarget) Result {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", hostPort(target.Url))
+	if err != nil {
+		return Result{Status: StatusDown, Err: err}
+	}
+	conn.Close()
+
+	return Result{Status: StatusUp}
+}
+
+// grpcConn アドレス毎にgRPC接続を使い回す
+func (c *Checker) grpcConn(addr string) (*grpc.ClientConn, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if conn, ok := c.grpcConns[addr]; ok {
+		return conn, nil
+	}
+
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	c.grpcConns[addr] = conn
+
+	return conn, nil
+}
+
+// hostPort "grpc://host:port" や "http://host:port/path" から host:port を取り出す
+func hostPort(raw string) string {
+	if strings.Contains(raw, "://") {
+		if u, err := url.Parse(raw); err == nil {
+			return u.Host
+		}
+	}
+
+	return strings.TrimRight(raw, "/")
+}
+
+func portOf(raw string) int {
+	_, port, err := net.SplitHostPort(hostPort(raw))
+	if err != nil {
+		return 0
+	}
+
+	p, err := strconv.Atoi(port)
+	if err != nil {
+		return 0
+	}
+
+	return p
+}
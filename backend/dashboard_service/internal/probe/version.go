@@ -0,0 +1,28 @@
+package probe
+
+import "github.com/winyx/backend/common/buildinfo"
+
+// HasVersionSkew 自身と監視対象が報告したビルド（バージョン・コミット）が揃っていないか
+// バージョンを報告しない監視対象（gRPC / TCP など）は比較から除外する
+func HasVersionSkew(self buildinfo.Info, results []Result) bool {
+	builds := map[string]struct{}{
+		buildKey(self.Version, self.Commit): {},
+	}
+	for _, result := range results {
+		if result.Version == "" {
+			continue
+		}
+		builds[buildKey(result.Version, result.Commit)] = struct{}{}
+	}
+
+	return len(builds) > 1
+}
+
+// buildKey コミットを報告しない古いビルドはバージョンのみで比較する
+func buildKey(version, commit string) string {
+	if commit == "" {
+		return version
+	}
+
+	return version + "@" + commit
+}
@@ -0,0 +1,174 @@
+package sampler
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/winyx/backend/common/buildinfo"
+	"github.com/winyx/backend/dashboard_service/internal/probe"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/threading"
+)
+
+const (
+	StatusHealthy   = "healthy"
+	StatusDegraded  = "degraded"
+	StatusUnhealthy = "unhealthy"
+)
+
+// Snapshot 1回分のサンプリング結果
+// Metrics のキーはアラートルールから参照される（例: system.healthy, service.user_rpc.up）
+type Snapshot struct {
+	Time     time.Time
+	Status   string
+	Services []probe.Result
+	Metrics  map[string]float64
+}
+
+// Listener サンプリング毎に呼び出されるコールバック
+type Listener func(snapshot Snapshot)
+
+// Sampler 監視対象とランタイムの状態を定期的にサンプリングする
+type Sampler struct {
+	checker  *probe.Checker
+	interval time.Duration
+
+	mu        sync.RWMutex
+	latest    *Snapshot
+	listeners []Listener
+
+	stopOnce sync.Once
+	done     chan struct{}
+}
+
+// NewSampler 新しいサンプラーを作成
+func NewSampler(checker *probe.Checker, interval time.Duration) *Sampler {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	return &Sampler{
+		checker:  checker,
+		interval: interval,
+		done:     make(chan struct{}),
+	}
+}
+
+// Subscribe サンプリング結果の通知先を登録
+func (s *Sampler) Subscribe(listener Listener) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.listeners = append(s.listeners, listener)
+}
+
+// Latest 最新のスナップショットを取得（未サンプリングの場合は false）
+func (s *Sampler) Latest() (Snapshot, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.latest == nil {
+		return Snapshot{}, false
+	}
+
+	return *s.latest, true
+}
+
+// Start バックグラウンドでサンプリングを開始
+func (s *Sampler) Start() {
+	threading.GoSafe(s.run)
+}
+
+// Stop サンプリングを停止
+func (s *Sampler) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.done)
+	})
+}
+
+func (s *Sampler) run() {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	s.Sample(context.Background())
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			s.Sample(context.Background())
+		}
+	}
+}
+
+// Sample 即座に1回サンプリングし、登録済みのリスナーへ通知する
+func (s *Sampler) Sample(ctx context.Context) Snapshot {
+	snapshot := s.collect(ctx)
+
+	s.mu.Lock()
+	s.latest = &snapshot
+	listeners := append([]Listener(nil), s.listeners...)
+	s.mu.Unlock()
+
+	for _, listener := range listeners {
+		threading.RunSafe(func() {
+			listener(snapshot)
+		})
+	}
+
+	return snapshot
+}
+
+func (s *Sampler) collect(ctx context.Context) Snapshot {
+	results := s.checker.CheckAll(ctx)
+	metrics := make(map[string]float64)
+
+	status := StatusHealthy
+	for _, result := range results {
+		prefix := "service." + result.Target.Name + "."
+		metrics[prefix+"up"] = boolValue(result.Status == probe.StatusUp)
+		metrics[prefix+"latency_ms"] = float64(result.Latency.Milliseconds())
+		metrics[prefix+"error_count"] = float64(result.ErrorCount)
+
+		switch result.Status {
+		case probe.StatusDown:
+			status = StatusUnhealthy
+		case probe.StatusDegraded:
+			if status != StatusUnhealthy {
+				status = StatusDegraded
+			}
+		}
+	}
+	metrics["system.healthy"] = boolValue(status == StatusHealthy)
+	metrics["system.unhealthy"] = boolValue(status == StatusUnhealthy)
+	metrics["system.version_skew"] = boolValue(probe.HasVersionSkew(buildinfo.Summary(), results))
+
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	memoryUsage := float64(m.Alloc) / float64(m.Sys) * 100
+	if memoryUsage > 100 {
+		memoryUsage = 100
+	}
+	metrics["memory.usage_percent"] = memoryUsage
+	metrics["runtime.goroutines"] = float64(runtime.NumGoroutine())
+
+	logx.WithContext(ctx).Debugf("Sampled %d services, status: %s", len(results), status)
+
+	return Snapshot{
+		Time:     time.Now(),
+		Status:   status,
+		Services: results,
+		Metrics:  metrics,
+	}
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,263 @@
+package stream
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/winyx/backend/dashboard_service/internal/config"
+	"github.com/winyx/backend/dashboard_service/internal/sampler"
+	"github.com/winyx/backend/dashboard_service/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+const (
+	TopicMetrics = "metrics"
+	TopicHealth  = "health"
+)
+
+var ErrTooManyConnections = errors.New("too many streaming connections")
+
+// Event クライアントへ送信する1件のイベント
+type Event struct {
+	Type string
+	Data any
+}
+
+// Filter クライアント毎の購読条件（空の場合は全て）
+type Filter struct {
+	Topics   map[string]bool
+	Services map[string]bool
+}
+
+// ParseFilter カンマ区切りのクエリパラメータから購読条件を作成
+func ParseFilter(topics, services string) Filter {
+	return Filter{
+		Topics:   splitSet(topics),
+		Services: splitSet(services),
+	}
+}
+
+func (f Filter) wantsTopic(topic string) bool {
+	return len(f.Topics) == 0 || f.Topics[topic]
+}
+
+func (f Filter) wantsService(name string) bool {
+	return len(f.Services) == 0 || f.Services[name]
+}
+
+// Subscription ストリーミング接続1本分の購読
+type Subscription struct {
+	hub    *Hub
+	filter Filter
+	events chan Event
+
+	dropped   int32
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+// Events 送信待ちのイベント
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Done 購読が終了した（クライアント切断、または送信遅延による強制切断）
+func (s *Subscription) Done() <-chan struct{} {
+	return s.done
+}
+
+// Close 購読を解除
+func (s *Subscription) Close() {
+	s.closeOnce.Do(func() {
+		s.hub.remove(s)
+		close(s.done)
+	})
+}
+
+// offer イベントをノンブロッキングで積む
+// バッファが一杯の場合は最も古いイベントを捨て、捨てた数が上限に達したら切断する
+func (s *Subscription) offer(event Event) {
+	for {
+		select {
+		case s.events <- event:
+			return
+		default:
+		}
+
+		select {
+		case <-s.events:
+			if atomic.AddInt32(&s.dropped, 1) >= s.hub.maxDropped {
+				logx.Infof("Closing slow stream client after %d dropped events", s.hub.maxDropped)
+				s.Close()
+				return
+			}
+		default:
+		}
+	}
+}
+
+// Hub サンプラーの結果をストリーミングクライアントへ配信する
+type Hub struct {
+	maxConns   int
+	bufferSize int
+	maxDropped int32
+
+	mu       sync.Mutex
+	subs     map[*Subscription]struct{}
+	previous *sampler.Snapshot
+}
+
+// NewHub 新しい配信ハブを作成
+func NewHub(c config.StreamConf) *Hub {
+	return &Hub{
+		maxConns:   c.MaxConnections,
+		bufferSize: max(c.BufferSize, 1),
+		maxDropped: int32(max(c.MaxDroppedEvents, 1)),
+		subs:       make(map[*Subscription]struct{}),
+	}
+}
+
+// Subscribe 新しい購読を登録し、最新のスナップショットを初期イベントとして積む
+func (h *Hub) Subscribe(filter Filter, latest *sampler.Snapshot) (*Subscription, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.maxConns > 0 && len(h.subs) >= h.maxConns {
+		return nil, ErrTooManyConnections
+	}
+
+	sub := &Subscription{
+		hub:    h,
+		filter: filter,
+		events: make(chan Event, h.bufferSize),
+		done:   make(chan struct{}),
+	}
+	h.subs[sub] = struct{}{}
+
+	if latest != nil && filter.wantsTopic(TopicMetrics) {
+		sub.offer(metricsEvent(*latest, filter))
+	}
+
+	return sub, nil
+}
+
+// Connections 現在の接続数
+func (h *Hub) Connections() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return len(h.subs)
+}
+
+// Publish スナップショットを全購読者へ配信する（sampler.Listener として登録して使う）
+func (h *Hub) Publish(snapshot sampler.Snapshot) {
+	h.mu.Lock()
+	previous := h.previous
+	h.previous = &snapshot
+	subs := make([]*Subscription, 0, len(h.subs))
+	for sub := range h.subs {
+		subs = append(subs, sub)
+	}
+	h.mu.Unlock()
+
+	for _, sub := range subs {
+		if sub.filter.wantsTopic(TopicMetrics) {
+			sub.offer(metricsEvent(snapshot, sub.filter))
+		}
+		if previous != nil && sub.filter.wantsTopic(TopicHealth) {
+			if event, changed := healthEvent(*previous, snapshot, sub.filter); changed {
+				sub.offer(event)
+			}
+		}
+	}
+}
+
+func (h *Hub) remove(sub *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delete(h.subs, sub)
+}
+
+func metricsEvent(snapshot sampler.Snapshot, filter Filter) Event {
+	metrics := make(map[string]float64, len(snapshot.Metrics))
+	for key, value := range snapshot.Metrics {
+		if name, ok := serviceOf(key); ok && !filter.wantsService(name) {
+			continue
+		}
+		metrics[key] = value
+	}
+
+	return Event{
+		Type: TopicMetrics,
+		Data: types.MetricsEvent{
+			Timestamp: snapshot.Time.Unix(),
+			Status:    snapshot.Status,
+			Metrics:   metrics,
+		},
+	}
+}
+
+// healthEvent 前回からのシステム/サービス状態の変化を抽出する
+func healthEvent(previous, current sampler.Snapshot, filter Filter) (Event, bool) {
+	before := make(map[string]string, len(previous.Services))
+	for _, result := range previous.Services {
+		before[result.Target.Name] = result.Status
+	}
+
+	var changes []types.ServiceStatusChange
+	for _, result := range current.Services {
+		name := result.Target.Name
+		if !filter.wantsService(name) || before[name] == result.Status {
+			continue
+		}
+		changes = append(changes, types.ServiceStatusChange{
+			Name:           name,
+			Status:         result.Status,
+			PreviousStatus: before[name],
+		})
+	}
+
+	if len(changes) == 0 && previous.Status == current.Status {
+		return Event{}, false
+	}
+
+	return Event{
+		Type: TopicHealth,
+		Data: types.HealthChangeEvent{
+			Timestamp:      current.Time.Unix(),
+			Status:         current.Status,
+			PreviousStatus: previous.Status,
+			Services:       changes,
+		},
+	}, true
+}
+
+// serviceOf "service.<name>.<metric>" 形式のキーからサービス名を取り出す
+func serviceOf(key string) (string, bool) {
+	rest, ok := strings.CutPrefix(key, "service.")
+	if !ok {
+		return "", false
+	}
+
+	idx := strings.LastIndex(rest, ".")
+	if idx < 0 {
+		return "", false
+	}
+
+	return rest[:idx], true
+}
+
+func splitSet(s string) map[string]bool {
+	set := make(map[string]bool)
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			set[item] = true
+		}
+	}
+
+	return set
+}
@@ -0,0 +1,70 @@
+package stream
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const writeWait = 10 * time.Second
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+}
+
+// wsMessage WebSocket で送信するメッセージ（SSE の event/data に対応）
+type wsMessage struct {
+	Type string `json:"type"`
+	Data any    `json:"data"`
+}
+
+// ServeWebSocket 購読したイベントを WebSocket で書き出す
+// ハートビートは ping フレームで送り、pong が返らない接続は切断する
+func ServeWebSocket(w http.ResponseWriter, r *http.Request, sub *Subscription, heartbeat time.Duration) error {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// クライアントからのメッセージは読み捨てるが、切断と pong の検知のために読み続ける
+	closed := make(chan struct{})
+	conn.SetReadDeadline(time.Now().Add(2 * heartbeat))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(2 * heartbeat))
+	})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-closed:
+			return nil
+		case <-sub.Done():
+			conn.WriteControl(websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "client too slow"),
+				time.Now().Add(writeWait))
+			return nil
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeWait)); err != nil {
+				return err
+			}
+		case event := <-sub.Events():
+			conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := conn.WriteJSON(wsMessage{Type: event.Type, Data: event.Data}); err != nil {
+				return err
+			}
+		}
+	}
+}
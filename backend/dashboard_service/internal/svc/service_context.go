@@ -1,15 +1,92 @@
 package svc
 
 import (
+	"time"
+
+	"github.com/winyx/backend/common/featureflag"
+	"github.com/winyx/backend/common/maintenance"
+	"github.com/winyx/backend/common/metrics"
+	"github.com/winyx/backend/common/rpc"
+	"github.com/winyx/backend/dashboard_service/internal/alert"
 	"github.com/winyx/backend/dashboard_service/internal/config"
+	"github.com/winyx/backend/dashboard_service/internal/middleware"
+	"github.com/winyx/backend/dashboard_service/internal/probe"
+	"github.com/winyx/backend/dashboard_service/internal/sampler"
+	"github.com/winyx/backend/dashboard_service/internal/stream"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/stores/redis"
+	"github.com/zeromicro/go-zero/rest"
 )
 
 type ServiceContext struct {
-	Config config.Config
+	Config  config.Config
+	Checker *probe.Checker
+	Sampler *sampler.Sampler
+	Alerts  *alert.Evaluator
+	Stream  *stream.Hub
+
+	Redis        *redis.Redis
+	Maintenance  *maintenance.Store
+	FeatureFlags *featureflag.Client
+
+	UserService *rpc.UserServiceClient
+	Authz       rest.Middleware
 }
 
 func NewServiceContext(c config.Config) *ServiceContext {
+	checker := probe.NewChecker(c)
+	smp := sampler.NewSampler(checker, time.Duration(c.Metrics.SampleIntervalSeconds)*time.Second)
+
+	// アラートルールはサンプリング毎に評価する
+	alerts, err := alert.NewEvaluator(c.Alerting)
+	logx.Must(err)
+	smp.Subscribe(alerts.Evaluate)
+	smp.Subscribe(exportServiceHealth)
+
+	// SSE / WebSocket クライアントへサンプリング結果を配信する
+	hub := stream.NewHub(c.Stream)
+	smp.Subscribe(hub.Publish)
+
+	rds := redis.MustNewRedis(redis.RedisConf{
+		Host:     c.Redis.Host,
+		Type:     c.Redis.Type,
+		NonBlock: true,
+	})
+
+	userService := rpc.NewUserServiceClient(c.UserService.Url, c.UserService.Secret)
+
 	return &ServiceContext{
-		Config: c,
+		Config:  c,
+		Checker: checker,
+		Sampler: smp,
+		Alerts:  alerts,
+		Stream:  hub,
+
+		Redis:        rds,
+		Maintenance:  maintenance.NewStore(rds, c.Maintenance.Key),
+		FeatureFlags: featureflag.NewClient(rds, c.FeatureFlags),
+
+		UserService: userService,
+		Authz:       middleware.NewAuthzMiddleware(userService, c.UserService).Handle,
+	}
+}
+
+// exportServiceHealth 監視対象のヘルス状態を Prometheus のゲージへ反映
+func exportServiceHealth(snapshot sampler.Snapshot) {
+	for _, result := range snapshot.Services {
+		metrics.SetServiceHealth(metrics.SourceDashboard, result.Target.Name, result.Status, result.Latency.Milliseconds())
 	}
 }
+
+// Start バックグラウンド処理を開始
+func (s *ServiceContext) Start() {
+	s.Sampler.Start()
+}
+
+// Stop バックグラウンド処理を停止し、リソースを解放
+func (s *ServiceContext) Stop() {
+	s.Sampler.Stop()
+	s.Checker.Close()
+	s.FeatureFlags.Close()
+}
@@ -3,6 +3,24 @@
 
 package types
 
+type ActiveAlertsRes struct {
+	Timestamp int64       `json:"timestamp"` // 取得時刻
+	Alerts    []AlertInfo `json:"alerts"`    // pending/firing 中のアラート
+}
+
+type AlertInfo struct {
+	Rule        string  `json:"rule"`                 // ルール名
+	Metric      string  `json:"metric"`               // 対象メトリクス
+	State       string  `json:"state"`                // 状態（pending/firing）
+	Severity    string  `json:"severity"`             // 重要度（info/warning/critical）
+	Value       float64 `json:"value"`                // 現在値
+	Operator    string  `json:"operator"`             // 比較演算子
+	Threshold   float64 `json:"threshold"`            // しきい値
+	Description string  `json:"description,optional"` // ルールの説明
+	ActiveAt    int64   `json:"active_at"`            // 条件成立時刻
+	FiredAt     int64   `json:"fired_at,optional"`    // 発火時刻
+}
+
 type ApiStatsReq struct {
 	Period      string `json:"period,optional,default=24h"` // 期間（1h/24h/7d/30d）
 	ServiceName string `json:"service_name,optional"`       // 特定サービス名（オプション）
@@ -21,6 +39,8 @@ type ApiStatsRes struct {
 type ConfigRes struct {
 	Environment string          `json:"environment"` // 実行環境（dev/staging/prod）
 	Version     string          `json:"version"`     // アプリケーションバージョン
+	Commit      string          `json:"commit"`      // ビルド元のコミット
+	Dirty       bool            `json:"dirty"`       // 未コミットの変更を含むビルドか
 	GoVersion   string          `json:"go_version"`  // Goバージョン
 	BuildTime   string          `json:"build_time"`  // ビルド日時
 	Features    map[string]bool `json:"features"`    // 有効な機能フラグ
@@ -50,13 +70,28 @@ type ErrorStat struct {
 	Message    string `json:"message,optional"` // エラーメッセージ
 }
 
+type LivenessRes struct {
+	Status    string `json:"status"`    // プロセスの稼働状況（常に ok）
+	Version   string `json:"version"`   // ビルドバージョン
+	Commit    string `json:"commit"`    // ビルド元のコミット
+	Timestamp int64  `json:"timestamp"` // 応答時刻
+}
+
 type MaintenanceInfo struct {
 	Enabled        bool   `json:"enabled"`                  // メンテナンスモード有効/無効
+	Active         bool   `json:"active"`                   // 現在メンテナンス期間中か
 	Message        string `json:"message,optional"`         // メンテナンスメッセージ
 	ScheduledStart int64  `json:"scheduled_start,optional"` // 予定開始時刻
 	ScheduledEnd   int64  `json:"scheduled_end,optional"`   // 予定終了時刻
 }
 
+type MaintenanceReq struct {
+	Enabled        bool   `json:"enabled"`                  // メンテナンスモード有効/無効
+	Message        string `json:"message,optional"`         // メンテナンスメッセージ
+	ScheduledStart int64  `json:"scheduled_start,optional"` // 予定開始時刻（0=即時）
+	ScheduledEnd   int64  `json:"scheduled_end,optional"`   // 予定終了時刻（0=未定）
+}
+
 type MemoryStatus struct {
 	UsedMB       int64 `json:"used_mb"`       // 使用メモリ（MB）
 	TotalMB      int64 `json:"total_mb"`      // 総メモリ（MB）
@@ -64,6 +99,24 @@ type MemoryStatus struct {
 	Available    int64 `json:"available_mb"`  // 利用可能メモリ（MB）
 }
 
+type HealthChangeEvent struct {
+	Timestamp      int64                 `json:"timestamp"`         // 変化を検知した時刻
+	Status         string                `json:"status"`            // システムステータス
+	PreviousStatus string                `json:"previous_status"`   // 前回のシステムステータス
+	Services       []ServiceStatusChange `json:"services,optional"` // 状態が変化したサービス
+}
+
+type MetricsEvent struct {
+	Timestamp int64              `json:"timestamp"` // サンプリング時刻
+	Status    string             `json:"status"`    // システムステータス
+	Metrics   map[string]float64 `json:"metrics"`   // メトリクス名と値
+}
+
+type MetricsStreamReq struct {
+	Topics   string `form:"topics,optional"`   // 購読するイベント（metrics,health のカンマ区切り。省略時は全て）
+	Services string `form:"services,optional"` // 対象サービス名のカンマ区切り（省略時は全て）
+}
+
 type RealtimeMetricsRes struct {
 	CurrentTime    int64   `json:"current_time"`         // 現在時刻
 	ActiveSessions int     `json:"active_sessions"`      // アクティブセッション数
@@ -83,6 +136,13 @@ type ServiceStatus struct {
 	ResponseTime int64  `json:"response_time_ms"` // 応答時間
 	ErrorCount   int    `json:"error_count"`      // 24時間以内のエラー数
 	Version      string `json:"version,optional"` // サービスバージョン
+	Commit       string `json:"commit,optional"`  // サービスのビルド元コミット
+}
+
+type ServiceStatusChange struct {
+	Name           string `json:"name"`            // サービス名
+	Status         string `json:"status"`          // 現在の状況（up/down/degraded）
+	PreviousStatus string `json:"previous_status"` // 前回の状況（初回検知時は空）
 }
 
 type SystemHealthRes struct {
@@ -92,4 +152,7 @@ type SystemHealthRes struct {
 	Database     DatabaseStatus  `json:"database"`         // データベース状況
 	Memory       MemoryStatus    `json:"memory"`           // メモリ使用状況
 	ResponseTime int64           `json:"response_time_ms"` // 応答時間（ミリ秒）
+	Version      string          `json:"version"`          // ダッシュボード自身のバージョン
+	Commit       string          `json:"commit"`           // ダッシュボード自身のビルド元コミット
+	VersionSkew  bool            `json:"version_skew"`     // 監視対象とのビルド差異の有無
 }
@@ -4,6 +4,7 @@ go 1.24.4
 
 require (
 	github.com/golang-jwt/jwt/v4 v4.5.2
+	github.com/winyx/backend v0.0.0-00010101000000-000000000000
 	github.com/zeromicro/go-zero v1.8.5
 	golang.org/x/crypto v0.41.0
 )
@@ -50,8 +51,10 @@ require (
 	golang.org/x/sys v0.35.0 // indirect
 	golang.org/x/text v0.28.0 // indirect
 	google.golang.org/genproto/googleapis/api v0.0.0-20240711142825-46eb208f015d // indirect
-	google.golang.org/genproto/googleapis/rpc v0.0.0-20240701130421-f6361c86f094 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240711142825-46eb208f015d // indirect
 	google.golang.org/grpc v1.65.0 // indirect
 	google.golang.org/protobuf v1.36.5 // indirect
 	gopkg.in/yaml.v2 v2.4.0 // indirect
 )
+
+replace github.com/winyx/backend => ../
@@ -0,0 +1,74 @@
+// Package apikey 組織の API キー（Authorization: Bearer wx_...）による機械クライアントの認証
+//
+// キーは "wx_<prefix>_<secret>" 形式。DB には検索用の prefix とキー全体のハッシュのみを保存する。
+package apikey
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+)
+
+// KeyPrefix API キーの先頭に付く固定文字列（JWT と区別するために使う）
+const KeyPrefix = "wx_"
+
+const (
+	// 検索用プレフィックスのランダムバイト長（hex で 12 文字）
+	lookupBytes = 6
+	// 秘密部分のランダムバイト長
+	secretBytes = 32
+)
+
+// Generate 新しい API キーと、保存用の検索プレフィックス・ハッシュを生成
+// キー自体は作成時のレスポンスで一度だけ返し、保存しない
+func Generate() (key, prefix, hash string, err error) {
+	lookup := make([]byte, lookupBytes)
+	if _, err := rand.Read(lookup); err != nil {
+		return "", "", "", err
+	}
+	secret := make([]byte, secretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", "", err
+	}
+
+	prefix = KeyPrefix + hex.EncodeToString(lookup)
+	key = prefix + "_" + base64.RawURLEncoding.EncodeToString(secret)
+	return key, prefix, Hash(key), nil
+}
+
+// Hash 保存・照合用のキーのハッシュ
+func Hash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsKey Bearer トークンが API キーの形式か
+func IsKey(token string) bool {
+	return strings.HasPrefix(token, KeyPrefix)
+}
+
+// prefixOf キーから検索用プレフィックスを取り出す
+func prefixOf(key string) (string, bool) {
+	length := len(KeyPrefix) + lookupBytes*2
+	if len(key) <= length+1 || key[length] != '_' {
+		return "", false
+	}
+
+	return key[:length], true
+}
+
+// JoinScopes スコープを保存用のカンマ区切り文字列にする
+func JoinScopes(scopes []string) string {
+	return strings.Join(scopes, ",")
+}
+
+// SplitScopes 保存されたスコープを一覧に戻す
+func SplitScopes(scopes string) []string {
+	if scopes == "" {
+		return []string{}
+	}
+
+	return strings.Split(scopes, ",")
+}
@@ -0,0 +1,104 @@
+package apikey
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+// errorRes API キー認証エラーのレスポンス
+type errorRes struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// API キーで呼び出せない組織内の操作（/api/v1/orgs/:id/ の次の要素）
+// 組織トークンの発行と所有権の移譲は、キーの作成者本人としての操作になるため対象外にする
+var userOnlyOrgActions = map[string]bool{
+	"token":    true,
+	"transfer": true,
+}
+
+// allowedPath API キーで呼び出せるパスか
+// キーは組織に属するため、組織単位（/api/v1/orgs/:id/*）・テナント・フィーチャーフラグの評価に限る。
+// 本人向け（/users/me/*・/user/*）や管理者向けの API はキーの作成者として実行されてしまうため拒否する
+func allowedPath(path string) bool {
+	switch {
+	case path == "/api/v1/tenant", strings.HasPrefix(path, "/api/v1/tenant/"), path == "/api/v1/features":
+		return true
+	}
+
+	rest, ok := strings.CutPrefix(path, "/api/v1/orgs/")
+	if !ok {
+		return false
+	}
+	id, action, _ := strings.Cut(rest, "/")
+	if _, err := strconv.ParseUint(id, 10, 64); err != nil {
+		return false
+	}
+	action, _, _ = strings.Cut(action, "/")
+	return !userOnlyOrgActions[action]
+}
+
+// router API キーのリクエストを JWT のリクエストに変換してから本来のルーターに渡す
+//
+// go-zero の JWT 認証（rest.WithJwt）はルート毎のミドルウェアより先に実行されるため、
+// ルーターの手前で Authorization ヘッダーを短命の JWT に差し替える。
+// これにより各ハンドラーは JWT と同じコンテキスト値（user_id・org_id）で処理できる。
+type router struct {
+	httpx.Router
+	auth *Authenticator
+}
+
+// NewRouter rest.WithRouter に渡すルーターを作成
+func NewRouter(next httpx.Router, auth *Authenticator) httpx.Router {
+	return &router{
+		Router: next,
+		auth:   auth,
+	}
+}
+
+func (rt *router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || !IsKey(key) {
+		rt.Router.ServeHTTP(w, r)
+		return
+	}
+	if !allowedPath(r.URL.Path) {
+		writeError(w, r, http.StatusForbidden, "この API は API キーでは呼び出せません")
+		return
+	}
+
+	principal, err := rt.auth.Authenticate(r.Context(), key)
+	switch {
+	case errors.Is(err, ErrInvalidKey):
+		writeError(w, r, http.StatusUnauthorized, err.Error())
+		return
+	case err != nil:
+		logx.WithContext(r.Context()).Errorf("Failed to authenticate API key: %v", err)
+		writeError(w, r, http.StatusServiceUnavailable, "API キーの確認に失敗しました")
+		return
+	}
+
+	token, err := rt.auth.token(principal)
+	if err != nil {
+		logx.WithContext(r.Context()).Errorf("Failed to sign token for API key %d: %v", principal.KeyId, err)
+		writeError(w, r, http.StatusServiceUnavailable, "API キーの確認に失敗しました")
+		return
+	}
+
+	r = r.Clone(r.Context())
+	r.Header.Set("Authorization", "Bearer "+token)
+	rt.Router.ServeHTTP(w, r)
+}
+
+func writeError(w http.ResponseWriter, r *http.Request, code int, message string) {
+	httpx.WriteJsonCtx(r.Context(), w, code, errorRes{
+		Code:    code,
+		Message: message,
+	})
+}
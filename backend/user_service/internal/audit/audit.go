@@ -0,0 +1,174 @@
+// Package audit はセキュリティ上重要な操作を監査ログ（audit_logs テーブル）に記録する
+//
+// 監査ログは追記のみで、各行は直前の行の hash を含めてハッシュ化される（ハッシュチェーン）。
+// 操作者は JWT（API キーの場合はキーID も）から、IP・User-Agent・リクエストID は
+// Middleware がコンテキストに格納した値から取得する。
+package audit
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"user_service/internal/model"
+
+	"github.com/winyx/backend/common/auth"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// 操作の種類（audit_logs.action）
+const (
+	ActionLogin         = "auth.login"
+	ActionLoginFailed   = "auth.login_failed"
+	ActionOrgTokenIssue = "auth.org_token_issue"
+
+	ActionUserRegister    = "user.register"
+	ActionUserCreate      = "user.create"
+	ActionUserUpdate      = "user.update"
+	ActionUserRolesAssign = "user.roles_assign"
+	ActionUserDelete      = "user.delete"
+	ActionUserRestore     = "user.restore"
+	ActionUserPurge       = "user.purge"
+	ActionUserImport      = "user.import"
+	ActionUserExport      = "user.export"
+	ActionPasswordSetup   = "user.password_setup"
+
+	ActionDataExport      = "privacy.data_export"
+	ActionErasureRequest  = "privacy.erasure_request"
+	ActionErasureCancel   = "privacy.erasure_cancel"
+	ActionErasureReject   = "privacy.erasure_reject"
+	ActionErasureComplete = "privacy.erasure_complete"
+
+	ActionOrgCreate         = "org.create"
+	ActionOrgUpdate         = "org.update"
+	ActionOrgDelete         = "org.delete"
+	ActionOrgRestore        = "org.restore"
+	ActionOrgPurge          = "org.purge"
+	ActionOrgMemberAdd      = "org.member_add"
+	ActionOrgMemberRole     = "org.member_role_change"
+	ActionOrgMemberRemove   = "org.member_remove"
+	ActionInvitationCreate  = "org.invitation_create"
+	ActionInvitationRevoke  = "org.invitation_revoke"
+	ActionInvitationAccept  = "org.invitation_accept"
+	ActionInvitationDecline = "org.invitation_decline"
+	ActionTransferRequest   = "org.transfer_request"
+	ActionTransferCancel    = "org.transfer_cancel"
+	ActionTransferAccept    = "org.transfer_accept"
+	ActionTransferDecline   = "org.transfer_decline"
+	ActionApiKeyCreate      = "org.api_key_create"
+	ActionApiKeyRevoke      = "org.api_key_revoke"
+	ActionFeatureFlagCreate = "feature_flag.create"
+	ActionFeatureFlagUpdate = "feature_flag.update"
+	ActionFeatureFlagDelete = "feature_flag.delete"
+)
+
+// 操作対象の種類（audit_logs.target_type）
+const (
+	TargetUser        = "user"
+	TargetOrg         = "org"
+	TargetInvitation  = "org_invitation"
+	TargetTransfer    = "org_transfer"
+	TargetApiKey      = "org_api_key"
+	TargetFeatureFlag = "feature_flag"
+	TargetUserImport  = "user_import"
+	TargetDataExport  = "user_data_export"
+	TargetErasure     = "user_erasure_request"
+)
+
+// Entry 記録する操作
+type Entry struct {
+	Action     string
+	ActorId    int64 // 省略時は JWT の user_id。ログインなど認証前の操作で指定する
+	OrgId      int64 // 組織に関する操作の場合のみ
+	TargetType string
+	TargetId   any // 数値の ID またはフィーチャーフラグのキーなど
+	Before     any // 変更前のスナップショット（作成時は nil）
+	After      any // 変更後のスナップショット（削除時は nil）。ログイン失敗など変更を伴わない操作では詳細を渡す
+}
+
+// Writer 監査ログの書き込み
+type Writer struct {
+	logs      model.AuditLogsModel
+	digestKey []byte
+}
+
+// NewWriter 新しい Writer を作成。digestKey は Digest・EmailDigest の鍵
+func NewWriter(logs model.AuditLogsModel, digestKey string) *Writer {
+	return &Writer{logs: logs, digestKey: []byte(digestKey)}
+}
+
+// Digest 個人データの代わりに記録する値（HMAC-SHA256）
+// 監査ログは後から消せないため、個人データの消去後も値そのものは残らないようにする。
+// 同じ値は同じハッシュになるため、変更の有無と、鍵を使った照合はできる
+func (w *Writer) Digest(value string) string {
+	if value == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, w.digestKey)
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// EmailDigest メールアドレスの Digest。大文字・小文字の違いは同じアドレスとして扱う
+func (w *Writer) EmailDigest(email string) string {
+	return w.Digest(strings.ToLower(strings.TrimSpace(email)))
+}
+
+// Record 操作を監査ログに追記する
+// 書き込みに失敗しても操作自体は取り消せないため呼び出し元には返さず、内容をエラーログに残す
+func (w *Writer) Record(ctx context.Context, entry Entry) {
+	logger := logx.WithContext(ctx)
+
+	row, err := w.newRow(ctx, entry)
+	if err == nil {
+		// クライアントが切断しても、完了した操作の記録は書き込む
+		err = w.logs.Append(context.WithoutCancel(ctx), row)
+	}
+	if err != nil {
+		logger.Errorf("Failed to write audit log %s (actor %d, org %d, target %s:%v): %v",
+			entry.Action, row.ActorUserId, entry.OrgId, entry.TargetType, entry.TargetId, err)
+	}
+}
+
+func (w *Writer) newRow(ctx context.Context, entry Entry) (*model.AuditLogs, error) {
+	req := RequestFromContext(ctx)
+	row := &model.AuditLogs{
+		OrgId:      uint64(max(entry.OrgId, 0)),
+		Action:     entry.Action,
+		TargetType: entry.TargetType,
+		Ip:         req.Ip,
+		UserAgent:  req.UserAgent,
+		RequestId:  req.RequestId,
+		OccurredAt: time.Now(),
+	}
+	if entry.TargetId != nil {
+		row.TargetId = truncate(fmt.Sprint(entry.TargetId), 64)
+	}
+
+	actorId := entry.ActorId
+	if actorId == 0 {
+		actorId, _ = auth.UserIdFromContext(ctx)
+	}
+	row.ActorUserId = uint64(max(actorId, 0))
+	if keyId, ok := auth.ApiKeyIdFromContext(ctx); ok {
+		row.ActorApiKeyId = uint64(keyId)
+	}
+
+	changes, err := Diff(entry.Before, entry.After)
+	if err != nil {
+		return row, fmt.Errorf("diff snapshots: %w", err)
+	}
+	diff, err := json.Marshal(changes)
+	if err != nil {
+		return row, fmt.Errorf("encode diff: %w", err)
+	}
+	row.Diff = string(diff)
+
+	return row, nil
+}
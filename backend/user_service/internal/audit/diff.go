@@ -0,0 +1,90 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// redacted 秘密情報の値の代わりに記録する文字列
+const redacted = "[REDACTED]"
+
+// sensitiveKeys 値を記録しない項目名（小文字で部分一致）
+var sensitiveKeys = []string{"password", "secret", "token", "hash"}
+
+// Change 項目の変更前後の値
+// 作成時は Before、削除時は After が nil になる
+type Change struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+// Diff 変更前後のスナップショットを比較し、値が異なる項目だけを返す
+// スナップショットは JSON オブジェクトに変換できる値（構造体・map）で、nil は「存在しない」を表す
+// パスワード等の項目は変更があったことのみ記録する
+func Diff(before, after any) (map[string]Change, error) {
+	beforeFields, err := toFields(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := toFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(map[string]Change)
+	for key, b := range beforeFields {
+		a, ok := afterFields[key]
+		if ok && reflect.DeepEqual(a, b) {
+			continue
+		}
+		changes[key] = mask(key, Change{Before: b, After: a})
+	}
+	for key, a := range afterFields {
+		if _, ok := beforeFields[key]; !ok {
+			changes[key] = mask(key, Change{After: a})
+		}
+	}
+
+	return changes, nil
+}
+
+func toFields(snapshot any) (map[string]any, error) {
+	if snapshot == nil || reflect.ValueOf(snapshot).Kind() == reflect.Ptr && reflect.ValueOf(snapshot).IsNil() {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	// 数値は json.Number のまま比較・記録し、大きな ID の精度落ちを防ぐ
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var fields map[string]any
+	if err := decoder.Decode(&fields); err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
+
+func mask(key string, change Change) Change {
+	lower := strings.ToLower(key)
+	for _, sensitive := range sensitiveKeys {
+		if !strings.Contains(lower, sensitive) {
+			continue
+		}
+		if change.Before != nil {
+			change.Before = redacted
+		}
+		if change.After != nil {
+			change.After = redacted
+		}
+		break
+	}
+
+	return change
+}
@@ -0,0 +1,74 @@
+package audit
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/zeromicro/go-zero/core/trace"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+// HeaderRequestId ゲートウェイが付与するリクエストID
+const HeaderRequestId = "X-Request-Id"
+
+type requestKey struct{}
+
+// Request 監査ログに記録するリクエスト元の情報
+type Request struct {
+	Ip        string
+	UserAgent string
+	RequestId string
+}
+
+// RequestFromContext Middleware がコンテキストに格納したリクエスト情報を取得
+func RequestFromContext(ctx context.Context) Request {
+	req, _ := ctx.Value(requestKey{}).(Request)
+	return req
+}
+
+// Middleware リクエスト元の IP・User-Agent・リクエストID をコンテキストに格納する（server.Use に渡す）
+// リクエストID はヘッダーが無ければトレースID を使い、ログと突き合わせられるようレスポンスにも返す
+func Middleware(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestId := truncate(r.Header.Get(HeaderRequestId), 64)
+		if requestId == "" {
+			requestId = trace.TraceIDFromContext(r.Context())
+		}
+		if requestId != "" {
+			w.Header().Set(HeaderRequestId, requestId)
+		}
+
+		ctx := context.WithValue(r.Context(), requestKey{}, Request{
+			Ip:        clientIp(r),
+			UserAgent: truncate(r.UserAgent(), 255),
+			RequestId: requestId,
+		})
+		next(w, r.WithContext(ctx))
+	}
+}
+
+// clientIp X-Forwarded-For の先頭（クライアント）またはポートを除いた接続元アドレス
+func clientIp(r *http.Request) string {
+	addr := httpx.GetRemoteAddr(r)
+	if first, _, found := strings.Cut(addr, ","); found {
+		addr = first
+	}
+	addr = strings.TrimSpace(addr)
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		addr = host
+	}
+
+	return truncate(addr, 45)
+}
+
+// truncate カラム長を超えないよう文字単位で切り詰める
+func truncate(s string, max int) string {
+	if utf8.RuneCountInString(s) <= max {
+		return s
+	}
+
+	return string([]rune(s)[:max])
+}
@@ -0,0 +1,50 @@
+package audit
+
+import (
+	"user_service/internal/model"
+)
+
+// UserSnapshot 監査ログに記録するユーザーの項目（パスワード・プロフィールは含めない）
+// 氏名・メールアドレスは消去の対象のため、Digest のみ記録する
+type UserSnapshot struct {
+	UserId      uint64 `json:"user_id"`
+	NameDigest  string `json:"name_digest"`
+	EmailDigest string `json:"email_digest"`
+	Status      int8   `json:"status"`
+	DeletedAt   string `json:"deleted_at,omitempty"`
+}
+
+// SnapshotUser ユーザーの変更前後の比較に使う値
+func (w *Writer) SnapshotUser(user *model.Users) UserSnapshot {
+	snapshot := UserSnapshot{
+		UserId:      user.Id,
+		NameDigest:  w.Digest(user.Name),
+		EmailDigest: w.EmailDigest(user.Email),
+		Status:      user.Status,
+	}
+	if user.DeletedAt.Valid {
+		snapshot.DeletedAt = user.DeletedAt.Time.Format("2006-01-02T15:04:05Z07:00")
+	}
+
+	return snapshot
+}
+
+// OrgSnapshot 監査ログに記録する組織の項目
+type OrgSnapshot struct {
+	Name      string `json:"name"`
+	OwnerId   uint64 `json:"owner_id"`
+	DeletedAt string `json:"deleted_at,omitempty"`
+}
+
+// SnapshotOrg 組織の変更前後の比較に使う値
+func SnapshotOrg(org *model.Orgs) OrgSnapshot {
+	snapshot := OrgSnapshot{
+		Name:    org.Name,
+		OwnerId: org.OwnerId,
+	}
+	if org.DeletedAt.Valid {
+		snapshot.DeletedAt = org.DeletedAt.Time.Format("2006-01-02T15:04:05Z07:00")
+	}
+
+	return snapshot
+}
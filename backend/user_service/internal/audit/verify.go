@@ -0,0 +1,98 @@
+package audit
+
+import (
+	"context"
+	"errors"
+
+	"user_service/internal/model"
+)
+
+// ErrStartNotFound 検証の起点に指定した行が存在しない
+var ErrStartNotFound = errors.New("audit log entry to start from not found")
+
+// Verification ハッシュチェーンの検証結果
+type Verification struct {
+	Checked  int    // 検証した行数
+	LastId   uint64 // 最後に検証した行（次回の起点）
+	Complete bool   // チェーンの末尾まで検証した
+	BrokenId uint64 // 不整合を検出した行（0 は不整合なし）
+	Reason   string
+}
+
+// Valid 不整合が無かったか
+func (v *Verification) Valid() bool {
+	return v.BrokenId == 0
+}
+
+// Verify afterId の次の行から最大 limit 行のハッシュチェーンを検証する
+// 行の改ざんは hash の再計算、途中の行の削除・挿入は prev_hash の不一致、
+// 末尾の行の削除は audit_log_chain に記録した末尾との不一致で検出する
+func Verify(ctx context.Context, logs model.AuditLogsModel, afterId uint64, limit int) (*Verification, error) {
+	// 検証中に追記された行は対象外にするため、先に末尾を読んでおく
+	headId, headHash, err := logs.ChainHead(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Verification{LastId: afterId}
+	prevHash := model.AuditGenesisHash
+	if afterId > 0 {
+		start, err := logs.FindOne(ctx, afterId)
+		if errors.Is(err, model.ErrNotFound) {
+			return nil, ErrStartNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+		if start.ComputeHash() != start.Hash {
+			result.BrokenId = start.Id
+			result.Reason = "行の内容が改ざんされています"
+			return result, nil
+		}
+		prevHash = start.Hash
+	}
+
+	rows, err := logs.FindAfter(ctx, afterId, limit)
+	if err != nil {
+		return nil, err
+	}
+	passedHead := false
+	for _, row := range rows {
+		if row.Id > headId {
+			passedHead = true
+			break
+		}
+		if row.PrevHash != prevHash {
+			result.BrokenId = row.Id
+			result.Reason = "直前の行が削除または改ざんされています"
+			return result, nil
+		}
+		if row.ComputeHash() != row.Hash {
+			result.BrokenId = row.Id
+			result.Reason = "行の内容が改ざんされています"
+			return result, nil
+		}
+		prevHash = row.Hash
+		result.LastId = row.Id
+		result.Checked++
+	}
+
+	switch {
+	case result.LastId == headId:
+		if prevHash != headHash {
+			result.BrokenId = headId
+			result.Reason = "末尾の行が改ざんされています"
+			return result, nil
+		}
+		result.Complete = true
+	case result.LastId > headId:
+		// 起点が末尾より後ろにある（末尾の記録が巻き戻されている）
+		result.BrokenId = result.LastId
+		result.Reason = "チェーンの末尾の記録が一致しません"
+	case passedHead || len(rows) < limit:
+		result.BrokenId = headId
+		result.Reason = "末尾の行が削除されています"
+	}
+
+	return result, nil
+}
@@ -0,0 +1,102 @@
+// Package avatar はプロフィール画像から標準サイズのサムネイルを作り、保存先のキーと公開URLを決める
+//
+// ファイル名はアップロードされた内容の SHA-256 とサイズから決まる（"<SHA-256>_256.jpg"）。
+// 同じ画像を再アップロードしても同じ名前になるため、保存済みのサムネイルは作り直さずに済み、
+// 公開URLの内容が後から変わることもない（長期間キャッシュできる）。
+package avatar
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"user_service/internal/imaging"
+)
+
+// Sizes 作成するサムネイルの一辺の画素数（小さい順）
+var Sizes = []int{64, 128, 256, 512}
+
+// ProfileSize プロフィールの avatar_url に載せるサイズ
+const ProfileSize = 256
+
+// keyPrefix ストレージ上のキーの接頭辞
+const keyPrefix = "avatars/"
+
+var namePattern = regexp.MustCompile(`^([0-9a-f]{64})_(64|128|256|512)\.(jpg|png)$`)
+
+// Thumbnail 1 サイズ分のサムネイル
+type Thumbnail struct {
+	Size        int
+	Name        string // 公開URLの末尾のファイル名
+	ContentType string
+	Data        []byte
+}
+
+// Key ストレージ上のキー
+func (t Thumbnail) Key() string {
+	return Key(t.Name)
+}
+
+// Generate 画像を検証し、Sizes の各サイズのサムネイルを作る
+// 形式の誤りや画素数の超過は imaging のエラーをそのまま返す
+func Generate(data []byte, maxPixels int) ([]Thumbnail, error) {
+	img, err := imaging.Decode(data, maxPixels)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(data)
+	digest := hex.EncodeToString(sum[:])
+	format := img.OutputFormat()
+
+	thumbnails := make([]Thumbnail, 0, len(Sizes))
+	for _, size := range Sizes {
+		encoded, err := imaging.Encode(img.Thumbnail(size), format)
+		if err != nil {
+			return nil, err
+		}
+		thumbnails = append(thumbnails, Thumbnail{
+			Size:        size,
+			Name:        fmt.Sprintf("%s_%d.%s", digest, size, format.Extension()),
+			ContentType: format.ContentType(),
+			Data:        encoded,
+		})
+	}
+	return thumbnails, nil
+}
+
+// ValidName 公開URLで受け付けるファイル名か（このパッケージが作る名前以外はストレージを参照しない）
+func ValidName(name string) bool {
+	return namePattern.MatchString(name)
+}
+
+// Key ファイル名からストレージ上のキーを求める
+func Key(name string) string {
+	return keyPrefix + name
+}
+
+// Url ファイル名から公開URLを求める
+func Url(publicUrl, name string) string {
+	return strings.TrimSuffix(publicUrl, "/") + "/" + name
+}
+
+// Names avatar_url から全サイズのファイル名を求める（Sizes の順）
+// このサービスが発行した URL でない場合（外部の画像を直接設定した古いデータなど）は nil を返す
+func Names(publicUrl, avatarUrl string) []string {
+	prefix := strings.TrimSuffix(publicUrl, "/") + "/"
+	if !strings.HasPrefix(avatarUrl, prefix) {
+		return nil
+	}
+	m := namePattern.FindStringSubmatch(strings.TrimPrefix(avatarUrl, prefix))
+	if m == nil {
+		return nil
+	}
+
+	names := make([]string, len(Sizes))
+	for i, size := range Sizes {
+		names[i] = fmt.Sprintf("%s_%d.%s", m[1], size, m[3])
+	}
+	return names
+}
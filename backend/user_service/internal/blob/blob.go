@@ -0,0 +1,71 @@
+// Package blob は画像などのバイナリを保存する差し替え可能なストレージ
+//
+// キーは "avatars/<SHA-256>_256.jpg" のような "/" 区切りの相対パスで、内容から決まる（同じ内容は同じキー）。
+// そのため上書きは同じ内容の書き込みになり、Put は冪等になる。
+// 開発用のローカルファイルシステムと、S3 互換のオブジェクトストレージ（MinIO など）を選べる。
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+var ErrNotFound = errors.New("blob not found")
+
+// Conf ストレージの設定
+type Conf struct {
+	Type  string    `json:",default=local,options=local|s3"` // local: ローカルのディレクトリ / s3: S3 互換のオブジェクトストレージ
+	Local LocalConf `json:",optional"`
+	S3    S3Conf    `json:",optional"`
+}
+
+// Object 保存されている内容
+type Object struct {
+	Data        []byte
+	ContentType string
+}
+
+// Store ストレージ
+type Store interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	Get(ctx context.Context, key string) (*Object, error) // 無い場合は ErrNotFound
+	Exists(ctx context.Context, key string) (bool, error)
+	Delete(ctx context.Context, key string) error // 無い場合もエラーにしない
+}
+
+// NewStore 設定からストレージを作成
+func NewStore(c Conf) (Store, error) {
+	switch c.Type {
+	case "", "local":
+		return NewLocalStore(c.Local)
+	case "s3":
+		return NewS3Store(c.S3)
+	default:
+		return nil, fmt.Errorf("unsupported blob store type %q", c.Type)
+	}
+}
+
+// MustNewStore NewStore の失敗時に終了する版
+func MustNewStore(c Conf) Store {
+	s, err := NewStore(c)
+	logx.Must(err)
+
+	return s
+}
+
+// validKey ストレージの外を指すキー（絶対パス・".."・空の要素）を拒否する
+func validKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return fmt.Errorf("invalid blob key %q", key)
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return fmt.Errorf("invalid blob key %q", key)
+		}
+	}
+	return nil
+}
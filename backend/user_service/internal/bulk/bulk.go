@@ -0,0 +1,191 @@
+// Package bulk はユーザーの一括インポート・エクスポートのファイル形式（CSV / JSON Lines）を扱う
+//
+// どちらの形式も 1 行が 1 ユーザーで、項目名は CSV のヘッダーと JSON のキーで共通にする。
+// エクスポートしたファイルをそのままインポートできるよう、エクスポートにだけ含まれる項目
+// （id・created_at・last_login_at）はインポート時に無視する。
+package bulk
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"strings"
+)
+
+// Format ファイル形式
+type Format string
+
+const (
+	FormatCSV    Format = "csv"
+	FormatNDJSON Format = "ndjson"
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("ファイル形式は csv または ndjson を指定してください")
+	ErrEmpty             = errors.New("インポートする行がありません")
+)
+
+// インポートできる項目
+const (
+	ColumnName        = "name"
+	ColumnEmail       = "email"
+	ColumnStatus      = "status"
+	ColumnRoles       = "roles"
+	ColumnBio         = "bio"
+	ColumnPhone       = "phone"
+	ColumnAddress     = "address"
+	ColumnBirthDate   = "birth_date"
+	ColumnGender      = "gender"
+	ColumnOccupation  = "occupation"
+	ColumnWebsite     = "website"
+	ColumnSocialLinks = "social_links"
+	ColumnOrgId       = "org_id"
+	ColumnOrgRole     = "org_role"
+)
+
+// エクスポートにだけ含まれる項目
+const (
+	ColumnId          = "id"
+	ColumnCreatedAt   = "created_at"
+	ColumnLastLoginAt = "last_login_at"
+)
+
+// roleSeparator CSV の roles 列で複数のロールを区切る文字
+const roleSeparator = ";"
+
+// exportColumns エクスポートする列（CSV のヘッダーの順序）
+var exportColumns = []string{
+	ColumnId, ColumnName, ColumnEmail, ColumnStatus, ColumnRoles,
+	ColumnBio, ColumnPhone, ColumnAddress, ColumnBirthDate, ColumnGender, ColumnOccupation, ColumnWebsite, ColumnSocialLinks,
+	ColumnCreatedAt, ColumnLastLoginAt,
+}
+
+// Record ファイルの 1 行
+// インポートでは Line と Errors を設定し、エクスポートでは Id・CreatedAt・LastLoginAt を設定する
+type Record struct {
+	Line int // ファイル内の行番号（CSV のヘッダーは 1 行目）
+
+	Id          uint64
+	Name        string
+	Email       string
+	Status      string
+	Roles       []string
+	Bio         string
+	Phone       string
+	Address     string
+	BirthDate   string
+	Gender      string
+	Occupation  string
+	Website     string
+	SocialLinks string
+	OrgId       uint64
+	OrgRole     string
+	CreatedAt   string
+	LastLoginAt string
+
+	// Errors 値を解釈できなかった項目（項目名 → 理由）。インポート時の行の検証結果に含める
+	Errors map[string]string
+}
+
+// HasProfile プロフィールの項目が 1 つでも指定されているか
+func (r *Record) HasProfile() bool {
+	return r.Bio != "" || r.Phone != "" || r.Address != "" || r.BirthDate != "" || r.Gender != "" ||
+		r.Occupation != "" || r.Website != "" || r.SocialLinks != ""
+}
+
+func (r *Record) addError(column, message string) {
+	if r.Errors == nil {
+		r.Errors = make(map[string]string)
+	}
+	if _, ok := r.Errors[column]; !ok {
+		r.Errors[column] = message
+	}
+}
+
+// field 項目名に対応する文字列の項目（roles と org_id 以外）
+func (r *Record) field(column string) *string {
+	switch column {
+	case ColumnName:
+		return &r.Name
+	case ColumnEmail:
+		return &r.Email
+	case ColumnStatus:
+		return &r.Status
+	case ColumnBio:
+		return &r.Bio
+	case ColumnPhone:
+		return &r.Phone
+	case ColumnAddress:
+		return &r.Address
+	case ColumnBirthDate:
+		return &r.BirthDate
+	case ColumnGender:
+		return &r.Gender
+	case ColumnOccupation:
+		return &r.Occupation
+	case ColumnWebsite:
+		return &r.Website
+	case ColumnSocialLinks:
+		return &r.SocialLinks
+	case ColumnOrgRole:
+		return &r.OrgRole
+	default:
+		return nil
+	}
+}
+
+// ignoredColumn エクスポートにだけ含まれ、インポートでは読み飛ばす項目
+func ignoredColumn(column string) bool {
+	return column == ColumnId || column == ColumnCreatedAt || column == ColumnLastLoginAt
+}
+
+// ParseFormat 形式の指定（format パラメータ）または Content-Type からファイル形式を決める
+func ParseFormat(name, contentType string) (Format, error) {
+	switch strings.ToLower(name) {
+	case "csv":
+		return FormatCSV, nil
+	case "ndjson", "jsonl":
+		return FormatNDJSON, nil
+	case "":
+	default:
+		return "", ErrUnsupportedFormat
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", ErrUnsupportedFormat
+	}
+	switch mediaType {
+	case "text/csv", "application/csv":
+		return FormatCSV, nil
+	case "application/x-ndjson", "application/ndjson", "application/jsonl", "application/x-jsonlines":
+		return FormatNDJSON, nil
+	default:
+		return "", ErrUnsupportedFormat
+	}
+}
+
+// ContentType エクスポートのレスポンスの Content-Type
+func (f Format) ContentType() string {
+	if f == FormatCSV {
+		return "text/csv; charset=utf-8"
+	}
+	return "application/x-ndjson"
+}
+
+// Extension エクスポートのファイル名の拡張子
+func (f Format) Extension() string {
+	if f == FormatCSV {
+		return "csv"
+	}
+	return "ndjson"
+}
+
+// TooManyRowsError 行数が上限を超えている
+type TooManyRowsError struct {
+	Max int
+}
+
+func (e *TooManyRowsError) Error() string {
+	return fmt.Sprintf("1 回にインポートできるのは %d 行までです", e.Max)
+}
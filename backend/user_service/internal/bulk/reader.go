@@ -0,0 +1,238 @@
+package bulk
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// utf8BOM Excel で保存した CSV の先頭に付くバイト順マーク
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// Read ファイル全体を読み込む。maxRows を超える行がある場合は TooManyRowsError
+// ファイルの構造の誤り（CSV の構文・不明な列・JSON として読めない行）はエラーにし、
+// 項目の値の誤りは Record.Errors に記録して呼び出し側の行の検証に任せる
+func Read(format Format, r io.Reader, maxRows int) ([]Record, error) {
+	var (
+		records []Record
+		err     error
+	)
+	switch format {
+	case FormatCSV:
+		records, err = readCSV(r, maxRows)
+	case FormatNDJSON:
+		records, err = readNDJSON(r, maxRows)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, ErrEmpty
+	}
+	return records, nil
+}
+
+func readCSV(r io.Reader, maxRows int) ([]Record, error) {
+	// 列数がヘッダーと異なる行は構文の誤りとして扱う
+	reader := csv.NewReader(skipBOM(r))
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, ErrEmpty
+	}
+	if err != nil {
+		return nil, fmt.Errorf("CSV を読み込めません: %w", err)
+	}
+	columns := make([]string, len(header))
+	seen := make(map[string]bool, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if seen[name] {
+			return nil, fmt.Errorf("列 %s が重複しています", name)
+		}
+		if err := checkColumn(name); err != nil {
+			return nil, err
+		}
+		seen[name] = true
+		columns[i] = name
+	}
+	if !seen[ColumnName] || !seen[ColumnEmail] {
+		return nil, fmt.Errorf("%s 列と %s 列は必須です", ColumnName, ColumnEmail)
+	}
+
+	var records []Record
+	for {
+		values, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("CSV を読み込めません: %w", err)
+		}
+		if blank(values) {
+			continue
+		}
+		if len(records) == maxRows {
+			return nil, &TooManyRowsError{Max: maxRows}
+		}
+
+		line, _ := reader.FieldPos(0)
+		record := Record{Line: line}
+		for i, value := range values {
+			record.set(columns[i], unescapeFormula(strings.TrimSpace(value)))
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func readNDJSON(r io.Reader, maxRows int) ([]Record, error) {
+	scanner := bufio.NewScanner(skipBOM(r))
+	// プロフィールの自由記述を含むため、1 行の上限を既定（64KB）より大きくする
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var records []Record
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		if len(records) == maxRows {
+			return nil, &TooManyRowsError{Max: maxRows}
+		}
+
+		var doc map[string]json.RawMessage
+		if err := json.Unmarshal(text, &doc); err != nil {
+			return nil, fmt.Errorf("%d 行目を JSON のオブジェクトとして読み込めません", line)
+		}
+		record := Record{Line: line}
+		for key, raw := range doc {
+			column := strings.ToLower(key)
+			if err := checkColumn(column); err != nil {
+				record.addError(key, "インポートできない項目です")
+				continue
+			}
+			record.setJSON(column, raw)
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			return nil, fmt.Errorf("1 行が長すぎます")
+		}
+		return nil, err
+	}
+	return records, nil
+}
+
+// checkColumn インポートで扱える項目か
+func checkColumn(column string) error {
+	var r Record
+	if r.field(column) != nil || column == ColumnRoles || column == ColumnOrgId || ignoredColumn(column) {
+		return nil
+	}
+	return fmt.Errorf("不明な列です: %s", column)
+}
+
+// set CSV の値を項目に設定する
+func (r *Record) set(column, value string) {
+	switch {
+	case column == ColumnRoles:
+		r.Roles = splitRoles(value)
+	case column == ColumnOrgId:
+		r.setOrgId(value)
+	case ignoredColumn(column):
+	default:
+		*r.field(column) = value
+	}
+}
+
+// setJSON JSON の値を項目に設定する
+// roles は文字列の配列（CSV と同じ区切り文字の文字列も可）、org_id は数値（数字の文字列も可）
+func (r *Record) setJSON(column string, raw json.RawMessage) {
+	if string(raw) == "null" {
+		return
+	}
+
+	switch {
+	case column == ColumnRoles:
+		var roles []string
+		if err := json.Unmarshal(raw, &roles); err == nil {
+			r.Roles = roles
+			return
+		}
+		var joined string
+		if err := json.Unmarshal(raw, &joined); err != nil {
+			r.addError(column, "ロール名の配列を指定してください")
+			return
+		}
+		r.Roles = splitRoles(joined)
+	case column == ColumnOrgId:
+		var id json.Number
+		if err := json.Unmarshal(raw, &id); err == nil {
+			r.setOrgId(id.String())
+			return
+		}
+		var text string
+		if err := json.Unmarshal(raw, &text); err != nil {
+			r.addError(column, "組織 ID は数値で指定してください")
+			return
+		}
+		r.setOrgId(strings.TrimSpace(text))
+	case ignoredColumn(column):
+	default:
+		var text string
+		if err := json.Unmarshal(raw, &text); err != nil {
+			r.addError(column, "値の型が正しくありません")
+			return
+		}
+		*r.field(column) = strings.TrimSpace(text)
+	}
+}
+
+func (r *Record) setOrgId(value string) {
+	if value == "" {
+		return
+	}
+	id, err := strconv.ParseUint(value, 10, 64)
+	if err != nil || id == 0 {
+		r.addError(ColumnOrgId, "組織 ID は数値で指定してください")
+		return
+	}
+	r.OrgId = id
+}
+
+func splitRoles(value string) []string {
+	var roles []string
+	for _, role := range strings.Split(value, roleSeparator) {
+		if role = strings.TrimSpace(role); role != "" {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
+
+func blank(values []string) bool {
+	for _, value := range values {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func skipBOM(r io.Reader) io.Reader {
+	buffered := bufio.NewReader(r)
+	if prefix, err := buffered.Peek(len(utf8BOM)); err == nil && bytes.Equal(prefix, utf8BOM) {
+		buffered.Discard(len(utf8BOM))
+	}
+	return buffered
+}
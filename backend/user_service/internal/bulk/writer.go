@@ -0,0 +1,133 @@
+package bulk
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Encoder エクスポートする行を 1 行ずつ書き出す
+// CSV は最初の行の前にヘッダーを書く。書き込みはバッファされるため、区切りごとに Flush する
+type Encoder struct {
+	format Format
+	csv    *csv.Writer
+	json   *json.Encoder
+	header bool
+}
+
+func NewEncoder(format Format, w io.Writer) *Encoder {
+	e := &Encoder{format: format}
+	if format == FormatCSV {
+		e.csv = csv.NewWriter(w)
+	} else {
+		e.json = json.NewEncoder(w)
+		e.json.SetEscapeHTML(false)
+	}
+	return e
+}
+
+// exportLine JSON Lines の 1 行（CSV と同じ項目名）
+type exportLine struct {
+	Id          uint64   `json:"id"`
+	Name        string   `json:"name"`
+	Email       string   `json:"email"`
+	Status      string   `json:"status"`
+	Roles       []string `json:"roles"`
+	Bio         string   `json:"bio,omitempty"`
+	Phone       string   `json:"phone,omitempty"`
+	Address     string   `json:"address,omitempty"`
+	BirthDate   string   `json:"birth_date,omitempty"`
+	Gender      string   `json:"gender,omitempty"`
+	Occupation  string   `json:"occupation,omitempty"`
+	Website     string   `json:"website,omitempty"`
+	SocialLinks string   `json:"social_links,omitempty"`
+	CreatedAt   string   `json:"created_at"`
+	LastLoginAt string   `json:"last_login_at,omitempty"`
+}
+
+// Encode 1 行を書き出す
+func (e *Encoder) Encode(r *Record) error {
+	if e.json != nil {
+		roles := r.Roles
+		if roles == nil {
+			roles = []string{}
+		}
+		return e.json.Encode(exportLine{
+			Id:          r.Id,
+			Name:        r.Name,
+			Email:       r.Email,
+			Status:      r.Status,
+			Roles:       roles,
+			Bio:         r.Bio,
+			Phone:       r.Phone,
+			Address:     r.Address,
+			BirthDate:   r.BirthDate,
+			Gender:      r.Gender,
+			Occupation:  r.Occupation,
+			Website:     r.Website,
+			SocialLinks: r.SocialLinks,
+			CreatedAt:   r.CreatedAt,
+			LastLoginAt: r.LastLoginAt,
+		})
+	}
+
+	if !e.header {
+		if err := e.csv.Write(exportColumns); err != nil {
+			return err
+		}
+		e.header = true
+	}
+	values := make([]string, len(exportColumns))
+	for i, column := range exportColumns {
+		switch column {
+		case ColumnId:
+			values[i] = strconv.FormatUint(r.Id, 10)
+		case ColumnRoles:
+			values[i] = escapeFormula(strings.Join(r.Roles, roleSeparator))
+		case ColumnCreatedAt:
+			values[i] = r.CreatedAt
+		case ColumnLastLoginAt:
+			values[i] = r.LastLoginAt
+		default:
+			values[i] = escapeFormula(*r.field(column))
+		}
+	}
+	return e.csv.Write(values)
+}
+
+// formulaPrefixes 表計算ソフトが数式の始まりとして扱う文字
+const formulaPrefixes = "=+-@\t\r"
+
+// escapeFormula CSV を表計算ソフトで開いたときに数式として評価されないよう、該当する値の先頭に ' を付ける
+// 名前やプロフィールは本人が自由に登録できるため、"=HYPERLINK(...)" のような値も含まれうる
+func escapeFormula(value string) string {
+	if value != "" && strings.ContainsRune(formulaPrefixes, rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// unescapeFormula escapeFormula で付けた ' を外す（エクスポートした CSV をそのままインポートできるようにする）
+func unescapeFormula(value string) string {
+	if len(value) > 1 && value[0] == '\'' && strings.ContainsRune(formulaPrefixes, rune(value[1])) {
+		return value[1:]
+	}
+	return value
+}
+
+// Flush バッファした行を書き出す。行が 1 つも無い CSV はヘッダーだけを書く
+func (e *Encoder) Flush() error {
+	if e.csv == nil {
+		return nil
+	}
+	if !e.header {
+		if err := e.csv.Write(exportColumns); err != nil {
+			return err
+		}
+		e.header = true
+	}
+	e.csv.Flush()
+	return e.csv.Error()
+}
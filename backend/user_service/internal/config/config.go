@@ -1,7 +1,15 @@
 package config
 
 import (
+	"user_service/internal/blob"
+
+	"github.com/winyx/backend/common/featureflag"
+	"github.com/winyx/backend/common/maintenance"
+	"github.com/winyx/backend/common/notify"
+	"github.com/winyx/backend/common/rpc"
+
 	"github.com/zeromicro/go-zero/core/stores/cache"
+	"github.com/zeromicro/go-zero/core/stores/redis"
 	"github.com/zeromicro/go-zero/rest"
 )
 
@@ -11,8 +19,89 @@ type Config struct {
 		DataSource string
 	}
 	CacheConf cache.CacheConf
-	Auth struct {
+	Redis     redis.RedisConf // メンテナンス情報などの共有状態
+	Auth      struct {
 		AccessSecret string
 		AccessExpire int64
 	}
+	Maintenance  maintenance.Conf    `json:",optional"`
+	FeatureFlags featureflag.Conf    `json:",optional"`
+	ServiceAuth  rpc.ServiceAuthConf `json:",optional"` // /internal/v1 を呼び出すサービスとの共有シークレット
+	Invitation   InvitationConf      `json:",optional"`
+	Notifier     notify.Conf         `json:",optional"`
+	Org          OrgConf             `json:",optional"`
+	User         UserConf            `json:",optional"`
+	Cursor       CursorConf          `json:",optional"`
+	Audit        AuditConf           `json:",optional"`
+	Import       ImportConf          `json:",optional"`
+	Privacy      PrivacyConf         `json:",optional"`
+	Avatar       AvatarConf          `json:",optional"`
+	Blob         blob.Conf           `json:",optional"` // アバター画像などの保存先
+}
+
+// AvatarConf プロフィール画像のアップロードの設定
+type AvatarConf struct {
+	MaxBytes  int64  `json:",default=5242880"`                                    // アップロードするファイルの最大サイズ
+	MaxPixels int    `json:",default=25000000"`                                   // 展開後の最大画素数（圧縮率の高い巨大画像でメモリを使い切らないため）
+	PublicUrl string `json:",default=http://localhost:8888/api/v1/media/avatars"` // avatar_url に載せる URL の接頭辞（CDN を挟む場合はその URL）
+}
+
+// PrivacyConf 個人データのエクスポート・消去の設定
+type PrivacyConf struct {
+	ExportExpireHours int `json:",default=72"` // エクスポートしたアーカイブをダウンロードできる期間（経過後は Redis から消える）
+}
+
+// ImportConf ユーザー一括インポートの設定
+type ImportConf struct {
+	MaxRows          int    `json:",default=5000"`                                 // 1 回のインポートの最大行数
+	MaxBytes         int64  `json:",default=10485760"`                             // アップロードするファイルの最大サイズ
+	SetupUrl         string `json:",default=http://localhost:3000/password/setup"` // 招待メールに載せるパスワード設定画面のURL（?token= を付与）
+	SetupExpireHours int    `json:",default=168"`                                  // パスワード設定リンクの有効期限
+}
+
+// CursorConf 一覧 API のページングカーソルの設定
+type CursorConf struct {
+	Secret string `json:",optional"` // カーソルの署名鍵（省略時は Auth.AccessSecret から導出）
+}
+
+// SigningSecret 署名鍵。JWT と同じ鍵をそのまま使わないよう、省略時は用途を付けて導出する
+func (c CursorConf) SigningSecret(accessSecret string) string {
+	if c.Secret != "" {
+		return c.Secret
+	}
+	return "cursor:" + accessSecret
+}
+
+// AuditConf 監査ログの設定
+type AuditConf struct {
+	DigestSecret string `json:",optional"` // 氏名・メールアドレスの代わりに記録するハッシュの鍵（省略時は Auth.AccessSecret から導出）
+}
+
+// DigestKey ハッシュの鍵。鍵を知らなければ、記録したハッシュから元の値を総当たりで求められない
+func (c AuditConf) DigestKey(accessSecret string) string {
+	if c.DigestSecret != "" {
+		return c.DigestSecret
+	}
+	return "audit:" + accessSecret
+}
+
+// OrgConf 組織のソフトデリート・所有権移譲の設定
+type OrgConf struct {
+	RestoreDays          int `json:",default=30"`  // 削除した組織を復元できる期間（経過後に物理削除）
+	PurgeIntervalMinutes int `json:",default=60"`  // 物理削除ジョブの実行間隔
+	PurgeBatchSize       int `json:",default=100"` // 1回のジョブで物理削除する最大件数
+	TransferExpireHours  int `json:",default=72"`  // 所有権移譲依頼の有効期限
+}
+
+// UserConf ユーザーのソフトデリートの設定
+type UserConf struct {
+	RestoreDays          int `json:",default=30"`  // 削除したユーザーを復元できる期間（経過後に物理削除）
+	PurgeIntervalMinutes int `json:",default=60"`  // 物理削除ジョブの実行間隔
+	PurgeBatchSize       int `json:",default=100"` // 1回のジョブで物理削除する最大件数
+}
+
+// InvitationConf 組織招待の設定
+type InvitationConf struct {
+	AcceptUrl   string `json:",default=http://localhost:3000/invitations/accept"` // 招待メールに載せる承諾画面のURL（?token= を付与）
+	ExpireHours int    `json:",default=168"`                                      // 有効期限の既定値
 }
@@ -0,0 +1,183 @@
+// Package cursor は一覧 API のキーセットページング用カーソルを作る
+//
+// カーソルは並べ替えキーと ID（同じキーの行の順序を決める）を持つ不透明な文字列で、
+// HMAC で署名するためクライアントが中身を書き換えて任意の位置を指定することはできない。
+// 一覧の種類と絞り込み条件もスコープとして署名に含め、条件を変えたリクエストでは使えないようにする。
+package cursor
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"user_service/internal/model"
+)
+
+// 配列をそのまま返す一覧で前後のページのカーソルを返すヘッダー
+const (
+	HeaderNext = "X-Next-Cursor"
+	HeaderPrev = "X-Prev-Cursor"
+)
+
+// ErrInvalid カーソルが壊れている・署名が一致しない・別の一覧や条件のもの
+var ErrInvalid = errors.New("cursor が不正です")
+
+// Position カーソルが指す位置
+// Key は行の並べ替えキー（string / time.Time、NULL の場合は nil）。ID 順の一覧では nil
+// Backward は前のページへ戻るカーソルであること（Position より前の行を返す）を表す
+type Position struct {
+	Key      any
+	Id       uint64
+	Backward bool
+}
+
+type payload struct {
+	Scope    string     `json:"s"`
+	Id       uint64     `json:"i"`
+	Str      *string    `json:"k,omitempty"`
+	Time     *time.Time `json:"t,omitempty"`
+	Backward bool       `json:"b,omitempty"`
+}
+
+// Codec カーソルの署名と検証
+type Codec struct {
+	secret []byte
+}
+
+func NewCodec(secret string) *Codec {
+	return &Codec{secret: []byte(secret)}
+}
+
+// Scope 一覧の種類と絞り込み条件からスコープを作る
+// 同じ一覧・同じ条件のリクエストで同じ値になるよう、条件は決まった順序で渡す
+func Scope(listing string, params ...any) string {
+	var b strings.Builder
+	b.WriteString(listing)
+	for _, param := range params {
+		fmt.Fprintf(&b, "\x00%v", param)
+	}
+	sum := sha256.Sum256([]byte(b.String()))
+	return hex.EncodeToString(sum[:8])
+}
+
+// Encode 位置をカーソルにする
+func (c *Codec) Encode(scope string, pos Position) string {
+	p := payload{Scope: scope, Id: pos.Id, Backward: pos.Backward}
+	switch key := pos.Key.(type) {
+	case string:
+		p.Str = &key
+	case time.Time:
+		p.Time = &key
+	case sql.NullTime:
+		if key.Valid {
+			p.Time = &key.Time
+		}
+	}
+
+	body, _ := json.Marshal(p)
+	return base64.RawURLEncoding.EncodeToString(body) + "." + base64.RawURLEncoding.EncodeToString(c.sign(body))
+}
+
+// Decode カーソルを検証して位置を返す。スコープが異なる場合も ErrInvalid
+func (c *Codec) Decode(scope, token string) (Position, error) {
+	encodedBody, encodedSig, ok := strings.Cut(token, ".")
+	if !ok {
+		return Position{}, ErrInvalid
+	}
+	body, err := base64.RawURLEncoding.DecodeString(encodedBody)
+	if err != nil {
+		return Position{}, ErrInvalid
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(encodedSig)
+	if err != nil || !hmac.Equal(sig, c.sign(body)) {
+		return Position{}, ErrInvalid
+	}
+
+	var p payload
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&p); err != nil || p.Scope != scope || p.Id == 0 {
+		return Position{}, ErrInvalid
+	}
+
+	pos := Position{Id: p.Id, Backward: p.Backward}
+	switch {
+	case p.Str != nil:
+		pos.Key = *p.Str
+	case p.Time != nil:
+		pos.Key = *p.Time
+	}
+	return pos, nil
+}
+
+// Parse リクエストのカーソルを検証する。カーソルが指定されていない場合は nil を返す
+func (c *Codec) Parse(scope, token string) (*Position, error) {
+	if token == "" {
+		return nil, nil
+	}
+	pos, err := c.Decode(scope, token)
+	if err != nil {
+		return nil, err
+	}
+	return &pos, nil
+}
+
+// KeysetPage モデルの検索条件にする（nil の場合は先頭から）
+func (p *Position) KeysetPage() model.KeysetPage {
+	if p == nil {
+		return model.KeysetPage{}
+	}
+	return model.KeysetPage{
+		After:    &model.Keyset{Value: p.Key, Id: p.Id},
+		Backward: p.Backward,
+	}
+}
+
+// Paginate 1 件多く（limit+1 件まで）取得した行を 1 ページ分に切り詰め、前後のページのカーソルを返す
+// rows は一覧の順序で並んでいること。from はリクエストのカーソル、offset はページ番号指定の場合の位置で、
+// どちらも無い先頭ページでは前のページのカーソルを返さない。続きが無い方向のカーソルは空文字
+func Paginate[T any](c *Codec, scope string, from *Position, offset int, rows []T, limit int, position func(T) Position) (page []T, next, prev string) {
+	backward := from != nil && from.Backward
+	more := len(rows) > limit
+	if more {
+		// 後ろ向きに取得した場合、余分な 1 件は先頭側にある
+		if backward {
+			rows = rows[len(rows)-limit:]
+		} else {
+			rows = rows[:limit]
+		}
+	}
+	if len(rows) == 0 {
+		return rows, "", ""
+	}
+
+	hasNext, hasPrev := more, from != nil || offset > 0
+	if backward {
+		hasNext, hasPrev = true, more
+	}
+	if hasNext {
+		pos := position(rows[len(rows)-1])
+		pos.Backward = false
+		next = c.Encode(scope, pos)
+	}
+	if hasPrev {
+		pos := position(rows[0])
+		pos.Backward = true
+		prev = c.Encode(scope, pos)
+	}
+	return rows, next, prev
+}
+
+func (c *Codec) sign(body []byte) []byte {
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write(body)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,192 @@
+// Package dataloader はユーザー一覧・詳細のロールとプロフィールをまとめて読み込む
+//
+// ユーザーごとに問い合わせると一覧の件数だけクエリが発行される（N+1）ため、
+// 表示するユーザーを先に登録しておき、最初に必要になった時点で登録済みのユーザー分を 1 クエリで取得する。
+// 読み込んだ値はリクエストの間だけ保持し、同じリクエスト内の別の処理からも再利用する。
+package dataloader
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"user_service/internal/model"
+	"user_service/internal/types"
+)
+
+type loaderKey struct{}
+
+// Loaders リクエストごとのローダーを作る
+type Loaders struct {
+	roles    model.UserRolesModel
+	profiles model.UserProfilesModel
+}
+
+func New(roles model.UserRolesModel, profiles model.UserProfilesModel) *Loaders {
+	return &Loaders{roles: roles, profiles: profiles}
+}
+
+// Middleware リクエスト用のローダーをコンテキストに格納する（server.Use に渡す）
+func (l *Loaders) Middleware(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), loaderKey{}, l.NewUsers())
+		next(w, r.WithContext(ctx))
+	}
+}
+
+// Users リクエストのローダーを返す。Middleware を通っていない場合（ジョブなど）は呼び出しごとに新しく作る
+func (l *Loaders) Users(ctx context.Context) *UserLoader {
+	if loader, ok := ctx.Value(loaderKey{}).(*UserLoader); ok {
+		return loader
+	}
+	return l.NewUsers()
+}
+
+// NewUsers リクエストとは別のローダーを作る
+// 読み込んだ値は捨てられないため、大量のユーザーを区切って処理する場合は区切りごとに作る
+func (l *Loaders) NewUsers() *UserLoader {
+	return &UserLoader{
+		roles:    newBatch(l.fetchRoles),
+		profiles: newBatch(l.fetchProfiles),
+	}
+}
+
+// fetchRoles ユーザーごとのロール名（割り当て順）
+func (l *Loaders) fetchRoles(ctx context.Context, userIds []uint64) (map[uint64][]string, error) {
+	ids := make([]int64, len(userIds))
+	for i, id := range userIds {
+		ids[i] = int64(id)
+	}
+	rows, err := l.roles.FindByUserIds(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	roles := make(map[uint64][]string, len(userIds))
+	for _, row := range rows {
+		roles[uint64(row.UserId)] = append(roles[uint64(row.UserId)], row.RoleName)
+	}
+	return roles, nil
+}
+
+func (l *Loaders) fetchProfiles(ctx context.Context, userIds []uint64) (map[uint64]*model.UserProfiles, error) {
+	rows, err := l.profiles.FindByUserIds(ctx, userIds)
+	if err != nil {
+		return nil, err
+	}
+
+	profiles := make(map[uint64]*model.UserProfiles, len(rows))
+	for _, row := range rows {
+		profiles[row.UserId] = row
+	}
+	return profiles, nil
+}
+
+// UserLoader 1 リクエスト分のユーザーのロールとプロフィール
+type UserLoader struct {
+	roles    *batch[[]string]
+	profiles *batch[*model.UserProfiles]
+}
+
+// Prime 次の読み込みでまとめて取得するユーザーを登録する（一覧では変換の前に全員を登録する）
+func (u *UserLoader) Prime(userIds ...uint64) {
+	u.roles.prime(userIds)
+	u.profiles.prime(userIds)
+}
+
+// Roles ユーザーのロール名。ロールが無い場合は空
+func (u *UserLoader) Roles(ctx context.Context, userId uint64) ([]string, error) {
+	return u.roles.load(ctx, userId)
+}
+
+// Profile ユーザーのプロフィール。プロフィールが無い場合は nil
+func (u *UserLoader) Profile(ctx context.Context, userId uint64) (*model.UserProfiles, error) {
+	return u.profiles.load(ctx, userId)
+}
+
+// Forget ユーザーの読み込み済みの値を捨てる（同じリクエスト内で更新した後に読み直す場合）
+func (u *UserLoader) Forget(userId uint64) {
+	u.roles.forget(userId)
+	u.profiles.forget(userId)
+}
+
+// ProfileData プロフィールの行をレスポンスの形にする（nil は nil）
+// birth_date が未設定の場合は空文字にする（現在日付などで埋めない）
+func ProfileData(profile *model.UserProfiles) *types.UserProfileData {
+	if profile == nil {
+		return nil
+	}
+	data := &types.UserProfileData{
+		AvatarUrl:   profile.AvatarUrl,
+		Bio:         profile.Bio,
+		Phone:       profile.Phone,
+		Address:     profile.Address,
+		Gender:      profile.Gender,
+		Occupation:  profile.Occupation,
+		Website:     profile.Website,
+		SocialLinks: profile.SocialLinks,
+	}
+	if profile.BirthDate.Valid {
+		data.BirthDate = profile.BirthDate.Time.Format("2006-01-02")
+	}
+	return data
+}
+
+// batch 登録済みのキーをまとめて取得し、結果を保持する
+type batch[V any] struct {
+	mu      sync.Mutex
+	fetch   func(ctx context.Context, keys []uint64) (map[uint64]V, error)
+	pending map[uint64]struct{}
+	values  map[uint64]V
+}
+
+func newBatch[V any](fetch func(ctx context.Context, keys []uint64) (map[uint64]V, error)) *batch[V] {
+	return &batch[V]{
+		fetch:   fetch,
+		pending: make(map[uint64]struct{}),
+		values:  make(map[uint64]V),
+	}
+}
+
+func (b *batch[V]) prime(keys []uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, key := range keys {
+		if _, ok := b.values[key]; !ok {
+			b.pending[key] = struct{}{}
+		}
+	}
+}
+
+func (b *batch[V]) load(ctx context.Context, key uint64) (V, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if value, ok := b.values[key]; ok {
+		return value, nil
+	}
+
+	b.pending[key] = struct{}{}
+	keys := make([]uint64, 0, len(b.pending))
+	for pending := range b.pending {
+		keys = append(keys, pending)
+	}
+	found, err := b.fetch(ctx, keys)
+	if err != nil {
+		// 登録は残し、次の呼び出しで取得し直す
+		var zero V
+		return zero, err
+	}
+
+	// 見つからなかったキーもゼロ値で保持し、同じキーで問い合わせ直さない
+	clear(b.pending)
+	for _, k := range keys {
+		b.values[k] = found[k]
+	}
+	return b.values[key], nil
+}
+
+func (b *batch[V]) forget(key uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.values, key)
+}
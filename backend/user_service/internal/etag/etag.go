@@ -0,0 +1,117 @@
+// Package etag はリソースのバージョンを ETag として返し、If-Match による条件付き更新を扱う
+//
+// ETag は version 列から作る強い ETag で、更新系の API は If-Match が一致した場合のみ書き込む。
+// 不一致の場合は 412 と最新の表現を返し、クライアントが取り込み直せるようにする。
+package etag
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"user_service/internal/model"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+const (
+	HeaderETag    = "ETag"
+	HeaderIfMatch = "If-Match"
+)
+
+// ErrPreconditionRequired If-Match が指定されていない
+var ErrPreconditionRequired = errors.New("If-Match ヘッダーに取得時の ETag を指定してください")
+
+// PreconditionFailedError If-Match が現在の ETag と一致しない
+// Current は 412 のボディとして返す最新の表現
+type PreconditionFailedError struct {
+	ETag    string
+	Current any
+}
+
+func (e *PreconditionFailedError) Error() string {
+	return "他の操作で更新されています。最新の内容を確認してから再度更新してください"
+}
+
+type errorRes struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Format バージョンから ETag を作る
+func Format(versions ...uint64) string {
+	parts := make([]string, len(versions))
+	for i, v := range versions {
+		parts[i] = strconv.FormatUint(v, 10)
+	}
+	return `"` + strings.Join(parts, ".") + `"`
+}
+
+// User ユーザーの ETag（管理画面で一緒に編集するプロフィールのバージョンを含む）
+// プロフィールが無い場合は nil を渡す
+func User(user *model.Users, profile *model.UserProfiles) string {
+	if profile == nil {
+		return Format(user.Version)
+	}
+	return Format(user.Version, profile.Version)
+}
+
+// Profile 本人が編集するプロフィール単体の ETag
+func Profile(profile *model.UserProfiles) string {
+	return Format(profile.Version)
+}
+
+// Org 組織の ETag
+func Org(org *model.Orgs) string {
+	return Format(org.Version)
+}
+
+// Require If-Match が指定されているか確認する
+func Require(ifMatch string) error {
+	if strings.TrimSpace(ifMatch) == "" {
+		return ErrPreconditionRequired
+	}
+
+	return nil
+}
+
+// Match If-Match の値（カンマ区切りの ETag の並び、または *）が tag を含むか
+// If-Match は強い比較のため、弱い ETag（W/ 付き）とは一致しない
+func Match(ifMatch, tag string) bool {
+	for _, candidate := range strings.Split(ifMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || candidate == tag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WriteJson ETag ヘッダーを付けてレスポンスを返す
+func WriteJson(ctx context.Context, w http.ResponseWriter, tag string, resp any) {
+	w.Header().Set(HeaderETag, tag)
+	httpx.OkJsonCtx(ctx, w, resp)
+}
+
+// WriteError 条件付き更新のエラーを 412 / 428 で返す。それ以外のエラーは通常どおり処理する
+func WriteError(ctx context.Context, w http.ResponseWriter, err error) {
+	var failed *PreconditionFailedError
+	switch {
+	case errors.As(err, &failed):
+		// 対象がまだ存在しない場合は ETag が無い
+		if failed.ETag != "" {
+			w.Header().Set(HeaderETag, failed.ETag)
+		}
+		httpx.WriteJsonCtx(ctx, w, http.StatusPreconditionFailed, failed.Current)
+	case errors.Is(err, ErrPreconditionRequired):
+		httpx.WriteJsonCtx(ctx, w, http.StatusPreconditionRequired, errorRes{
+			Code:    http.StatusPreconditionRequired,
+			Message: err.Error(),
+		})
+	default:
+		httpx.ErrorCtx(ctx, w, err)
+	}
+}
@@ -0,0 +1,35 @@
+package admin
+
+import (
+	"net/http"
+
+	"user_service/internal/logic/admin"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+func ExportUsersHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.UserExportReq
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		l := admin.NewExportUsersLogic(r.Context(), svcCtx)
+		export, err := l.ExportUsers(&req)
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		// 本文は逐次書き出すため、途中で失敗した場合はログに残して応答を打ち切る
+		w.Header().Set("Content-Type", export.Format.ContentType())
+		w.Header().Set("Content-Disposition", `attachment; filename="users.`+export.Format.Extension()+`"`)
+		if _, err := export.WriteTo(w); err != nil {
+			logx.WithContext(r.Context()).Errorf("Failed to export users: %v", err)
+		}
+	}
+}
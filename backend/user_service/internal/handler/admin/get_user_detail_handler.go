@@ -3,6 +3,7 @@ package admin
 import (
 	"net/http"
 
+	"user_service/internal/etag"
 	"user_service/internal/logic/admin"
 	"user_service/internal/svc"
 	"user_service/internal/types"
@@ -22,7 +23,7 @@ func GetUserDetailHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
 		if err != nil {
 			httpx.ErrorCtx(r.Context(), w, err)
 		} else {
-			httpx.OkJsonCtx(r.Context(), w, resp)
+			etag.WriteJson(r.Context(), w, resp.User.Etag, resp)
 		}
 	}
 }
\ No newline at end of file
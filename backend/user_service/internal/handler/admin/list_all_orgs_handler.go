@@ -4,18 +4,34 @@ import (
 	"net/http"
 
 	"github.com/zeromicro/go-zero/rest/httpx"
+	"user_service/internal/cursor"
 	"user_service/internal/logic/admin"
 	"user_service/internal/svc"
+	"user_service/internal/types"
 )
 
 func ListAllOrgsHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.OrgListReq
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
 		l := admin.NewListAllOrgsLogic(r.Context(), svcCtx)
-		resp, err := l.ListAllOrgs()
+		resp, err := l.ListAllOrgs(&req)
 		if err != nil {
 			httpx.ErrorCtx(r.Context(), w, err)
-		} else {
-			httpx.OkJsonCtx(r.Context(), w, resp)
+			return
+		}
+
+		// ボディは従来どおり組織の配列のため、カーソルはヘッダーで返す
+		if resp.NextCursor != "" {
+			w.Header().Set(cursor.HeaderNext, resp.NextCursor)
+		}
+		if resp.PrevCursor != "" {
+			w.Header().Set(cursor.HeaderPrev, resp.PrevCursor)
 		}
+		httpx.OkJsonCtx(r.Context(), w, resp.Orgs)
 	}
 }
@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"net/http"
+
+	"user_service/internal/etag"
+	"user_service/internal/logic"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+func DeleteAvatarHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.AvatarReq
+		if err := httpx.ParseHeaders(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		l := logic.NewDeleteAvatarLogic(r.Context(), svcCtx)
+		resp, err := l.DeleteAvatar(&req)
+		switch {
+		case err != nil:
+			etag.WriteError(r.Context(), w, err)
+		case resp.Etag == "":
+			httpx.OkJsonCtx(r.Context(), w, resp)
+		default:
+			etag.WriteJson(r.Context(), w, resp.Etag, resp)
+		}
+	}
+}
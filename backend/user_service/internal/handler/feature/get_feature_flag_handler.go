@@ -0,0 +1,28 @@
+package feature
+
+import (
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+	"user_service/internal/logic/feature"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+)
+
+func GetFeatureFlagHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.FeatureFlagKeyReq
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		l := feature.NewGetFeatureFlagLogic(r.Context(), svcCtx)
+		resp, err := l.GetFeatureFlag(&req)
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.OkJsonCtx(r.Context(), w, resp)
+		}
+	}
+}
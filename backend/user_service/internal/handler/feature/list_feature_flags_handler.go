@@ -0,0 +1,21 @@
+package feature
+
+import (
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+	"user_service/internal/logic/feature"
+	"user_service/internal/svc"
+)
+
+func ListFeatureFlagsHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		l := feature.NewListFeatureFlagsLogic(r.Context(), svcCtx)
+		resp, err := l.ListFeatureFlags()
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.OkJsonCtx(r.Context(), w, resp)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"user_service/internal/logic"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+func GetAvatarHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.AvatarFileReq
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		l := logic.NewGetAvatarLogic(r.Context(), svcCtx)
+		object, err := l.GetAvatar(&req)
+		switch {
+		case errors.Is(err, logic.ErrAvatarNotFound):
+			http.NotFound(w, r)
+			return
+		case err != nil:
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		// ファイル名は内容のハッシュなので、同じ URL の内容は変わらない
+		w.Header().Set("Content-Type", object.ContentType)
+		w.Header().Set("Content-Length", strconv.Itoa(len(object.Data)))
+		w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		if _, err := w.Write(object.Data); err != nil {
+			logx.WithContext(r.Context()).Errorf("Failed to write avatar %s: %v", req.Name, err)
+		}
+	}
+}
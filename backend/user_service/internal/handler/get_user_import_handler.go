@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"net/http"
+
+	"user_service/internal/logic"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+func GetUserImportHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.UserImportJobReq
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		l := logic.NewGetUserImportLogic(r.Context(), svcCtx)
+		resp, err := l.GetUserImport(&req)
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.OkJsonCtx(r.Context(), w, resp)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package handler
+
+import (
+	"net/http"
+
+	"user_service/internal/logic"
+	"user_service/internal/svc"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+func HealthHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		l := logic.NewHealthLogic(r.Context(), svcCtx)
+		resp, err := l.Health()
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.OkJsonCtx(r.Context(), w, resp)
+		}
+	}
+}
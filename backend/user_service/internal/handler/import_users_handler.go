@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"net/http"
+
+	"user_service/internal/logic"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+func ImportUsersHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.UserImportReq
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		// ボディはファイルそのものなので、読み込む量を設定の上限までに制限する
+		body := http.MaxBytesReader(w, r.Body, svcCtx.Config.Import.MaxBytes)
+
+		l := logic.NewImportUsersLogic(r.Context(), svcCtx)
+		resp, err := l.ImportUsers(&req, body, r.Header.Get("Content-Type"))
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.WriteJsonCtx(r.Context(), w, http.StatusAccepted, resp)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package internalapi
+
+import (
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+	"user_service/internal/logic/internalapi"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+)
+
+func CheckOrgPermissionHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.CheckOrgPermissionReq
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		l := internalapi.NewCheckOrgPermissionLogic(r.Context(), svcCtx)
+		resp, err := l.CheckOrgPermission(&req)
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.OkJsonCtx(r.Context(), w, resp)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/zeromicro/go-zero/rest/httpx"
+	"user_service/internal/etag"
 	"user_service/internal/logic/org"
 	"user_service/internal/svc"
 	"user_service/internal/types"
@@ -22,7 +23,7 @@ func GetOrgHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
 		if err != nil {
 			httpx.ErrorCtx(r.Context(), w, err)
 		} else {
-			httpx.OkJsonCtx(r.Context(), w, resp)
+			etag.WriteJson(r.Context(), w, resp.Etag, resp)
 		}
 	}
 }
@@ -0,0 +1,39 @@
+package org
+
+import (
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+	"user_service/internal/etag"
+	"user_service/internal/logic/org"
+	"user_service/internal/mergepatch"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+)
+
+func PatchOrgHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.PatchOrgReq
+		if err := httpx.ParsePath(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+		if err := httpx.ParseHeaders(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+		var patch org.OrgPatch
+		if err := mergepatch.Decode(r, &patch); err != nil {
+			mergepatch.WriteError(r.Context(), w, err)
+			return
+		}
+
+		l := org.NewPatchOrgLogic(r.Context(), svcCtx)
+		resp, err := l.PatchOrg(&req, &patch)
+		if err != nil {
+			mergepatch.WriteError(r.Context(), w, err)
+		} else {
+			etag.WriteJson(r.Context(), w, resp.Etag, resp)
+		}
+	}
+}
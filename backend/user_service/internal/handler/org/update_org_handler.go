@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/zeromicro/go-zero/rest/httpx"
+	"user_service/internal/etag"
 	"user_service/internal/logic/org"
 	"user_service/internal/svc"
 	"user_service/internal/types"
@@ -20,9 +21,9 @@ func UpdateOrgHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
 		l := org.NewUpdateOrgLogic(r.Context(), svcCtx)
 		resp, err := l.UpdateOrg(&req)
 		if err != nil {
-			httpx.ErrorCtx(r.Context(), w, err)
+			etag.WriteError(r.Context(), w, err)
 		} else {
-			httpx.OkJsonCtx(r.Context(), w, resp)
+			etag.WriteJson(r.Context(), w, resp.Etag, resp)
 		}
 	}
 }
@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"net/http"
+
+	"user_service/internal/etag"
+	"user_service/internal/logic"
+	"user_service/internal/mergepatch"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+func PatchProfileHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.ProfilePatchReq
+		if err := httpx.ParseHeaders(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+		var patch logic.ProfilePatch
+		if err := mergepatch.Decode(r, &patch); err != nil {
+			mergepatch.WriteError(r.Context(), w, err)
+			return
+		}
+
+		l := logic.NewPatchProfileLogic(r.Context(), svcCtx)
+		resp, err := l.PatchProfile(&req, &patch)
+		if err != nil {
+			mergepatch.WriteError(r.Context(), w, err)
+		} else {
+			etag.WriteJson(r.Context(), w, resp.Etag, resp)
+		}
+	}
+}
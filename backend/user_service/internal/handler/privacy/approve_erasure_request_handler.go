@@ -0,0 +1,28 @@
+package privacy
+
+import (
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+	"user_service/internal/logic/privacy"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+)
+
+func ApproveErasureRequestHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.ErasureReviewReq
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		l := privacy.NewApproveErasureRequestLogic(r.Context(), svcCtx)
+		resp, err := l.ApproveErasureRequest(&req)
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.OkJsonCtx(r.Context(), w, resp)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package privacy
+
+import (
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+	"user_service/internal/logic/privacy"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+)
+
+func CancelErasureRequestHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.ErasureRequestIdReq
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		l := privacy.NewCancelErasureRequestLogic(r.Context(), svcCtx)
+		resp, err := l.CancelErasureRequest(&req)
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.OkJsonCtx(r.Context(), w, resp)
+		}
+	}
+}
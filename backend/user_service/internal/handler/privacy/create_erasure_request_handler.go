@@ -0,0 +1,28 @@
+package privacy
+
+import (
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+	"user_service/internal/logic/privacy"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+)
+
+func CreateErasureRequestHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.ErasureRequestReq
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		l := privacy.NewCreateErasureRequestLogic(r.Context(), svcCtx)
+		resp, err := l.CreateErasureRequest(&req)
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.OkJsonCtx(r.Context(), w, resp)
+		}
+	}
+}
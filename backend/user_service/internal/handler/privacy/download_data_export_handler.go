@@ -0,0 +1,39 @@
+package privacy
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/rest/httpx"
+	"user_service/internal/logic/privacy"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+)
+
+func DownloadDataExportHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.DataExportIdReq
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		l := privacy.NewDownloadDataExportLogic(r.Context(), svcCtx)
+		file, err := l.DownloadDataExport(&req)
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		// 個人データのため中継するプロキシやブラウザにキャッシュさせない
+		w.Header().Set("Content-Type", file.Format.ContentType())
+		w.Header().Set("Content-Disposition", `attachment; filename="`+file.FileName(req.Id)+`"`)
+		w.Header().Set("Content-Length", strconv.Itoa(len(file.Data)))
+		w.Header().Set("Cache-Control", "no-store")
+		w.Header().Set("X-Content-Sha256", file.Sha256)
+		if _, err := w.Write(file.Data); err != nil {
+			logx.WithContext(r.Context()).Errorf("Failed to write data export %d: %v", req.Id, err)
+		}
+	}
+}
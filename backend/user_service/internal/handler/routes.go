@@ -7,7 +7,11 @@ import (
 	"net/http"
 
 	admin "user_service/internal/handler/admin"
+	feature "user_service/internal/handler/feature"
+	internalapi "user_service/internal/handler/internalapi"
 	org "user_service/internal/handler/org"
+	privacy "user_service/internal/handler/privacy"
+	tenant "user_service/internal/handler/tenant"
 	"user_service/internal/svc"
 
 	"github.com/zeromicro/go-zero/rest"
@@ -26,6 +30,16 @@ func RegisterHandlers(server *rest.Server, serverCtx *svc.ServiceContext) {
 				Path:    "/api/v1/users/register",
 				Handler: RegisterHandler(serverCtx),
 			},
+			{
+				Method:  http.MethodGet,
+				Path:    "/health",
+				Handler: HealthHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodGet,
+				Path:    "/api/v1/media/avatars/:name",
+				Handler: GetAvatarHandler(serverCtx),
+			},
 		},
 	)
 
@@ -41,9 +55,52 @@ func RegisterHandlers(server *rest.Server, serverCtx *svc.ServiceContext) {
 				Path:    "/user/profile",
 				Handler: UpdateProfileHandler(serverCtx),
 			},
+			{
+				Method:  http.MethodPatch,
+				Path:    "/user/profile",
+				Handler: PatchProfileHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodDelete,
+				Path:    "/user/avatar",
+				Handler: DeleteAvatarHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodPut,
+				Path:    "/v1/admin/users/:id",
+				Handler: UserUpdateHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodPatch,
+				Path:    "/v1/admin/users/:id",
+				Handler: UserPatchHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodDelete,
+				Path:    "/v1/admin/users/:id",
+				Handler: UserDeleteHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodPost,
+				Path:    "/v1/admin/users/:id/restore",
+				Handler: UserRestoreHandler(serverCtx),
+			},
+		},
+		rest.WithJwt(serverCtx.Config.Auth.AccessSecret),
+		rest.WithPrefix("/api"),
+	)
+
+	server.AddRoutes(
+		[]rest.Route{
+			{
+				Method:  http.MethodPost,
+				Path:    "/user/avatar",
+				Handler: UploadAvatarHandler(serverCtx),
+			},
 		},
 		rest.WithJwt(serverCtx.Config.Auth.AccessSecret),
 		rest.WithPrefix("/api"),
+		rest.WithMaxBytes(serverCtx.Config.Avatar.MaxBytes),
 	)
 
 	server.AddRoutes(
@@ -68,6 +125,11 @@ func RegisterHandlers(server *rest.Server, serverCtx *svc.ServiceContext) {
 				Path:    "/admin/users/:id",
 				Handler: admin.GetUserDetailHandler(serverCtx),
 			},
+			{
+				Method:  http.MethodGet,
+				Path:    "/admin/users/export",
+				Handler: admin.ExportUsersHandler(serverCtx),
+			},
 		},
 		rest.WithJwt(serverCtx.Config.Auth.AccessSecret),
 		rest.WithPrefix("/api/v1"),
@@ -95,6 +157,11 @@ func RegisterHandlers(server *rest.Server, serverCtx *svc.ServiceContext) {
 				Path:    "/orgs/:id",
 				Handler: org.UpdateOrgHandler(serverCtx),
 			},
+			{
+				Method:  http.MethodPatch,
+				Path:    "/orgs/:id",
+				Handler: org.PatchOrgHandler(serverCtx),
+			},
 			{
 				Method:  http.MethodDelete,
 				Path:    "/orgs/:id",
@@ -110,8 +177,294 @@ func RegisterHandlers(server *rest.Server, serverCtx *svc.ServiceContext) {
 				Path:    "/orgs/:id/members/:userId",
 				Handler: org.RemoveOrgMemberHandler(serverCtx),
 			},
+			{
+				Method:  http.MethodPost,
+				Path:    "/orgs/:id/token",
+				Handler: org.IssueOrgTokenHandler(serverCtx),
+			},
+		},
+		rest.WithJwt(serverCtx.Config.Auth.AccessSecret),
+		rest.WithPrefix("/api/v1"),
+	)
+
+	server.AddRoutes(
+		[]rest.Route{
+			{
+				Method:  http.MethodPost,
+				Path:    "/orgs/:id/invitations",
+				Handler: org.CreateOrgInvitationHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodGet,
+				Path:    "/orgs/:id/invitations",
+				Handler: org.ListOrgInvitationsHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodDelete,
+				Path:    "/orgs/:id/invitations/:invitationId",
+				Handler: org.RevokeOrgInvitationHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodPost,
+				Path:    "/invitations/accept",
+				Handler: org.AcceptInvitationHandler(serverCtx),
+			},
+		},
+		rest.WithJwt(serverCtx.Config.Auth.AccessSecret),
+		rest.WithPrefix("/api/v1"),
+	)
+
+	server.AddRoutes(
+		[]rest.Route{
+			{
+				Method:  http.MethodPost,
+				Path:    "/invitations/decline",
+				Handler: org.DeclineInvitationHandler(serverCtx),
+			},
+		},
+		rest.WithPrefix("/api/v1"),
+	)
+
+	server.AddRoutes(
+		[]rest.Route{
+			{
+				Method:  http.MethodPost,
+				Path:    "/orgs/:id/transfer",
+				Handler: org.RequestOrgTransferHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodGet,
+				Path:    "/orgs/:id/transfer",
+				Handler: org.GetOrgTransferHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodDelete,
+				Path:    "/orgs/:id/transfer",
+				Handler: org.CancelOrgTransferHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodPost,
+				Path:    "/orgs/:id/transfer/accept",
+				Handler: org.AcceptOrgTransferHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodPost,
+				Path:    "/orgs/:id/transfer/decline",
+				Handler: org.DeclineOrgTransferHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodGet,
+				Path:    "/orgs/deleted",
+				Handler: org.ListDeletedOrgsHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodPost,
+				Path:    "/orgs/:id/restore",
+				Handler: org.RestoreOrgHandler(serverCtx),
+			},
+		},
+		rest.WithJwt(serverCtx.Config.Auth.AccessSecret),
+		rest.WithPrefix("/api/v1"),
+	)
+
+	server.AddRoutes(
+		[]rest.Route{
+			{
+				Method:  http.MethodPost,
+				Path:    "/orgs/:id/api-keys",
+				Handler: org.CreateOrgApiKeyHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodGet,
+				Path:    "/orgs/:id/api-keys",
+				Handler: org.ListOrgApiKeysHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodDelete,
+				Path:    "/orgs/:id/api-keys/:keyId",
+				Handler: org.RevokeOrgApiKeyHandler(serverCtx),
+			},
+		},
+		rest.WithJwt(serverCtx.Config.Auth.AccessSecret),
+		rest.WithPrefix("/api/v1"),
+	)
+
+	server.AddRoutes(
+		rest.WithMiddlewares(
+			[]rest.Middleware{serverCtx.TenantMiddleware},
+			[]rest.Route{
+				{
+					Method:  http.MethodGet,
+					Path:    "/tenant",
+					Handler: tenant.GetTenantHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodGet,
+					Path:    "/tenant/members",
+					Handler: tenant.ListTenantMembersHandler(serverCtx),
+				},
+			}...,
+		),
+		rest.WithJwt(serverCtx.Config.Auth.AccessSecret),
+		rest.WithPrefix("/api/v1"),
+	)
+
+	server.AddRoutes(
+		[]rest.Route{
+			{
+				Method:  http.MethodGet,
+				Path:    "/admin/feature-flags",
+				Handler: feature.ListFeatureFlagsHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodPost,
+				Path:    "/admin/feature-flags",
+				Handler: feature.CreateFeatureFlagHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodGet,
+				Path:    "/admin/feature-flags/:key",
+				Handler: feature.GetFeatureFlagHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodPut,
+				Path:    "/admin/feature-flags/:key",
+				Handler: feature.UpdateFeatureFlagHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodDelete,
+				Path:    "/admin/feature-flags/:key",
+				Handler: feature.DeleteFeatureFlagHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodGet,
+				Path:    "/features",
+				Handler: feature.EvaluateFeaturesHandler(serverCtx),
+			},
+		},
+		rest.WithJwt(serverCtx.Config.Auth.AccessSecret),
+		rest.WithPrefix("/api/v1"),
+	)
+
+	server.AddRoutes(
+		[]rest.Route{
+			{
+				Method:  http.MethodGet,
+				Path:    "/admin/audit-logs",
+				Handler: admin.ListAuditLogsHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodGet,
+				Path:    "/admin/audit-logs/verify",
+				Handler: admin.VerifyAuditLogsHandler(serverCtx),
+			},
+		},
+		rest.WithJwt(serverCtx.Config.Auth.AccessSecret),
+		rest.WithPrefix("/api/v1"),
+	)
+
+	server.AddRoutes(
+		[]rest.Route{
+			{
+				Method:  http.MethodPost,
+				Path:    "/admin/users/import",
+				Handler: ImportUsersHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodGet,
+				Path:    "/admin/users/import/:id",
+				Handler: GetUserImportHandler(serverCtx),
+			},
+		},
+		rest.WithJwt(serverCtx.Config.Auth.AccessSecret),
+		rest.WithPrefix("/api/v1"),
+		rest.WithMaxBytes(serverCtx.Config.Import.MaxBytes),
+	)
+
+	server.AddRoutes(
+		[]rest.Route{
+			{
+				Method:  http.MethodPost,
+				Path:    "/users/password/setup",
+				Handler: SetupPasswordHandler(serverCtx),
+			},
+		},
+		rest.WithPrefix("/api/v1"),
+	)
+
+	server.AddRoutes(
+		[]rest.Route{
+			{
+				Method:  http.MethodPost,
+				Path:    "/users/me/data-exports",
+				Handler: privacy.CreateDataExportHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodGet,
+				Path:    "/users/me/data-exports/:id",
+				Handler: privacy.GetDataExportHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodGet,
+				Path:    "/users/me/data-exports/:id/download",
+				Handler: privacy.DownloadDataExportHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodPost,
+				Path:    "/users/me/erasure-requests",
+				Handler: privacy.CreateErasureRequestHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodGet,
+				Path:    "/users/me/erasure-requests",
+				Handler: privacy.ListMyErasureRequestsHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodDelete,
+				Path:    "/users/me/erasure-requests/:id",
+				Handler: privacy.CancelErasureRequestHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodGet,
+				Path:    "/admin/erasure-requests",
+				Handler: privacy.ListErasureRequestsHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodPost,
+				Path:    "/admin/erasure-requests/:id/approve",
+				Handler: privacy.ApproveErasureRequestHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodPost,
+				Path:    "/admin/erasure-requests/:id/reject",
+				Handler: privacy.RejectErasureRequestHandler(serverCtx),
+			},
 		},
 		rest.WithJwt(serverCtx.Config.Auth.AccessSecret),
 		rest.WithPrefix("/api/v1"),
 	)
+
+	server.AddRoutes(
+		rest.WithMiddlewares(
+			[]rest.Middleware{serverCtx.ServiceAuthMiddleware},
+			[]rest.Route{
+				{
+					Method:  http.MethodPost,
+					Path:    "/users/permission",
+					Handler: internalapi.CheckPermissionHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/users/validate",
+					Handler: internalapi.ValidateUserHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/orgs/permission",
+					Handler: internalapi.CheckOrgPermissionHandler(serverCtx),
+				},
+			}...,
+		),
+		rest.WithPrefix("/internal/v1"),
+	)
 }
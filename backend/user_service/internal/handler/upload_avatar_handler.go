@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"net/http"
+
+	"user_service/internal/etag"
+	"user_service/internal/logic"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+func UploadAvatarHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.AvatarReq
+		if err := httpx.ParseHeaders(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		// multipart のボディを直接読むため、チャンク転送で Content-Length が無い場合も上限で止める
+		body := http.MaxBytesReader(w, r.Body, svcCtx.Config.Avatar.MaxBytes)
+
+		l := logic.NewUploadAvatarLogic(r.Context(), svcCtx)
+		resp, err := l.UploadAvatar(&req, body, r.Header.Get("Content-Type"))
+		if err != nil {
+			etag.WriteError(r.Context(), w, err)
+		} else {
+			etag.WriteJson(r.Context(), w, resp.Etag, resp)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"net/http"
+
+	"user_service/internal/etag"
+	"user_service/internal/logic"
+	"user_service/internal/mergepatch"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+func UserPatchHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// ボディは送られた項目だけを扱うため httpx.Parse ではなく mergepatch で読む
+		var req types.UserPatchReq
+		if err := httpx.ParsePath(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+		if err := httpx.ParseHeaders(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+		var patch logic.UserPatch
+		if err := mergepatch.Decode(r, &patch); err != nil {
+			mergepatch.WriteError(r.Context(), w, err)
+			return
+		}
+
+		l := logic.NewUserPatchLogic(r.Context(), svcCtx)
+		resp, err := l.UserPatch(&req, &patch)
+		if err != nil {
+			mergepatch.WriteError(r.Context(), w, err)
+		} else {
+			etag.WriteJson(r.Context(), w, resp.User.Etag, resp)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package handler
+
+import (
+	"net/http"
+
+	"user_service/internal/etag"
+	"user_service/internal/logic"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+func UserRestoreHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.UserRestoreReq
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		l := logic.NewUserRestoreLogic(r.Context(), svcCtx)
+		resp, err := l.UserRestore(&req)
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			etag.WriteJson(r.Context(), w, resp.User.Etag, resp)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package handler
 import (
 	"net/http"
 
+	"user_service/internal/etag"
 	"user_service/internal/logic"
 	"user_service/internal/svc"
 	"user_service/internal/types"
@@ -20,9 +21,9 @@ func UserUpdateHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
 		l := logic.NewUserUpdateLogic(r.Context(), svcCtx)
 		resp, err := l.UserUpdate(&req)
 		if err != nil {
-			httpx.ErrorCtx(r.Context(), w, err)
+			etag.WriteError(r.Context(), w, err)
 		} else {
-			httpx.OkJsonCtx(r.Context(), w, resp)
+			etag.WriteJson(r.Context(), w, resp.User.Etag, resp)
 		}
 	}
 }
@@ -0,0 +1,164 @@
+// Package imaging はアップロードされた画像を検証し、サムネイルに変換する
+//
+// 形式は先頭のバイト列から判定し（拡張子や Content-Type は信用しない）、JPEG・PNG・GIF のみ受け付ける。
+// 展開後のサイズが大きすぎる画像はデコードする前に拒否する。
+// 出力は画素から作り直すため、EXIF などのメタデータ（撮影位置を含む）は残らない。
+// JPEG の向き（EXIF Orientation）はメタデータを捨てる前に画素へ反映する。
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+)
+
+// Format 画像の形式
+type Format string
+
+const (
+	FormatJPEG Format = "jpeg"
+	FormatPNG  Format = "png"
+	FormatGIF  Format = "gif"
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("画像は JPEG・PNG・GIF のいずれかを指定してください")
+	ErrInvalidImage      = errors.New("画像を読み込めませんでした")
+)
+
+// jpegQuality サムネイルの JPEG の品質
+const jpegQuality = 85
+
+// TooLargeError 画素数が上限を超えている
+type TooLargeError struct {
+	Width, Height, MaxPixels int
+}
+
+func (e *TooLargeError) Error() string {
+	return fmt.Sprintf("画像が大きすぎます（%dx%d）。%d 画素以内の画像を指定してください", e.Width, e.Height, e.MaxPixels)
+}
+
+// Detect 先頭のバイト列から画像の形式を判定する
+func Detect(data []byte) (Format, error) {
+	switch http.DetectContentType(data) {
+	case "image/jpeg":
+		return FormatJPEG, nil
+	case "image/png":
+		return FormatPNG, nil
+	case "image/gif":
+		return FormatGIF, nil
+	default:
+		return "", ErrUnsupportedFormat
+	}
+}
+
+// Image デコードして向きを補正した画像
+type Image struct {
+	Format Format
+	pixels *image.RGBA
+}
+
+// Decode 画像を検証してデコードする。maxPixels を超える画像は展開せずに TooLargeError を返す
+// GIF はアニメーションでも最初のフレームのみを使う
+func Decode(data []byte, maxPixels int) (*Image, error) {
+	format, err := Detect(data)
+	if err != nil {
+		return nil, err
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+	if config.Width <= 0 || config.Height <= 0 {
+		return nil, ErrInvalidImage
+	}
+	if config.Width > maxPixels/config.Height {
+		return nil, &TooLargeError{Width: config.Width, Height: config.Height, MaxPixels: maxPixels}
+	}
+
+	var src image.Image
+	switch format {
+	case FormatJPEG:
+		src, err = jpeg.Decode(bytes.NewReader(data))
+	case FormatPNG:
+		src, err = png.Decode(bytes.NewReader(data))
+	case FormatGIF:
+		src, err = gif.Decode(bytes.NewReader(data))
+	}
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+
+	bounds := src.Bounds()
+	pixels := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(pixels, pixels.Bounds(), src, bounds.Min, draw.Src)
+	if format == FormatJPEG {
+		pixels = orient(pixels, jpegOrientation(data))
+	}
+
+	return &Image{Format: format, pixels: pixels}, nil
+}
+
+// Bounds 向きを補正した後の大きさ
+func (img *Image) Bounds() image.Rectangle {
+	return img.pixels.Bounds()
+}
+
+// OutputFormat サムネイルの形式。透過を含まない画像は JPEG、含む画像は PNG にする
+// 元画像で決めるため、縮小で透過が消えるサイズがあっても全サイズが同じ形式になる
+func (img *Image) OutputFormat() Format {
+	if img.pixels.Opaque() {
+		return FormatJPEG
+	}
+	return FormatPNG
+}
+
+// Thumbnail 中央を正方形に切り抜き、size x size に縮小（小さい画像は拡大）する
+func (img *Image) Thumbnail(size int) *image.RGBA {
+	bounds := img.pixels.Bounds()
+	side := min(bounds.Dx(), bounds.Dy())
+	x0 := (bounds.Dx() - side) / 2
+	y0 := (bounds.Dy() - side) / 2
+	square := img.pixels.SubImage(image.Rect(x0, y0, x0+side, y0+side)).(*image.RGBA)
+
+	return resize(square, size, size)
+}
+
+// Encode サムネイルを format（JPEG か PNG）で書き出す
+func Encode(thumbnail *image.RGBA, format Format) ([]byte, error) {
+	var buf bytes.Buffer
+	switch format {
+	case FormatJPEG:
+		if err := jpeg.Encode(&buf, thumbnail, &jpeg.Options{Quality: jpegQuality}); err != nil {
+			return nil, err
+		}
+	case FormatPNG:
+		encoder := png.Encoder{CompressionLevel: png.BestCompression}
+		if err := encoder.Encode(&buf, thumbnail); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+	return buf.Bytes(), nil
+}
+
+// ContentType 形式の MIME タイプ
+func (f Format) ContentType() string {
+	return "image/" + string(f)
+}
+
+// Extension 形式のファイル名の拡張子
+func (f Format) Extension() string {
+	if f == FormatJPEG {
+		return "jpg"
+	}
+	return string(f)
+}
@@ -0,0 +1,92 @@
+package imaging
+
+import (
+	"image"
+	"math"
+)
+
+// resize 面積平均で拡大・縮小する
+// 縮小では出力の 1 画素が覆う入力の画素を面積で重み付けして平均するため、間引きによるモアレが出ない。
+// 横方向と縦方向を順に処理し、計算量を入力の画素数に比例させる
+func resize(src *image.RGBA, width, height int) *image.RGBA {
+	bounds := src.Bounds()
+	horizontal := image.NewRGBA(image.Rect(0, 0, width, bounds.Dy()))
+	xWeights := weights(bounds.Dx(), width)
+	for y := 0; y < bounds.Dy(); y++ {
+		in := src.Pix[src.PixOffset(bounds.Min.X, bounds.Min.Y+y):]
+		out := horizontal.Pix[horizontal.PixOffset(0, y):]
+		for x, w := range xWeights {
+			blend(out[x*4:x*4+4], in, w, 4)
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	yWeights := weights(bounds.Dy(), height)
+	for x := 0; x < width; x++ {
+		in := horizontal.Pix[x*4:]
+		for y, w := range yWeights {
+			offset := dst.PixOffset(x, y)
+			blend(dst.Pix[offset:offset+4], in, w, horizontal.Stride)
+		}
+	}
+	return dst
+}
+
+// span 出力の 1 画素に寄与する入力の画素の範囲と重み
+type span struct {
+	start   int
+	weights []float64
+}
+
+// weights 入力 n 画素を出力 m 画素に対応させたときの、出力の各画素の重み
+func weights(n, m int) []span {
+	spans := make([]span, m)
+	scale := float64(n) / float64(m)
+	for i := range spans {
+		left := float64(i) * scale
+		right := left + scale
+		start := int(math.Floor(left))
+		end := min(int(math.Ceil(right)), n)
+
+		s := span{start: start, weights: make([]float64, 0, end-start)}
+		var total float64
+		for j := start; j < end; j++ {
+			w := math.Min(right, float64(j+1)) - math.Max(left, float64(j))
+			s.weights = append(s.weights, w)
+			total += w
+		}
+		for k := range s.weights {
+			s.weights[k] /= total
+		}
+		spans[i] = s
+	}
+	return spans
+}
+
+// blend in の画素（stride 間隔）を重み付きで平均して out に書き込む
+// image.RGBA はアルファ乗算済みのため、チャンネルごとにそのまま平均できる
+func blend(out, in []uint8, s span, stride int) {
+	var r, g, b, a float64
+	for k, w := range s.weights {
+		p := in[(s.start+k)*stride:]
+		r += float64(p[0]) * w
+		g += float64(p[1]) * w
+		b += float64(p[2]) * w
+		a += float64(p[3]) * w
+	}
+	out[0] = clamp(r)
+	out[1] = clamp(g)
+	out[2] = clamp(b)
+	out[3] = clamp(a)
+}
+
+func clamp(v float64) uint8 {
+	v = math.Round(v)
+	if v < 0 {
+		return 0
+	}
+	if v > 255 {
+		return 255
+	}
+	return uint8(v)
+}
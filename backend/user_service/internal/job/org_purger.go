@@ -0,0 +1,133 @@
+// Package job user_service のバックグラウンドジョブ
+package job
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"user_service/internal/audit"
+	"user_service/internal/config"
+	"user_service/internal/model"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/stores/redis"
+	"github.com/zeromicro/go-zero/core/threading"
+)
+
+// 複数インスタンスで同時に実行しないためのロック
+const orgPurgeLockKey = "winyx:user_service:org_purge"
+
+// OrgPurger 復元期間を過ぎたソフトデリート済みの組織を定期的に物理削除する
+type OrgPurger struct {
+	orgs      model.OrgsModel
+	audit     *audit.Writer
+	lock      *redis.RedisLock
+	interval  time.Duration
+	retention time.Duration
+	batchSize int
+
+	stopOnce sync.Once
+	done     chan struct{}
+}
+
+// NewOrgPurger 新しい物理削除ジョブを作成
+func NewOrgPurger(orgs model.OrgsModel, auditWriter *audit.Writer, rds *redis.Redis, c config.OrgConf) *OrgPurger {
+	interval := time.Duration(c.PurgeIntervalMinutes) * time.Minute
+	lock := redis.NewRedisLock(rds, orgPurgeLockKey)
+	// 処理中にロックが切れないよう、実行間隔と同じだけ保持する
+	lock.SetExpire(int(interval.Seconds()))
+
+	return &OrgPurger{
+		orgs:      orgs,
+		audit:     auditWriter,
+		lock:      lock,
+		interval:  interval,
+		retention: time.Duration(c.RestoreDays) * 24 * time.Hour,
+		batchSize: max(c.PurgeBatchSize, 1),
+		done:      make(chan struct{}),
+	}
+}
+
+// Start 定期実行を開始
+func (p *OrgPurger) Start() {
+	if p.interval <= 0 {
+		logx.Info("Org purge job is disabled")
+		return
+	}
+
+	threading.GoSafe(p.run)
+}
+
+// Stop 定期実行を停止
+func (p *OrgPurger) Stop() {
+	p.stopOnce.Do(func() {
+		close(p.done)
+	})
+}
+
+func (p *OrgPurger) run() {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-ticker.C:
+			p.RunOnce(context.Background())
+		}
+	}
+}
+
+// RunOnce 1回分の物理削除を実行し、削除した件数を返す
+// 他のインスタンスが実行中の場合は何もしない
+func (p *OrgPurger) RunOnce(ctx context.Context) int {
+	logger := logx.WithContext(ctx)
+
+	acquired, err := p.lock.AcquireCtx(ctx)
+	if err != nil {
+		logger.Errorf("Failed to acquire org purge lock: %v", err)
+		return 0
+	}
+	if !acquired {
+		return 0
+	}
+	defer func() {
+		if _, err := p.lock.ReleaseCtx(ctx); err != nil {
+			logger.Errorf("Failed to release org purge lock: %v", err)
+		}
+	}()
+
+	deletedBefore := time.Now().Add(-p.retention)
+	orgs, err := p.orgs.FindDeletedBefore(ctx, deletedBefore, p.batchSize)
+	if err != nil {
+		logger.Errorf("Failed to find organizations to purge: %v", err)
+		return 0
+	}
+
+	purged := 0
+	for _, org := range orgs {
+		ok, err := p.orgs.Purge(ctx, org.Id, deletedBefore)
+		if err != nil {
+			logger.Errorf("Failed to purge organization %d: %v", org.Id, err)
+			continue
+		}
+		if ok {
+			purged++
+			// 操作者はシステム（actor_user_id = 0）として記録する
+			p.audit.Record(ctx, audit.Entry{
+				Action:     audit.ActionOrgPurge,
+				OrgId:      int64(org.Id),
+				TargetType: audit.TargetOrg,
+				TargetId:   org.Id,
+				Before:     audit.SnapshotOrg(org),
+			})
+		}
+	}
+	if purged > 0 {
+		logger.Infof("Purged %d soft-deleted organizations", purged)
+	}
+
+	return purged
+}
@@ -0,0 +1,209 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"user_service/internal/audit"
+	"user_service/internal/config"
+	"user_service/internal/model"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/stores/redis"
+	"github.com/zeromicro/go-zero/core/threading"
+)
+
+// 複数インスタンスで同時に実行しないためのロック
+const userPurgeLockKey = "winyx:user_service:user_purge"
+
+// UserPurgeModels ユーザーの物理削除で行を消すモデル
+// 参照している行も各モデルから消し、それぞれのキャッシュを破棄する
+type UserPurgeModels struct {
+	UnitOfWork   *model.UnitOfWork
+	Users        model.UsersModel
+	UserRoles    model.UserRolesModel
+	UserProfiles model.UserProfilesModel
+	OrgMembers   model.OrgMembersModel
+	Orgs         model.OrgsModel
+}
+
+// UserPurger 復元期間を過ぎたソフトデリート済みのユーザーを定期的に物理削除する
+// 個人データを消去（匿名化）したユーザーは完了記録の参照先として行を残すため対象外
+type UserPurger struct {
+	models    UserPurgeModels
+	audit     *audit.Writer
+	lock      *redis.RedisLock
+	interval  time.Duration
+	retention time.Duration
+	batchSize int
+
+	stopOnce sync.Once
+	done     chan struct{}
+}
+
+// NewUserPurger 新しい物理削除ジョブを作成
+func NewUserPurger(models UserPurgeModels, auditWriter *audit.Writer, rds *redis.Redis, c config.UserConf) *UserPurger {
+	interval := time.Duration(c.PurgeIntervalMinutes) * time.Minute
+	lock := redis.NewRedisLock(rds, userPurgeLockKey)
+	lock.SetExpire(int(interval.Seconds()))
+
+	return &UserPurger{
+		models:    models,
+		audit:     auditWriter,
+		lock:      lock,
+		interval:  interval,
+		retention: time.Duration(c.RestoreDays) * 24 * time.Hour,
+		batchSize: max(c.PurgeBatchSize, 1),
+		done:      make(chan struct{}),
+	}
+}
+
+// Start 定期実行を開始
+func (p *UserPurger) Start() {
+	if p.interval <= 0 {
+		logx.Info("User purge job is disabled")
+		return
+	}
+
+	threading.GoSafe(p.run)
+}
+
+// Stop 定期実行を停止
+func (p *UserPurger) Stop() {
+	p.stopOnce.Do(func() {
+		close(p.done)
+	})
+}
+
+func (p *UserPurger) run() {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-ticker.C:
+			p.RunOnce(context.Background())
+		}
+	}
+}
+
+// RunOnce 1回分の物理削除を実行し、削除した件数を返す
+// 他のインスタンスが実行中の場合は何もしない
+func (p *UserPurger) RunOnce(ctx context.Context) int {
+	logger := logx.WithContext(ctx)
+
+	acquired, err := p.lock.AcquireCtx(ctx)
+	if err != nil {
+		logger.Errorf("Failed to acquire user purge lock: %v", err)
+		return 0
+	}
+	if !acquired {
+		return 0
+	}
+	defer func() {
+		if _, err := p.lock.ReleaseCtx(ctx); err != nil {
+			logger.Errorf("Failed to release user purge lock: %v", err)
+		}
+	}()
+
+	deletedBefore := time.Now().Add(-p.retention)
+	purged := 0
+	// 削除に失敗したユーザーは次のページで読み飛ばし、後続のユーザーの削除を止めない
+	var after *model.Keyset
+	for purged < p.batchSize {
+		users, err := p.models.Users.FindDeletedBefore(ctx, deletedBefore, after, p.batchSize)
+		if err != nil {
+			logger.Errorf("Failed to find users to purge: %v", err)
+			break
+		}
+
+		for _, user := range users {
+			ok, err := p.purge(ctx, user, deletedBefore)
+			if err != nil {
+				logger.Errorf("Failed to purge user %d: %v", user.Id, err)
+				continue
+			}
+			if ok {
+				purged++
+				// 操作者はシステム（actor_user_id = 0）として記録する
+				p.audit.Record(ctx, audit.Entry{
+					Action:     audit.ActionUserPurge,
+					TargetType: audit.TargetUser,
+					TargetId:   user.Id,
+					Before:     p.audit.SnapshotUser(user),
+				})
+			}
+		}
+
+		if len(users) < p.batchSize {
+			break
+		}
+		last := users[len(users)-1]
+		after = &model.Keyset{Value: last.DeletedAt.Time, Id: last.Id}
+	}
+	if purged > 0 {
+		logger.Infof("Purged %d soft-deleted users", purged)
+	}
+
+	return purged
+}
+
+// purge ユーザーと参照している行を物理削除する。途中で復元された場合は false を返す
+func (p *UserPurger) purge(ctx context.Context, user *model.Users, deletedBefore time.Time) (bool, error) {
+	// 削除済み（復元待ち）の組織はオーナーと共に復元できなくなるため、先に物理削除する
+	if err := p.purgeOwnedOrgs(ctx, user.Id); err != nil {
+		return false, err
+	}
+
+	err := p.models.UnitOfWork.Do(ctx, func(ctx context.Context, tx *model.Tx) error {
+		if _, err := p.models.Users.LockDeletedTx(ctx, tx, user.Id, deletedBefore); err != nil {
+			return err
+		}
+		if err := p.models.OrgMembers.DeleteByUserIdTx(ctx, tx, user.Id); err != nil {
+			return err
+		}
+		if err := p.models.UserRoles.DeleteByUserIdTx(ctx, tx, int64(user.Id)); err != nil {
+			return err
+		}
+		if err := p.models.UserProfiles.DeleteByUserIdTx(ctx, tx, user.Id); err != nil {
+			return err
+		}
+		return p.models.Users.DeleteTx(ctx, tx, user.Id)
+	})
+	if errors.Is(err, model.ErrNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (p *UserPurger) purgeOwnedOrgs(ctx context.Context, userId uint64) error {
+	orgs, err := p.models.Orgs.FindByOwnerId(ctx, userId)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, org := range orgs {
+		if !org.DeletedAt.Valid {
+			continue
+		}
+		ok, err := p.models.Orgs.Purge(ctx, org.Id, now)
+		if err != nil {
+			return err
+		}
+		if ok {
+			p.audit.Record(ctx, audit.Entry{
+				Action:     audit.ActionOrgPurge,
+				OrgId:      int64(org.Id),
+				TargetType: audit.TargetOrg,
+				TargetId:   org.Id,
+				Before:     audit.SnapshotOrg(org),
+			})
+		}
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"user_service/internal/svc"
+
+	"github.com/winyx/backend/common/auth"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+var errAdminRequired = errors.New("システム管理者権限が必要です")
+
+// requireSystemAdmin 操作ユーザーがシステム管理者であることを確認
+// 組織内の admin ロールでは /admin 配下の API は利用できない
+func requireSystemAdmin(ctx context.Context, svcCtx *svc.ServiceContext) error {
+	userId, ok := auth.UserIdFromContext(ctx)
+	if !ok {
+		return fmt.Errorf("認証エラー: ユーザーIDが取得できません")
+	}
+
+	admin, err := svcCtx.OrgPermissions.IsSystemAdmin(ctx, uint64(userId))
+	if err != nil {
+		logx.WithContext(ctx).Errorf("Failed to check system role of user %d: %v", userId, err)
+		return fmt.Errorf("権限の確認に失敗しました")
+	}
+	if !admin {
+		return errAdminRequired
+	}
+
+	return nil
+}
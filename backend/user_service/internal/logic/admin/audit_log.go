@@ -0,0 +1,47 @@
+package admin
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"user_service/internal/audit"
+	"user_service/internal/model"
+	"user_service/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+func toAuditLog(row *model.AuditLogs) types.AuditLog {
+	result := types.AuditLog{
+		Id:            int64(row.Id),
+		ActorUserId:   int64(row.ActorUserId),
+		ActorApiKeyId: int64(row.ActorApiKeyId),
+		OrgId:         int64(row.OrgId),
+		Action:        row.Action,
+		TargetType:    row.TargetType,
+		TargetId:      row.TargetId,
+		Diff:          map[string]types.AuditChange{},
+		Ip:            row.Ip,
+		UserAgent:     row.UserAgent,
+		RequestId:     row.RequestId,
+		Hash:          row.Hash,
+		OccurredAt:    row.OccurredAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+
+	// ID などの大きな数値が丸められないよう json.Number のまま返す
+	var changes map[string]audit.Change
+	decoder := json.NewDecoder(bytes.NewReader([]byte(row.Diff)))
+	decoder.UseNumber()
+	if err := decoder.Decode(&changes); err != nil {
+		logx.Errorf("Invalid diff in audit log %d: %v", row.Id, err)
+		return result
+	}
+	for key, change := range changes {
+		result.Diff[key] = types.AuditChange{
+			Before: change.Before,
+			After:  change.After,
+		}
+	}
+
+	return result
+}
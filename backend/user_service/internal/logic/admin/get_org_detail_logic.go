@@ -24,6 +24,10 @@ func NewGetOrgDetailLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetO
 }
 
 func (l *GetOrgDetailLogic) GetOrgDetail(req *types.GetOrgReq) (resp *types.Org, err error) {
+	if err := requireSystemAdmin(l.ctx, l.svcCtx); err != nil {
+		return nil, err
+	}
+
 	// Admin権限で任意の組織詳細を取得
 	orgModel, err := l.svcCtx.OrgsModel.FindOne(l.ctx, uint64(req.Id))
 	if err != nil {
@@ -31,12 +35,17 @@ func (l *GetOrgDetailLogic) GetOrgDetail(req *types.GetOrgReq) (resp *types.Org,
 		return nil, err
 	}
 
-	// レスポンス構築
-	return &types.Org{
+	// レスポンス構築（削除済み・復元待ちの組織も deleted_at 付きで返す）
+	resp = &types.Org{
 		Id:        int64(orgModel.Id),
 		Name:      orgModel.Name,
 		OwnerId:   int64(orgModel.OwnerId),
 		CreatedAt: orgModel.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		UpdatedAt: orgModel.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
-	}, nil
+	}
+	if orgModel.DeletedAt.Valid {
+		resp.DeletedAt = orgModel.DeletedAt.Time.Format("2006-01-02T15:04:05Z07:00")
+	}
+
+	return resp, nil
 }
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 
+	"user_service/internal/dataloader"
+	"user_service/internal/etag"
 	"user_service/internal/svc"
 	"user_service/internal/types"
 
@@ -25,6 +27,10 @@ func NewGetUserDetailLogic(ctx context.Context, svcCtx *svc.ServiceContext) *Get
 }
 
 func (l *GetUserDetailLogic) GetUserDetail(req *types.UserDetailReq) (resp *types.UserDetailRes, err error) {
+	if err := requireSystemAdmin(l.ctx, l.svcCtx); err != nil {
+		return nil, err
+	}
+
 	// ユーザー情報の取得
 	user, err := l.svcCtx.UsersModel.FindOne(l.ctx, uint64(req.UserId))
 	if err != nil {
@@ -53,9 +59,20 @@ func (l *GetUserDetailLogic) GetUserDetail(req *types.UserDetailReq) (resp *type
 		UpdatedAt: user.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
 	}
 
-	// ロールやプロフィール情報は必要に応じて後から実装
-	// TODO: ユーザーのロール情報を取得
-	// TODO: ユーザーのプロフィール情報を取得
+	// ETag はユーザー更新 API と同じく、一緒に編集するプロフィールのバージョンも含める
+	loader := l.svcCtx.Loaders.Users(l.ctx)
+	profile, err := loader.Profile(l.ctx, user.Id)
+	if err != nil {
+		l.Errorf("Failed to find profile of user %d: %v", req.UserId, err)
+		return nil, fmt.Errorf("ユーザー情報の取得に失敗しました")
+	}
+	userInfo.Etag = etag.User(user, profile)
+	userInfo.Profile = dataloader.ProfileData(profile)
+
+	if userInfo.Roles, err = loader.Roles(l.ctx, user.Id); err != nil {
+		l.Errorf("Failed to find roles of user %d: %v", req.UserId, err)
+		return nil, fmt.Errorf("ユーザー情報の取得に失敗しました")
+	}
 
 	resp = &types.UserDetailRes{
 		User: userInfo,
@@ -3,6 +3,8 @@ package admin
 import (
 	"context"
 
+	"user_service/internal/cursor"
+	"user_service/internal/model"
 	"user_service/internal/svc"
 	"user_service/internal/types"
 
@@ -23,28 +25,66 @@ func NewListAllOrgsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListA
 	}
 }
 
-func (l *ListAllOrgsLogic) ListAllOrgs() (resp []types.Org, err error) {
-	// Admin権限チェックは必要に応じてhandlerまたはmiddlewareで実装
+// OrgPage 全組織一覧の 1 ページ（カーソルはヘッダーで返す）
+type OrgPage struct {
+	Orgs       []types.Org
+	NextCursor string
+	PrevCursor string
+}
+
+// defaultOrgPageLimit cursor だけが指定された場合の件数
+const defaultOrgPageLimit = 50
+
+func (l *ListAllOrgsLogic) ListAllOrgs(req *types.OrgListReq) (resp *OrgPage, err error) {
+	if err := requireSystemAdmin(l.ctx, l.svcCtx); err != nil {
+		return nil, err
+	}
 
-	// 全組織をデータベースから取得
-	orgs, err := l.svcCtx.OrgsModel.FindAll(l.ctx)
+	var orgs []*model.Orgs
+	resp = &OrgPage{}
+	if req.Limit == 0 && req.Cursor == "" {
+		// ページングの指定が無い場合は従来どおり全件を返す（削除済み・復元待ちを含む）
+		orgs, err = l.svcCtx.OrgsModel.FindAll(l.ctx)
+	} else {
+		limit := req.Limit
+		if limit == 0 {
+			limit = defaultOrgPageLimit
+		}
+		scope := cursor.Scope("admin_orgs")
+		var from *cursor.Position
+		if from, err = l.svcCtx.Cursors.Parse(scope, req.Cursor); err != nil {
+			return nil, err
+		}
+		// 全組織をデータベースから取得（削除済み・復元待ちを含む。続きの判定のため1件多く取得する）
+		orgs, err = l.svcCtx.OrgsModel.FindPage(l.ctx, from.KeysetPage(), limit+1)
+		if err == nil {
+			orgs, resp.NextCursor, resp.PrevCursor = cursor.Paginate(l.svcCtx.Cursors, scope, from, 0, orgs, limit,
+				func(org *model.Orgs) cursor.Position {
+					return cursor.Position{Key: org.CreatedAt, Id: org.Id}
+				})
+		}
+	}
 	if err != nil {
 		l.Errorf("Failed to fetch all organizations: %v", err)
 		return nil, err
 	}
 
 	// DB model から types.Org に変換
-	resp = make([]types.Org, 0, len(orgs))
+	resp.Orgs = make([]types.Org, 0, len(orgs))
 	for _, org := range orgs {
-		resp = append(resp, types.Org{
+		item := types.Org{
 			Id:        int64(org.Id),
 			Name:      org.Name,
 			OwnerId:   int64(org.OwnerId),
 			CreatedAt: org.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 			UpdatedAt: org.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
-		})
+		}
+		if org.DeletedAt.Valid {
+			item.DeletedAt = org.DeletedAt.Time.Format("2006-01-02T15:04:05Z07:00")
+		}
+		resp.Orgs = append(resp.Orgs, item)
 	}
 
-	l.Infof("Successfully retrieved %d organizations for admin", len(resp))
+	l.Infof("Successfully retrieved %d organizations for admin", len(resp.Orgs))
 	return resp, nil
 }
@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 
+	"user_service/internal/cursor"
+	"user_service/internal/dataloader"
+	"user_service/internal/model"
 	"user_service/internal/svc"
 	"user_service/internal/types"
 
@@ -25,6 +28,10 @@ func NewListAllUsersLogic(ctx context.Context, svcCtx *svc.ServiceContext) *List
 }
 
 func (l *ListAllUsersLogic) ListAllUsers(req *types.UserListReq) (resp *types.UserListRes, err error) {
+	if err := requireSystemAdmin(l.ctx, l.svcCtx); err != nil {
+		return nil, err
+	}
+
 	// ページング設定のデフォルト値
 	page := req.Page
 	if page <= 0 {
@@ -38,19 +45,47 @@ func (l *ListAllUsersLogic) ListAllUsers(req *types.UserListReq) (resp *types.Us
 	
 	offset := (page - 1) * limit
 
-	// 全ユーザー数の取得
-	totalCount, err := l.svcCtx.UsersModel.Count(l.ctx)
+	filter, err := userFilter(req)
+	if err != nil {
+		return nil, err
+	}
+	scope := userCursorScope(filter)
+	from, err := l.svcCtx.Cursors.Parse(scope, req.Cursor)
 	if err != nil {
-		l.Errorf("Failed to count users: %v", err)
-		return nil, fmt.Errorf("ユーザー数の取得に失敗しました")
+		return nil, err
 	}
 
-	// ユーザー一覧の取得（ページング付き）
-	users, err := l.svcCtx.UsersModel.FindAll(l.ctx, int(limit), int(offset))
+	// 条件に一致するユーザー数の取得（カーソル指定時は大きな表を毎回数えないよう省略する）
+	var totalCount int64
+	if from == nil {
+		totalCount, err = l.svcCtx.UsersModel.CountMatching(l.ctx, filter)
+		if err != nil {
+			l.Errorf("Failed to count users: %v", err)
+			return nil, fmt.Errorf("ユーザー数の取得に失敗しました")
+		}
+	} else {
+		page, offset = 0, 0
+	}
+
+	// ユーザー一覧の取得（続きがあるかを判定するため1件多く取得する）
+	filter.Limit = int(limit) + 1
+	filter.Offset = int(offset)
+	filter.Page = from.KeysetPage()
+	users, err := l.svcCtx.UsersModel.Search(l.ctx, filter)
 	if err != nil {
 		l.Errorf("Failed to fetch users list: %v", err)
 		return nil, fmt.Errorf("ユーザー一覧の取得に失敗しました")
 	}
+	users, nextCursor, prevCursor := cursor.Paginate(l.svcCtx.Cursors, scope, from, int(offset), users, int(limit),
+		func(user *model.Users) cursor.Position {
+			return cursor.Position{Key: user.SortValue(filter.Sort), Id: user.Id}
+		})
+
+	// ロールとプロフィールはページ内のユーザー分をまとめて取得する
+	loader := l.svcCtx.Loaders.Users(l.ctx)
+	for _, user := range users {
+		loader.Prime(user.Id)
+	}
 
 	// レスポンス用に変換
 	userInfos := make([]types.UserInfo, 0, len(users))
@@ -74,19 +109,33 @@ func (l *ListAllUsersLogic) ListAllUsers(req *types.UserListReq) (resp *types.Us
 			CreatedAt: user.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 			UpdatedAt: user.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		}
-		
-		// ロールやプロフィール情報は必要に応じて後から実装
-		// TODO: ユーザーのロール情報を取得
-		// TODO: ユーザーのプロフィール情報を取得
-		
+		if user.LastLoginAt.Valid {
+			userInfo.LastLoginAt = user.LastLoginAt.Time.Format("2006-01-02T15:04:05Z07:00")
+		}
+		if user.DeletedAt.Valid {
+			userInfo.DeletedAt = user.DeletedAt.Time.Format("2006-01-02T15:04:05Z07:00")
+		}
+		if userInfo.Roles, err = loader.Roles(l.ctx, user.Id); err != nil {
+			l.Errorf("Failed to fetch roles of users: %v", err)
+			return nil, fmt.Errorf("ユーザー一覧の取得に失敗しました")
+		}
+		profile, err := loader.Profile(l.ctx, user.Id)
+		if err != nil {
+			l.Errorf("Failed to fetch profiles of users: %v", err)
+			return nil, fmt.Errorf("ユーザー一覧の取得に失敗しました")
+		}
+		userInfo.Profile = dataloader.ProfileData(profile)
+
 		userInfos = append(userInfos, userInfo)
 	}
 
 	resp = &types.UserListRes{
-		Users: userInfos,
-		Total: totalCount,
-		Page:  page,
-		Limit: limit,
+		Users:      userInfos,
+		Total:      totalCount,
+		Page:       page,
+		Limit:      limit,
+		NextCursor: nextCursor,
+		PrevCursor: prevCursor,
 	}
 
 	l.Infof("Successfully retrieved %d users (page: %d, limit: %d, total: %d)", len(userInfos), page, limit, totalCount)
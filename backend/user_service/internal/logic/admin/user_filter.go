@@ -0,0 +1,97 @@
+package admin
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"user_service/internal/cursor"
+	"user_service/internal/model"
+	"user_service/internal/types"
+)
+
+// userFilter 一覧のクエリパラメータを検索条件に変換する
+// ページングは呼び出し側で設定する
+func userFilter(req *types.UserListReq) (model.UserFilter, error) {
+	filter := model.UserFilter{
+		Query:    strings.TrimSpace(req.Q),
+		RoleName: strings.TrimSpace(req.Role),
+		Deleted:  req.Deleted,
+	}
+	if req.OrgId < 0 {
+		return filter, fmt.Errorf("org_id が正しくありません")
+	}
+	filter.OrgId = uint64(req.OrgId)
+
+	switch req.Status {
+	case "":
+	case "active", "1":
+		status := int8(1)
+		filter.Status = &status
+	case "inactive", "0":
+		status := int8(0)
+		filter.Status = &status
+	default:
+		return filter, fmt.Errorf("status は active または inactive を指定してください")
+	}
+
+	var err error
+	if filter.CreatedFrom, err = parseCreatedBound(req.CreatedFrom, false); err != nil {
+		return filter, fmt.Errorf("created_from の形式が正しくありません")
+	}
+	if filter.CreatedTo, err = parseCreatedBound(req.CreatedTo, true); err != nil {
+		return filter, fmt.Errorf("created_to の形式が正しくありません")
+	}
+	if !filter.CreatedFrom.IsZero() && !filter.CreatedTo.IsZero() && !filter.CreatedFrom.Before(filter.CreatedTo) {
+		return filter, fmt.Errorf("created_from は created_to より前の日時を指定してください")
+	}
+
+	if req.Sort != "" {
+		if !model.IsUserSortColumn(req.Sort) {
+			return filter, fmt.Errorf("sort は name / email / created_at / last_login_at のいずれかを指定してください")
+		}
+		filter.Sort = req.Sort
+	}
+	switch req.Order {
+	case "":
+		// 並べ替えの指定が無い場合は従来どおり新しいユーザーから返す
+		filter.Desc = req.Sort == ""
+	case "asc":
+	case "desc":
+		filter.Desc = true
+	default:
+		return filter, fmt.Errorf("order は asc または desc を指定してください")
+	}
+
+	return filter, nil
+}
+
+// userCursorScope 検索条件と並び順が同じリクエストでだけカーソルを使えるようにする
+func userCursorScope(filter model.UserFilter) string {
+	status := ""
+	if filter.Status != nil {
+		status = fmt.Sprint(*filter.Status)
+	}
+	return cursor.Scope("admin_users", filter.Query, status, filter.RoleName, filter.OrgId,
+		filter.CreatedFrom.Unix(), filter.CreatedTo.Unix(), filter.Sort, filter.Desc, filter.Deleted)
+}
+
+// parseCreatedBound 作成日時の範囲指定を解釈する（空文字はゼロ値）
+// 日付のみの上限はその日を含めるため翌日の 0 時にする
+func parseCreatedBound(value string, upper bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	day, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if upper {
+		day = day.AddDate(0, 0, 1)
+	}
+	return day, nil
+}
@@ -0,0 +1,103 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+
+	"user_service/internal/avatar"
+	"user_service/internal/etag"
+	"user_service/internal/model"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+)
+
+// avatarFormField 画像を入れる multipart のフィールド名
+const avatarFormField = "file"
+
+var errAvatarFileRequired = errors.New("画像を multipart/form-data の file フィールドで送信してください")
+
+// readAvatarFile multipart/form-data のボディから file フィールドの内容を読み込む
+// 上限を超えた場合は読み込み中の *http.MaxBytesError をそのまま返す
+func readAvatarFile(body io.Reader, contentType string) ([]byte, error) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil || mediaType != "multipart/form-data" || params["boundary"] == "" {
+		return nil, errAvatarFileRequired
+	}
+
+	reader := multipart.NewReader(body, params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			return nil, errAvatarFileRequired
+		}
+		if err != nil {
+			return nil, err
+		}
+		if part.FormName() != avatarFormField {
+			continue
+		}
+
+		data, err := io.ReadAll(part)
+		if err != nil {
+			return nil, err
+		}
+		if len(data) == 0 {
+			return nil, errAvatarFileRequired
+		}
+		return data, nil
+	}
+}
+
+// setAvatarUrl ログイン中のユーザーの avatar_url を書き換える（プロフィールが未作成なら作成する）
+// ifMatch が空でなければ現在のプロフィールの ETag と照合する。
+// 消去（avatarUrl が空）でプロフィールが無い場合は何もせず nil を返す
+func setAvatarUrl(ctx context.Context, svcCtx *svc.ServiceContext, userId int64, ifMatch, avatarUrl string) (*model.UserProfiles, error) {
+	var profile *model.UserProfiles
+	err := svcCtx.UnitOfWork.Do(ctx, func(ctx context.Context, tx *model.Tx) error {
+		if _, err := svcCtx.UsersModel.LockTx(ctx, tx, uint64(userId)); err != nil {
+			return err
+		}
+		locked, err := svcCtx.UserProfilesModel.LockByUserIdTx(ctx, tx, uint64(userId))
+		switch {
+		case err == nil:
+			profile = locked
+		case !errors.Is(err, model.ErrNotFound):
+			return err
+		}
+
+		if ifMatch != "" && (profile == nil || !etag.Match(ifMatch, etag.Profile(profile))) {
+			return model.ErrVersionConflict
+		}
+
+		switch {
+		case profile == nil && avatarUrl == "":
+			return nil
+		case profile == nil:
+			profile = &model.UserProfiles{
+				UserId:      uint64(userId),
+				SocialLinks: "null",
+				Preferences: "null",
+			}
+		case profile.AvatarUrl == avatarUrl:
+			return nil
+		}
+		profile.AvatarUrl = avatarUrl
+		return svcCtx.UserProfilesModel.UpsertTx(ctx, tx, profile)
+	})
+	if errors.Is(err, model.ErrVersionConflict) {
+		return nil, profileConflict(ctx, svcCtx, userId)
+	}
+	return profile, err
+}
+
+// avatarThumbnails 作成したサムネイルの公開URL
+func avatarThumbnails(publicUrl string, thumbnails []avatar.Thumbnail) []types.AvatarThumbnail {
+	result := make([]types.AvatarThumbnail, len(thumbnails))
+	for i, thumbnail := range thumbnails {
+		result[i] = types.AvatarThumbnail{Size: thumbnail.Size, Url: avatar.Url(publicUrl, thumbnail.Name)}
+	}
+	return result
+}
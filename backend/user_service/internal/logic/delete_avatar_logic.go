@@ -0,0 +1,58 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"user_service/internal/dataloader"
+	"user_service/internal/etag"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+
+	"github.com/winyx/backend/common/auth"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type DeleteAvatarLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewDeleteAvatarLogic(ctx context.Context, svcCtx *svc.ServiceContext) *DeleteAvatarLogic {
+	return &DeleteAvatarLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// DeleteAvatar ログイン中のユーザーのアバター画像の設定を外す
+// 保存した画像は同じ画像をアップロードした他のユーザーと共有している場合があるため消さない
+func (l *DeleteAvatarLogic) DeleteAvatar(req *types.AvatarReq) (resp *types.ProfileRes, err error) {
+	userId, ok := auth.UserIdFromContext(l.ctx)
+	if !ok {
+		return nil, fmt.Errorf("認証エラー: ユーザーIDが取得できません")
+	}
+
+	profile, err := setAvatarUrl(l.ctx, l.svcCtx, userId, req.IfMatch, "")
+	if err != nil {
+		var failed *etag.PreconditionFailedError
+		if errors.As(err, &failed) {
+			return nil, err
+		}
+		l.Errorf("Failed to remove avatar of user %d: %v", userId, err)
+		return nil, fmt.Errorf("アバター画像の削除に失敗しました")
+	}
+	// プロフィールが未作成の場合は外す画像も無い
+	if profile == nil {
+		return &types.ProfileRes{}, nil
+	}
+
+	return &types.ProfileRes{
+		Profile: *dataloader.ProfileData(profile),
+		Etag:    etag.Profile(profile),
+	}, nil
+}
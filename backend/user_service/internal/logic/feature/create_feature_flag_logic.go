@@ -0,0 +1,79 @@
+package feature
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"user_service/internal/audit"
+	"user_service/internal/model"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+
+	"github.com/winyx/backend/common/featureflag"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type CreateFeatureFlagLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// Admin用フィーチャーフラグの作成
+func NewCreateFeatureFlagLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CreateFeatureFlagLogic {
+	return &CreateFeatureFlagLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *CreateFeatureFlagLogic) CreateFeatureFlag(req *types.CreateFeatureFlagReq) (resp *types.FeatureFlag, err error) {
+	if err := requireSystemAdmin(l.ctx, l.svcCtx); err != nil {
+		return nil, err
+	}
+
+	if !featureflag.ValidKey(req.Key) {
+		return nil, fmt.Errorf("フラグキーは英小文字・数字・_ . - の100文字以内で指定してください")
+	}
+
+	if _, err := l.svcCtx.FeatureFlagsModel.FindOneByFlagKey(l.ctx, req.Key); err == nil {
+		return nil, fmt.Errorf("フィーチャーフラグは既に存在します: %s", req.Key)
+	} else if !errors.Is(err, model.ErrNotFound) {
+		l.Errorf("Failed to check feature flag %s: %v", req.Key, err)
+		return nil, fmt.Errorf("フィーチャーフラグの作成に失敗しました")
+	}
+
+	rules, err := marshalRules(req.Rules)
+	if err != nil {
+		return nil, err
+	}
+
+	row := &model.FeatureFlags{
+		FlagKey:           req.Key,
+		Description:       req.Description,
+		Enabled:           boolToInt(req.Enabled),
+		Rules:             rules,
+		RolloutPercentage: uint64(req.RolloutPercentage),
+	}
+	if _, err := l.svcCtx.FeatureFlagsModel.Insert(l.ctx, row); err != nil {
+		l.Errorf("Failed to insert feature flag %s: %v", req.Key, err)
+		return nil, fmt.Errorf("フィーチャーフラグの作成に失敗しました")
+	}
+
+	if err := l.svcCtx.PublishFeatureFlags(l.ctx); err != nil {
+		l.Errorf("Failed to publish feature flags: %v", err)
+	}
+
+	l.Infof("Feature flag created: %s", req.Key)
+	l.svcCtx.Audit.Record(l.ctx, audit.Entry{
+		Action:     audit.ActionFeatureFlagCreate,
+		TargetType: audit.TargetFeatureFlag,
+		TargetId:   req.Key,
+		After:      toFlagSnapshot(row),
+	})
+
+	return NewGetFeatureFlagLogic(l.ctx, l.svcCtx).GetFeatureFlag(&types.FeatureFlagKeyReq{Key: req.Key})
+}
@@ -0,0 +1,66 @@
+package feature
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"user_service/internal/audit"
+	"user_service/internal/model"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type DeleteFeatureFlagLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// Admin用フィーチャーフラグの削除
+func NewDeleteFeatureFlagLogic(ctx context.Context, svcCtx *svc.ServiceContext) *DeleteFeatureFlagLogic {
+	return &DeleteFeatureFlagLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *DeleteFeatureFlagLogic) DeleteFeatureFlag(req *types.FeatureFlagKeyReq) (resp *types.CommonRes, err error) {
+	if err := requireSystemAdmin(l.ctx, l.svcCtx); err != nil {
+		return nil, err
+	}
+
+	row, err := l.svcCtx.FeatureFlagsModel.FindOneByFlagKey(l.ctx, req.Key)
+	if err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			return nil, fmt.Errorf("フィーチャーフラグが見つかりません: %s", req.Key)
+		}
+		l.Errorf("Failed to fetch feature flag %s: %v", req.Key, err)
+		return nil, fmt.Errorf("フィーチャーフラグの削除に失敗しました")
+	}
+
+	if err := l.svcCtx.FeatureFlagsModel.Delete(l.ctx, row.Id); err != nil {
+		l.Errorf("Failed to delete feature flag %s: %v", req.Key, err)
+		return nil, fmt.Errorf("フィーチャーフラグの削除に失敗しました")
+	}
+
+	if err := l.svcCtx.PublishFeatureFlags(l.ctx); err != nil {
+		l.Errorf("Failed to publish feature flags: %v", err)
+	}
+
+	l.Infof("Feature flag deleted: %s", req.Key)
+	l.svcCtx.Audit.Record(l.ctx, audit.Entry{
+		Action:     audit.ActionFeatureFlagDelete,
+		TargetType: audit.TargetFeatureFlag,
+		TargetId:   req.Key,
+		Before:     toFlagSnapshot(row),
+	})
+
+	return &types.CommonRes{
+		Message: "フィーチャーフラグを削除しました",
+		Success: true,
+	}, nil
+}
@@ -0,0 +1,63 @@
+package feature
+
+import (
+	"context"
+
+	"user_service/internal/svc"
+	"user_service/internal/types"
+
+	"github.com/winyx/backend/common/auth"
+	"github.com/winyx/backend/common/featureflag"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type EvaluateFeaturesLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// ログインユーザーに対するフィーチャーフラグの評価結果
+func NewEvaluateFeaturesLogic(ctx context.Context, svcCtx *svc.ServiceContext) *EvaluateFeaturesLogic {
+	return &EvaluateFeaturesLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *EvaluateFeaturesLogic) EvaluateFeatures() (resp *types.FeaturesRes, err error) {
+	ec := featureflag.EvalContext{}
+	if userId, ok := auth.UserIdFromContext(l.ctx); ok {
+		ec = l.evalContext(userId)
+	}
+
+	return &types.FeaturesRes{
+		Features: l.svcCtx.FeatureFlags.Evaluate(ec),
+	}, nil
+}
+
+// evalContext ターゲティングに使うロールと所属組織を取得
+// 取得に失敗した場合はユーザーIDのみで評価する
+func (l *EvaluateFeaturesLogic) evalContext(userId int64) featureflag.EvalContext {
+	ec := featureflag.EvalContext{UserId: userId}
+
+	roles, err := l.svcCtx.UserRolesModel.FindByUserIdWithRole(l.ctx, userId)
+	if err != nil {
+		l.Errorf("Failed to fetch roles for user %d: %v", userId, err)
+	}
+	for _, role := range roles {
+		ec.Roles = append(ec.Roles, role.RoleName)
+	}
+
+	members, err := l.svcCtx.OrgMembersModel.FindByUserId(l.ctx, uint64(userId))
+	if err != nil {
+		l.Errorf("Failed to fetch orgs for user %d: %v", userId, err)
+	}
+	for _, member := range members {
+		ec.OrgIds = append(ec.OrgIds, int64(member.OrgId))
+	}
+
+	return ec
+}
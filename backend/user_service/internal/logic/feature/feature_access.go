@@ -0,0 +1,35 @@
+package feature
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"user_service/internal/svc"
+
+	"github.com/winyx/backend/common/auth"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+var errAdminRequired = errors.New("システム管理者権限が必要です")
+
+// requireSystemAdmin フラグの定義を扱えるのはシステム管理者のみ
+// 変更は全サービスへ配信されるため、組織内の admin ロールでは操作できない
+func requireSystemAdmin(ctx context.Context, svcCtx *svc.ServiceContext) error {
+	userId, ok := auth.UserIdFromContext(ctx)
+	if !ok {
+		return fmt.Errorf("認証エラー: ユーザーIDが取得できません")
+	}
+
+	admin, err := svcCtx.OrgPermissions.IsSystemAdmin(ctx, uint64(userId))
+	if err != nil {
+		logx.WithContext(ctx).Errorf("Failed to check system role of user %d: %v", userId, err)
+		return fmt.Errorf("権限の確認に失敗しました")
+	}
+	if !admin {
+		return errAdminRequired
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package feature
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"user_service/internal/model"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetFeatureFlagLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// Admin用フィーチャーフラグの取得
+func NewGetFeatureFlagLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetFeatureFlagLogic {
+	return &GetFeatureFlagLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *GetFeatureFlagLogic) GetFeatureFlag(req *types.FeatureFlagKeyReq) (resp *types.FeatureFlag, err error) {
+	if err := requireSystemAdmin(l.ctx, l.svcCtx); err != nil {
+		return nil, err
+	}
+
+	row, err := l.svcCtx.FeatureFlagsModel.FindOneByFlagKey(l.ctx, req.Key)
+	if err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			return nil, fmt.Errorf("フィーチャーフラグが見つかりません: %s", req.Key)
+		}
+		l.Errorf("Failed to fetch feature flag %s: %v", req.Key, err)
+		return nil, fmt.Errorf("フィーチャーフラグの取得に失敗しました")
+	}
+
+	flag := toFeatureFlag(row)
+	return &flag, nil
+}
@@ -0,0 +1,74 @@
+package feature
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"user_service/internal/audit"
+	"user_service/internal/model"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type UpdateFeatureFlagLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// Admin用フィーチャーフラグの更新
+func NewUpdateFeatureFlagLogic(ctx context.Context, svcCtx *svc.ServiceContext) *UpdateFeatureFlagLogic {
+	return &UpdateFeatureFlagLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *UpdateFeatureFlagLogic) UpdateFeatureFlag(req *types.UpdateFeatureFlagReq) (resp *types.FeatureFlag, err error) {
+	if err := requireSystemAdmin(l.ctx, l.svcCtx); err != nil {
+		return nil, err
+	}
+
+	row, err := l.svcCtx.FeatureFlagsModel.FindOneByFlagKey(l.ctx, req.Key)
+	if err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			return nil, fmt.Errorf("フィーチャーフラグが見つかりません: %s", req.Key)
+		}
+		l.Errorf("Failed to fetch feature flag %s: %v", req.Key, err)
+		return nil, fmt.Errorf("フィーチャーフラグの更新に失敗しました")
+	}
+
+	rules, err := marshalRules(req.Rules)
+	if err != nil {
+		return nil, err
+	}
+
+	before := toFlagSnapshot(row)
+	row.Description = req.Description
+	row.Enabled = boolToInt(req.Enabled)
+	row.Rules = rules
+	row.RolloutPercentage = uint64(req.RolloutPercentage)
+	if err := l.svcCtx.FeatureFlagsModel.Update(l.ctx, row); err != nil {
+		l.Errorf("Failed to update feature flag %s: %v", req.Key, err)
+		return nil, fmt.Errorf("フィーチャーフラグの更新に失敗しました")
+	}
+
+	if err := l.svcCtx.PublishFeatureFlags(l.ctx); err != nil {
+		l.Errorf("Failed to publish feature flags: %v", err)
+	}
+
+	l.Infof("Feature flag updated: %s (enabled: %t, rollout: %d%%)", req.Key, req.Enabled, req.RolloutPercentage)
+	l.svcCtx.Audit.Record(l.ctx, audit.Entry{
+		Action:     audit.ActionFeatureFlagUpdate,
+		TargetType: audit.TargetFeatureFlag,
+		TargetId:   req.Key,
+		Before:     before,
+		After:      toFlagSnapshot(row),
+	})
+
+	return NewGetFeatureFlagLogic(l.ctx, l.svcCtx).GetFeatureFlag(&types.FeatureFlagKeyReq{Key: req.Key})
+}
@@ -0,0 +1,49 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"user_service/internal/avatar"
+	"user_service/internal/blob"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// ErrAvatarNotFound 配信するアバター画像が無い（ハンドラーは 404 を返す）
+var ErrAvatarNotFound = errors.New("画像が見つかりません")
+
+type GetAvatarLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetAvatarLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetAvatarLogic {
+	return &GetAvatarLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// GetAvatar 保存したサムネイルを読み込む（認証不要。ファイル名の形式に合わないものはストレージを参照しない）
+func (l *GetAvatarLogic) GetAvatar(req *types.AvatarFileReq) (*blob.Object, error) {
+	if !avatar.ValidName(req.Name) {
+		return nil, ErrAvatarNotFound
+	}
+
+	object, err := l.svcCtx.Blobs.Get(l.ctx, avatar.Key(req.Name))
+	switch {
+	case err == nil:
+		return object, nil
+	case errors.Is(err, blob.ErrNotFound):
+		return nil, ErrAvatarNotFound
+	default:
+		l.Errorf("Failed to read avatar %s: %v", req.Name, err)
+		return nil, fmt.Errorf("画像の読み込みに失敗しました")
+	}
+}
@@ -0,0 +1,53 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"user_service/internal/model"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetUserImportLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetUserImportLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetUserImportLogic {
+	return &GetUserImportLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// GetUserImport インポートジョブの進捗と、登録しなかった行の理由を返す
+func (l *GetUserImportLogic) GetUserImport(req *types.UserImportJobReq) (resp *types.UserImportJob, err error) {
+	if _, err := requireSystemAdmin(l.ctx, l.svcCtx); err != nil {
+		return nil, err
+	}
+	if req.Id <= 0 {
+		return nil, fmt.Errorf("インポートジョブが見つかりません")
+	}
+
+	job, err := l.svcCtx.UserImportJobsModel.FindOne(l.ctx, uint64(req.Id))
+	if errors.Is(err, model.ErrNotFound) {
+		return nil, fmt.Errorf("インポートジョブが見つかりません")
+	}
+	if err != nil {
+		l.Errorf("Failed to fetch user import job %d: %v", req.Id, err)
+		return nil, fmt.Errorf("インポートジョブの取得に失敗しました")
+	}
+
+	rows, err := parseRowResults(job)
+	if err != nil {
+		l.Errorf("Failed to decode results of user import job %d: %v", job.Id, err)
+		return nil, fmt.Errorf("インポートジョブの取得に失敗しました")
+	}
+	return toUserImportJob(job, rows), nil
+}
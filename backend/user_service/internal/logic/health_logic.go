@@ -0,0 +1,63 @@
+package logic
+
+import (
+	"context"
+	"time"
+
+	"user_service/internal/svc"
+	"user_service/internal/types"
+
+	"github.com/winyx/backend/common/buildinfo"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+const dbPingTimeout = 2 * time.Second
+
+type HealthLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewHealthLogic(ctx context.Context, svcCtx *svc.ServiceContext) *HealthLogic {
+	return &HealthLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// Health DB疎通とビルド情報を返す（DBに接続できない場合は degraded）
+func (l *HealthLogic) Health() (resp *types.HealthRes, err error) {
+	build := buildinfo.Summary()
+	resp = &types.HealthRes{
+		Status:    "healthy",
+		Database:  "up",
+		Version:   build.Version,
+		Commit:    build.Commit,
+		BuildTime: build.BuildTime,
+		Dirty:     build.Dirty,
+		Timestamp: time.Now().Unix(),
+	}
+
+	if err := l.pingDatabase(); err != nil {
+		l.Errorf("Health check database ping failed: %v", err)
+		resp.Status = "degraded"
+		resp.Database = "down"
+	}
+
+	return resp, nil
+}
+
+func (l *HealthLogic) pingDatabase() error {
+	db, err := l.svcCtx.Conn.RawDB()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(l.ctx, dbPingTimeout)
+	defer cancel()
+
+	return db.PingContext(ctx)
+}
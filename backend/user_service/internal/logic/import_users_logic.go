@@ -0,0 +1,146 @@
+package logic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"user_service/internal/audit"
+	"user_service/internal/bulk"
+	"user_service/internal/model"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/threading"
+)
+
+type ImportUsersLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewImportUsersLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ImportUsersLogic {
+	return &ImportUsersLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// ImportUsers ファイルを解析してインポートジョブを作成し、行の検証と登録をバックグラウンドで始める
+// ファイル自体を読めない場合（形式・列・行数の誤り）はジョブを作らずにエラーを返す
+func (l *ImportUsersLogic) ImportUsers(req *types.UserImportReq, body io.Reader, contentType string) (resp *types.UserImportJob, err error) {
+	operatorId, err := requireSystemAdmin(l.ctx, l.svcCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	format, err := bulk.ParseFormat(req.Format, contentType)
+	if err != nil {
+		return nil, err
+	}
+	records, err := bulk.Read(format, body, l.svcCtx.Config.Import.MaxRows)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return nil, fmt.Errorf("ファイルサイズは %d バイト以内にしてください", tooLarge.Limit)
+		}
+		return nil, err
+	}
+
+	job := &model.UserImportJobs{
+		Status:          model.ImportStatusPending,
+		Format:          string(format),
+		DryRun:          req.DryRun,
+		SendInvitations: req.SendInvitations && !req.DryRun,
+		TotalRows:       uint64(len(records)),
+		RowResults:      "[]",
+		CreatedBy:       uint64(operatorId),
+	}
+	result, err := l.svcCtx.UserImportJobsModel.Insert(l.ctx, job)
+	if err != nil {
+		l.Errorf("Failed to create user import job: %v", err)
+		return nil, fmt.Errorf("インポートの開始に失敗しました")
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		l.Errorf("Failed to get user import job id: %v", err)
+		return nil, fmt.Errorf("インポートの開始に失敗しました")
+	}
+	if job, err = l.svcCtx.UserImportJobsModel.FindOne(l.ctx, uint64(id)); err != nil {
+		l.Errorf("Failed to fetch user import job %d: %v", id, err)
+		return nil, fmt.Errorf("インポートの開始に失敗しました")
+	}
+
+	l.Infof("User import job %d created by user %d (%s, %d rows, dry run: %t)", job.Id, operatorId, format, len(records), job.DryRun)
+	l.svcCtx.Audit.Record(l.ctx, audit.Entry{
+		Action:     audit.ActionUserImport,
+		TargetType: audit.TargetUserImport,
+		TargetId:   job.Id,
+		After:      importSnapshot{Format: job.Format, DryRun: job.DryRun, SendInvitations: job.SendInvitations, Rows: len(records)},
+	})
+
+	// リクエストが終わっても続けるが、操作者や接続元は監査ログに引き継ぐ
+	ctx := context.WithoutCancel(l.ctx)
+	threading.GoSafe(func() {
+		newUserImport(ctx, l.svcCtx, job, records).run()
+	})
+
+	return toUserImportJob(job, nil), nil
+}
+
+// importSnapshot 監査ログに記録するインポートの指定内容
+type importSnapshot struct {
+	Format          string `json:"format"`
+	DryRun          bool   `json:"dry_run"`
+	SendInvitations bool   `json:"send_invitations"`
+	Rows            int    `json:"rows"`
+}
+
+// toUserImportJob ジョブの行をレスポンスの形にする（rows は呼び出し側で解析済みのもの）
+func toUserImportJob(job *model.UserImportJobs, rows []types.UserImportRowResult) *types.UserImportJob {
+	if rows == nil {
+		rows = []types.UserImportRowResult{}
+	}
+	resp := &types.UserImportJob{
+		Id:              int64(job.Id),
+		Status:          job.Status,
+		Format:          job.Format,
+		DryRun:          job.DryRun,
+		SendInvitations: job.SendInvitations,
+		TotalRows:       int64(job.TotalRows),
+		ProcessedRows:   int64(job.ProcessedRows),
+		ValidRows:       int64(job.ValidRows),
+		CreatedRows:     int64(job.CreatedRows),
+		SkippedRows:     int64(job.SkippedRows),
+		FailedRows:      int64(job.FailedRows),
+		Rows:            rows,
+		Error:           job.Error,
+		CreatedBy:       int64(job.CreatedBy),
+		CreatedAt:       job.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if job.StartedAt.Valid {
+		resp.StartedAt = job.StartedAt.Time.Format("2006-01-02T15:04:05Z07:00")
+	}
+	if job.FinishedAt.Valid {
+		resp.FinishedAt = job.FinishedAt.Time.Format("2006-01-02T15:04:05Z07:00")
+	}
+	return resp
+}
+
+// parseRowResults 保存した行ごとの結果を読み込む
+func parseRowResults(job *model.UserImportJobs) ([]types.UserImportRowResult, error) {
+	var rows []types.UserImportRowResult
+	if job.RowResults == "" {
+		return rows, nil
+	}
+	if err := json.Unmarshal([]byte(job.RowResults), &rows); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
@@ -0,0 +1,73 @@
+package internalapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"user_service/internal/model"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type CheckOrgPermissionLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// ユーザーが組織内ロール経由で permission を持つか確認
+func NewCheckOrgPermissionLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CheckOrgPermissionLogic {
+	return &CheckOrgPermissionLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// CheckOrgPermission 判定結果は CheckPermission と同じく Allowed と理由で返す
+func (l *CheckOrgPermissionLogic) CheckOrgPermission(req *types.CheckOrgPermissionReq) (resp *types.CheckPermissionRes, err error) {
+	if req.UserId <= 0 || req.OrgId <= 0 || req.Permission == "" {
+		return nil, fmt.Errorf("user_id, org_id, permission は必須です")
+	}
+
+	user, err := l.svcCtx.UsersModel.FindOne(l.ctx, uint64(req.UserId))
+	if err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			return &types.CheckPermissionRes{Reason: "user not found"}, nil
+		}
+		l.Errorf("Failed to fetch user %d: %v", req.UserId, err)
+		return nil, fmt.Errorf("ユーザー情報の取得に失敗しました")
+	}
+	if user.Status != 1 {
+		return &types.CheckPermissionRes{Reason: "user is inactive"}, nil
+	}
+
+	org, err := l.svcCtx.OrgsModel.FindOne(l.ctx, uint64(req.OrgId))
+	if err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			return &types.CheckPermissionRes{Reason: "org not found"}, nil
+		}
+		l.Errorf("Failed to fetch organization %d: %v", req.OrgId, err)
+		return nil, fmt.Errorf("組織情報の取得に失敗しました")
+	}
+	if org.DeletedAt.Valid {
+		return &types.CheckPermissionRes{Reason: "org is deleted"}, nil
+	}
+
+	grant, err := l.svcCtx.OrgPermissions.Resolve(l.ctx, uint64(req.OrgId), user.Id)
+	if err != nil {
+		l.Errorf("Failed to resolve permissions of user %d in org %d: %v", req.UserId, req.OrgId, err)
+		return nil, fmt.Errorf("権限の確認に失敗しました")
+	}
+	switch {
+	case grant.Has(req.Permission):
+		return &types.CheckPermissionRes{Allowed: true}, nil
+	case !grant.IsMember():
+		return &types.CheckPermissionRes{Reason: "not a member of the org"}, nil
+	default:
+		return &types.CheckPermissionRes{Reason: "permission denied"}, nil
+	}
+}
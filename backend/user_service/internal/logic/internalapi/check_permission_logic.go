@@ -0,0 +1,59 @@
+package internalapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"user_service/internal/model"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type CheckPermissionLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// ユーザーがロール経由で resource:action の権限を持つか確認
+func NewCheckPermissionLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CheckPermissionLogic {
+	return &CheckPermissionLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// CheckPermission 拒否した場合もエラーにはせず Allowed=false と理由を返す
+// エラーは DB 障害など判定自体ができない場合のみ
+func (l *CheckPermissionLogic) CheckPermission(req *types.CheckPermissionReq) (resp *types.CheckPermissionRes, err error) {
+	if req.UserId <= 0 || req.Resource == "" || req.Action == "" {
+		return nil, fmt.Errorf("user_id, resource, action は必須です")
+	}
+
+	user, err := l.svcCtx.UsersModel.FindOne(l.ctx, uint64(req.UserId))
+	if err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			return &types.CheckPermissionRes{Reason: "user not found"}, nil
+		}
+		l.Errorf("Failed to fetch user %d: %v", req.UserId, err)
+		return nil, fmt.Errorf("ユーザー情報の取得に失敗しました")
+	}
+	if user.Status != 1 {
+		return &types.CheckPermissionRes{Reason: "user is inactive"}, nil
+	}
+
+	allowed, err := l.svcCtx.UserRolesModel.HasPermission(l.ctx, req.UserId, req.Resource, req.Action)
+	if err != nil {
+		l.Errorf("Failed to check permission %s:%s for user %d: %v", req.Resource, req.Action, req.UserId, err)
+		return nil, fmt.Errorf("権限の確認に失敗しました")
+	}
+	if !allowed {
+		return &types.CheckPermissionRes{Reason: "permission denied"}, nil
+	}
+
+	return &types.CheckPermissionRes{Allowed: true}, nil
+}
@@ -0,0 +1,137 @@
+package internalapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"user_service/internal/model"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ValidateUserLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// ユーザーが有効かを検証し、グローバルロールと所属組織を返す
+func NewValidateUserLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ValidateUserLogic {
+	return &ValidateUserLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// ValidateUser 無効な場合もエラーにはせず Valid=false と理由を返す
+func (l *ValidateUserLogic) ValidateUser(req *types.ValidateUserReq) (resp *types.ValidateUserRes, err error) {
+	if req.UserId <= 0 {
+		return nil, fmt.Errorf("user_id は必須です")
+	}
+
+	if req.Token != "" && !l.tokenMatches(req.Token, req.UserId) {
+		return &types.ValidateUserRes{Message: "invalid token"}, nil
+	}
+
+	user, err := l.svcCtx.UsersModel.FindOne(l.ctx, uint64(req.UserId))
+	if err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			return &types.ValidateUserRes{Message: "user not found"}, nil
+		}
+		l.Errorf("Failed to fetch user %d: %v", req.UserId, err)
+		return nil, fmt.Errorf("ユーザー情報の取得に失敗しました")
+	}
+	if user.Status != 1 {
+		return &types.ValidateUserRes{Message: "user is inactive"}, nil
+	}
+
+	roles, err := l.svcCtx.UserRolesModel.FindByUserIdWithRole(l.ctx, req.UserId)
+	if err != nil {
+		l.Errorf("Failed to fetch roles of user %d: %v", req.UserId, err)
+		return nil, fmt.Errorf("ロール情報の取得に失敗しました")
+	}
+
+	orgs, err := l.memberships(user.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &types.UserBasicInfo{
+		UserId: int64(user.Id),
+		Name:   user.Name,
+		Email:  user.Email,
+		Status: "active",
+		Roles:  make([]string, 0, len(roles)),
+		Orgs:   orgs,
+	}
+	for _, role := range roles {
+		info.Roles = append(info.Roles, role.RoleName)
+	}
+
+	return &types.ValidateUserRes{
+		Valid:   true,
+		User:    info,
+		Message: "ok",
+	}, nil
+}
+
+// memberships 所属組織毎の組織内ロールと権限
+func (l *ValidateUserLogic) memberships(userId uint64) ([]types.OrgMembershipInfo, error) {
+	grants, err := l.svcCtx.OrgPermissions.Memberships(l.ctx, userId)
+	if err != nil {
+		l.Errorf("Failed to resolve memberships of user %d: %v", userId, err)
+		return nil, fmt.Errorf("所属組織の取得に失敗しました")
+	}
+
+	orgs, err := l.svcCtx.OrgsModel.FindByMemberUserId(l.ctx, userId)
+	if err != nil {
+		l.Errorf("Failed to fetch organizations of user %d: %v", userId, err)
+		return nil, fmt.Errorf("所属組織の取得に失敗しました")
+	}
+	names := make(map[uint64]string, len(orgs))
+	for _, org := range orgs {
+		names[org.Id] = org.Name
+	}
+
+	result := make([]types.OrgMembershipInfo, 0, len(grants))
+	for _, grant := range grants {
+		// 削除済み（復元待ち）の組織は所属組織として返さない
+		name, ok := names[grant.OrgId]
+		if !ok {
+			continue
+		}
+		result = append(result, types.OrgMembershipInfo{
+			OrgId:       int64(grant.OrgId),
+			OrgName:     name,
+			Role:        grant.RoleName(),
+			Permissions: grant.Permissions(),
+		})
+	}
+
+	return result, nil
+}
+
+// tokenMatches JWT が有効で、user_id クレームが一致するか
+func (l *ValidateUserLogic) tokenMatches(tokenString string, userId int64) bool {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (any, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte(l.svcCtx.Config.Auth.AccessSecret), nil
+	})
+	if err != nil || !token.Valid {
+		return false
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return false
+	}
+	claimed, ok := claims["user_id"].(float64)
+	return ok && int64(claimed) == userId
+}
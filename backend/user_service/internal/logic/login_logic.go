@@ -5,10 +5,13 @@ import (
 	"errors"
 	"time"
 
+	"user_service/internal/audit"
 	"user_service/internal/model"
 	"user_service/internal/svc"
 	"user_service/internal/types"
 
+	"github.com/winyx/backend/common/metrics"
+
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/zeromicro/go-zero/core/logx"
 	"golang.org/x/crypto/bcrypt"
@@ -34,15 +37,20 @@ func (l *LoginLogic) Login(req *types.LoginReq) (resp *types.LoginRes, err error
 	if err != nil {
 		if errors.Is(err, model.ErrNotFound) {
 			logx.Errorf("ユーザーが見つかりません: %s", req.Email)
+			metrics.LoginFailed("not_found")
+			l.recordFailure(0, req.Email, "not_found")
 			return nil, errors.New("メールアドレスまたはパスワードが間違っています")
 		}
 		logx.Errorf("ユーザー検索エラー: %v", err)
+		metrics.LoginFailed("error")
 		return nil, errors.New("ログイン処理中にエラーが発生しました")
 	}
 
 	// ユーザーのステータスチェック
 	if user.Status != 1 {
 		logx.Errorf("無効なユーザー: %s (status: %d)", req.Email, user.Status)
+		metrics.LoginFailed("inactive")
+		l.recordFailure(int64(user.Id), req.Email, "inactive")
 		return nil, errors.New("このアカウントは無効になっています")
 	}
 
@@ -50,6 +58,8 @@ func (l *LoginLogic) Login(req *types.LoginReq) (resp *types.LoginRes, err error
 	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password))
 	if err != nil {
 		logx.Errorf("パスワード検証失敗: %s", req.Email)
+		metrics.LoginFailed("bad_password")
+		l.recordFailure(int64(user.Id), req.Email, "bad_password")
 		return nil, errors.New("メールアドレスまたはパスワードが間違っています")
 	}
 
@@ -70,13 +80,39 @@ func (l *LoginLogic) Login(req *types.LoginReq) (resp *types.LoginRes, err error
 	accessToken, err := token.SignedString([]byte(l.svcCtx.Config.Auth.AccessSecret))
 	if err != nil {
 		logx.Errorf("JWT トークン生成エラー: %v", err)
+		metrics.LoginFailed("error")
 		return nil, errors.New("ログイン処理中にエラーが発生しました")
 	}
 
 	logx.Infof("ユーザーログイン成功: %s (ID: %d)", user.Email, user.Id)
+	metrics.LoginSucceeded()
+	// 最終ログイン日時は管理画面の並べ替え用のため、記録に失敗してもログインは成功させる
+	if err := l.svcCtx.UsersModel.TouchLastLogin(l.ctx, user.Id, time.Unix(now, 0)); err != nil {
+		logx.Errorf("最終ログイン日時の更新に失敗: %d: %v", user.Id, err)
+	}
+	l.svcCtx.Audit.Record(l.ctx, audit.Entry{
+		Action:     audit.ActionLogin,
+		ActorId:    int64(user.Id),
+		TargetType: audit.TargetUser,
+		TargetId:   user.Id,
+	})
 
 	return &types.LoginRes{
 		AccessToken: accessToken,
 		ExpireTime:  now + accessExpire,
 	}, nil
 }
+
+// recordFailure ログイン失敗を監査ログに記録（userId は該当ユーザーが存在する場合のみ）
+// 本人の操作とは限らないため、操作者は未認証として対象ユーザーにのみ記録する
+func (l *LoginLogic) recordFailure(userId int64, email, reason string) {
+	entry := audit.Entry{
+		Action:     audit.ActionLoginFailed,
+		TargetType: audit.TargetUser,
+		After:      map[string]string{"email_digest": l.svcCtx.Audit.EmailDigest(email), "reason": reason},
+	}
+	if userId > 0 {
+		entry.TargetId = userId
+	}
+	l.svcCtx.Audit.Record(l.ctx, entry)
+}
@@ -0,0 +1,70 @@
+package org
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"user_service/internal/audit"
+	"user_service/internal/model"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type AcceptInvitationLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewAcceptInvitationLogic(ctx context.Context, svcCtx *svc.ServiceContext) *AcceptInvitationLogic {
+	return &AcceptInvitationLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *AcceptInvitationLogic) AcceptInvitation(req *types.InvitationTokenReq) (resp *types.Org, err error) {
+	userId, err := currentUserId(l.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	inv, err := findPendingInvitation(l.ctx, l.svcCtx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	// トークンが漏れても別アカウントでは参加できないよう、招待先メールアドレスと照合する
+	user, err := l.svcCtx.UsersModel.FindOne(l.ctx, uint64(userId))
+	if err != nil {
+		l.Errorf("Failed to fetch user %d: %v", userId, err)
+		return nil, fmt.Errorf("ユーザー情報の取得に失敗しました")
+	}
+	if !strings.EqualFold(strings.TrimSpace(user.Email), inv.Email) {
+		return nil, fmt.Errorf("この招待は別のメールアドレス宛てです")
+	}
+
+	org, err := findActiveOrg(l.ctx, l.svcCtx, int64(inv.OrgId))
+	if err != nil {
+		return nil, err
+	}
+
+	ok, err := l.svcCtx.OrgInvitationsModel.Accept(l.ctx, inv, user.Id)
+	if err != nil {
+		l.Errorf("Failed to accept invitation %d for user %d: %v", inv.Id, userId, err)
+		return nil, fmt.Errorf("招待の承諾に失敗しました")
+	}
+	if !ok {
+		return nil, errInvitationUsed
+	}
+
+	l.Infof("User %d joined org %d via invitation %d", userId, org.Id, inv.Id)
+	recordStatusChange(l.ctx, l.svcCtx, audit.ActionInvitationAccept, inv.OrgId, audit.TargetInvitation, inv.Id,
+		model.InvitationStatusPending, model.InvitationStatusAccepted)
+	result := toOrg(org)
+	return &result, nil
+}
@@ -0,0 +1,88 @@
+package org
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"user_service/internal/audit"
+	"user_service/internal/model"
+	"user_service/internal/orgperm"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type AcceptOrgTransferLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewAcceptOrgTransferLogic(ctx context.Context, svcCtx *svc.ServiceContext) *AcceptOrgTransferLogic {
+	return &AcceptOrgTransferLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *AcceptOrgTransferLogic) AcceptOrgTransfer(req *types.GetOrgReq) (resp *types.Org, err error) {
+	access, err := loadOrgAccess(l.ctx, l.svcCtx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	transfer, err := findPendingTransfer(l.ctx, l.svcCtx, access.org.Id)
+	if err != nil {
+		return nil, err
+	}
+	// 承諾できるのは移譲先ユーザー本人のみ（システム管理者でも代理承諾はできない）
+	if transfer.ToUserId != uint64(access.userId) {
+		return nil, errOrgForbidden
+	}
+
+	ownerRole, err := findOrgRoleByName(l.ctx, l.svcCtx, orgperm.RoleOwner)
+	if err != nil {
+		return nil, err
+	}
+	adminRole, err := findOrgRoleByName(l.ctx, l.svcCtx, orgperm.RoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	ok, err := l.svcCtx.OrgTransfersModel.Complete(l.ctx, transfer, ownerRole.Id, adminRole.Id)
+	if err != nil {
+		if errors.Is(err, model.ErrOwnerChanged) {
+			// 依頼後にオーナーが変わった依頼は無効
+			if _, err := l.svcCtx.OrgTransfersModel.Transition(l.ctx, transfer, model.TransferStatusCancelled); err != nil {
+				l.Errorf("Failed to cancel stale ownership transfer %d: %v", transfer.Id, err)
+			}
+			return nil, fmt.Errorf("依頼後に組織のオーナーが変更されたため、この依頼は無効です")
+		}
+		l.Errorf("Failed to complete ownership transfer %d: %v", transfer.Id, err)
+		return nil, fmt.Errorf("所有権移譲の承諾に失敗しました")
+	}
+	if !ok {
+		return nil, errTransferNotFound
+	}
+
+	org, err := l.svcCtx.OrgsModel.FindOne(l.ctx, access.org.Id)
+	if err != nil {
+		l.Errorf("Failed to fetch organization %d: %v", req.Id, err)
+		return nil, fmt.Errorf("組織情報の取得に失敗しました")
+	}
+
+	l.Infof("Ownership of org %d transferred from user %d to user %d", req.Id, transfer.FromUserId, transfer.ToUserId)
+	l.svcCtx.Audit.Record(l.ctx, audit.Entry{
+		Action:     audit.ActionTransferAccept,
+		OrgId:      req.Id,
+		TargetType: audit.TargetTransfer,
+		TargetId:   transfer.Id,
+		Before:     statusSnapshot{Status: model.TransferStatusPending, OwnerId: transfer.FromUserId},
+		After:      statusSnapshot{Status: model.TransferStatusAccepted, OwnerId: transfer.ToUserId},
+	})
+	result := toOrg(org)
+	return &result, nil
+}
@@ -0,0 +1,89 @@
+package org
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"user_service/internal/apikey"
+	"user_service/internal/model"
+	"user_service/internal/orgperm"
+	"user_service/internal/types"
+)
+
+var errApiKeyNotFound = errors.New("API キーが見つかりません")
+
+// apiKeyScopes API キーに付与できる権限
+// キーの管理（api_keys:manage）は人が JWT で行う操作に限り、キーには付与しない
+var apiKeyScopes = map[string]bool{
+	orgperm.OrgView:           true,
+	orgperm.OrgUpdate:         true,
+	orgperm.OrgDelete:         true,
+	orgperm.OrgTransfer:       true,
+	orgperm.MembersView:       true,
+	orgperm.MembersManage:     true,
+	orgperm.InvitationsManage: true,
+	orgperm.ResourcesRead:     true,
+	orgperm.ResourcesWrite:    true,
+}
+
+// normalizeApiKeyScopes スコープを検証し、重複を除いて並べ替える
+// 作成者が組織内ロールで持っていない権限は指定できない
+func normalizeApiKeyScopes(scopes []string, access *orgAccess) ([]string, error) {
+	granted := access.grant.Permissions()
+
+	result := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		scope = strings.TrimSpace(scope)
+		if !apiKeyScopes[scope] {
+			return nil, fmt.Errorf("API キーに付与できない権限です: %s", scope)
+		}
+		if !slices.Contains(granted, scope) {
+			return nil, fmt.Errorf("自分が持たない権限は API キーに付与できません: %s", scope)
+		}
+		if !slices.Contains(result, scope) {
+			result = append(result, scope)
+		}
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("スコープを1つ以上指定してください")
+	}
+	slices.Sort(result)
+
+	return result, nil
+}
+
+// normalizeApiKeyName API キー名の前後の空白を除いて検証
+func normalizeApiKeyName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", fmt.Errorf("API キー名を入力してください")
+	}
+	if utf8.RuneCountInString(name) > 100 {
+		return "", fmt.Errorf("API キー名は100文字以内で入力してください")
+	}
+
+	return name, nil
+}
+
+func toOrgApiKey(key *model.OrgApiKeys) types.OrgApiKey {
+	result := types.OrgApiKey{
+		Id:        int64(key.Id),
+		OrgId:     int64(key.OrgId),
+		Name:      key.Name,
+		Prefix:    key.Prefix,
+		Scopes:    apikey.SplitScopes(key.Scopes),
+		CreatedBy: int64(key.CreatedBy),
+		CreatedAt: key.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if key.ExpiresAt.Valid {
+		result.ExpiresAt = key.ExpiresAt.Time.Format("2006-01-02T15:04:05Z07:00")
+	}
+	if key.LastUsedAt.Valid {
+		result.LastUsedAt = key.LastUsedAt.Time.Format("2006-01-02T15:04:05Z07:00")
+	}
+
+	return result
+}
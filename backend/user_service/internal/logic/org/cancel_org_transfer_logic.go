@@ -0,0 +1,60 @@
+package org
+
+import (
+	"context"
+	"fmt"
+
+	"user_service/internal/audit"
+	"user_service/internal/model"
+	"user_service/internal/orgperm"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type CancelOrgTransferLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewCancelOrgTransferLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CancelOrgTransferLogic {
+	return &CancelOrgTransferLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *CancelOrgTransferLogic) CancelOrgTransfer(req *types.GetOrgReq) (resp *types.CommonRes, err error) {
+	access, err := loadOrgAccess(l.ctx, l.svcCtx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if !access.can(orgperm.OrgTransfer) {
+		return nil, errOrgForbidden
+	}
+
+	transfer, err := findPendingTransfer(l.ctx, l.svcCtx, access.org.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	ok, err := l.svcCtx.OrgTransfersModel.Transition(l.ctx, transfer, model.TransferStatusCancelled)
+	if err != nil {
+		l.Errorf("Failed to cancel ownership transfer %d: %v", transfer.Id, err)
+		return nil, fmt.Errorf("所有権移譲依頼の取り消しに失敗しました")
+	}
+	if !ok {
+		return nil, errTransferNotFound
+	}
+
+	l.Infof("Ownership transfer %d of org %d cancelled by user %d", transfer.Id, req.Id, access.userId)
+	recordStatusChange(l.ctx, l.svcCtx, audit.ActionTransferCancel, transfer.OrgId, audit.TargetTransfer, transfer.Id,
+		model.TransferStatusPending, model.TransferStatusCancelled)
+	return &types.CommonRes{
+		Message: "所有権移譲の依頼を取り消しました",
+		Success: true,
+	}, nil
+}
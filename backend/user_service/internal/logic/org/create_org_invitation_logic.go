@@ -0,0 +1,140 @@
+package org
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"user_service/internal/audit"
+	"user_service/internal/model"
+	"user_service/internal/orgperm"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type CreateOrgInvitationLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewCreateOrgInvitationLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CreateOrgInvitationLogic {
+	return &CreateOrgInvitationLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *CreateOrgInvitationLogic) CreateOrgInvitation(req *types.CreateOrgInvitationReq) (resp *types.OrgInvitation, err error) {
+	access, err := loadOrgAccess(l.ctx, l.svcCtx, req.OrgId)
+	if err != nil {
+		return nil, err
+	}
+	if !access.can(orgperm.InvitationsManage) {
+		return nil, errOrgForbidden
+	}
+
+	email, err := normalizeEmail(req.Email)
+	if err != nil {
+		return nil, err
+	}
+	role, err := findOrgRole(l.ctx, l.svcCtx, req.RoleName)
+	if err != nil {
+		return nil, err
+	}
+
+	// 登録済みユーザーが既にメンバーなら招待しない
+	user, err := l.svcCtx.UsersModel.FindOneByEmail(l.ctx, email)
+	switch {
+	case err == nil:
+		_, err = l.svcCtx.OrgMembersModel.FindOneByOrgIdUserId(l.ctx, access.org.Id, user.Id)
+		if err == nil {
+			return nil, fmt.Errorf("既に組織のメンバーです")
+		}
+		if !errors.Is(err, model.ErrNotFound) {
+			l.Errorf("Failed to fetch membership of user %d in org %d: %v", user.Id, req.OrgId, err)
+			return nil, fmt.Errorf("招待の作成に失敗しました")
+		}
+	case !errors.Is(err, model.ErrNotFound):
+		l.Errorf("Failed to fetch user by email %s: %v", email, err)
+		return nil, fmt.Errorf("招待の作成に失敗しました")
+	}
+
+	// 同じ宛先への未回答の招待は取り消し、新しいトークンで送り直す
+	pending, err := l.svcCtx.OrgInvitationsModel.FindPendingByOrgId(l.ctx, access.org.Id)
+	if err != nil {
+		l.Errorf("Failed to fetch invitations of org %d: %v", req.OrgId, err)
+		return nil, fmt.Errorf("招待の作成に失敗しました")
+	}
+	for _, inv := range pending {
+		if inv.Email != email {
+			continue
+		}
+		if _, err := l.svcCtx.OrgInvitationsModel.Transition(l.ctx, inv, model.InvitationStatusRevoked); err != nil {
+			l.Errorf("Failed to revoke invitation %d: %v", inv.Id, err)
+			return nil, fmt.Errorf("招待の作成に失敗しました")
+		}
+	}
+
+	token, tokenHash, err := newInvitationToken()
+	if err != nil {
+		l.Errorf("Failed to generate invitation token: %v", err)
+		return nil, fmt.Errorf("招待の作成に失敗しました")
+	}
+
+	expireHours := req.ExpireHours
+	if expireHours <= 0 {
+		expireHours = l.svcCtx.Config.Invitation.ExpireHours
+	}
+	result, err := l.svcCtx.OrgInvitationsModel.Insert(l.ctx, &model.OrgInvitations{
+		OrgId:     access.org.Id,
+		Email:     email,
+		RoleId:    role.Id,
+		TokenHash: tokenHash,
+		Status:    model.InvitationStatusPending,
+		InvitedBy: uint64(access.userId),
+		ExpiresAt: time.Now().Add(time.Duration(expireHours) * time.Hour),
+	})
+	if err != nil {
+		l.Errorf("Failed to create invitation to org %d for %s: %v", req.OrgId, email, err)
+		return nil, fmt.Errorf("招待の作成に失敗しました")
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		l.Errorf("Failed to get invitation id: %v", err)
+		return nil, fmt.Errorf("招待の作成に失敗しました")
+	}
+	inv, err := l.svcCtx.OrgInvitationsModel.FindOne(l.ctx, uint64(id))
+	if err != nil {
+		l.Errorf("Failed to fetch invitation %d: %v", id, err)
+		return nil, fmt.Errorf("招待の作成に失敗しました")
+	}
+
+	// 届かない招待を残さないよう、送信に失敗した場合は取り消す
+	if err := sendInvitation(l.ctx, l.svcCtx, access.org, inv, token); err != nil {
+		l.Errorf("Failed to send invitation %d to %s: %v", inv.Id, email, err)
+		if _, err := l.svcCtx.OrgInvitationsModel.Transition(l.ctx, inv, model.InvitationStatusRevoked); err != nil {
+			l.Errorf("Failed to revoke undelivered invitation %d: %v", inv.Id, err)
+		}
+		return nil, fmt.Errorf("招待の送信に失敗しました")
+	}
+
+	l.Infof("Invitation %d to org %d sent to %s as %s by user %d", inv.Id, req.OrgId, email, role.Name, access.userId)
+	invitation := toOrgInvitation(inv, role.Name)
+	l.svcCtx.Audit.Record(l.ctx, audit.Entry{
+		Action:     audit.ActionInvitationCreate,
+		OrgId:      req.OrgId,
+		TargetType: audit.TargetInvitation,
+		TargetId:   inv.Id,
+		After: invitationSnapshot{
+			EmailDigest: l.svcCtx.Audit.EmailDigest(inv.Email),
+			Role:        role.Name,
+			ExpiresAt:   invitation.ExpiresAt,
+		},
+	})
+	return &invitation, nil
+}
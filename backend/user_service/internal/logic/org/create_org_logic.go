@@ -2,12 +2,12 @@ package org
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"strconv"
 	"strings"
 
+	"user_service/internal/audit"
 	"user_service/internal/model"
+	"user_service/internal/orgperm"
 	"user_service/internal/svc"
 	"user_service/internal/types"
 
@@ -29,43 +29,11 @@ func NewCreateOrgLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CreateO
 }
 
 func (l *CreateOrgLogic) CreateOrg(req *types.CreateOrgReq) (resp *types.Org, err error) {
-	// JWTから現在のユーザーIDを取得 (user_id キーから数値として取得)
-	userIdValue := l.ctx.Value("user_id")
-	if userIdValue == nil {
+	// JWTから現在のユーザーIDを取得
+	userIdInt, err := currentUserId(l.ctx)
+	if err != nil {
 		l.Errorf("user_id not found in JWT context")
-		return nil, fmt.Errorf("認証エラー: ユーザーIDが取得できません")
-	}
-
-	var userIdInt int64
-	switch v := userIdValue.(type) {
-	case int:
-		userIdInt = int64(v)
-	case int64:
-		userIdInt = v
-	case float64:
-		userIdInt = int64(v)
-	case json.Number:
-		var err error
-		userIdInt, err = v.Int64()
-		if err != nil {
-			l.Errorf("Failed to convert json.Number to int64: %v", err)
-			return nil, fmt.Errorf("認証エラー: 無効なユーザーID")
-		}
-	case string:
-		var err error
-		userIdInt, err = strconv.ParseInt(v, 10, 64)
-		if err != nil {
-			l.Errorf("Failed to convert user_id string to int64: %v", err)
-			return nil, fmt.Errorf("認証エラー: 無効なユーザーID")
-		}
-	default:
-		l.Errorf("Unexpected user_id type: %T, value: %v", v, v)
-		return nil, fmt.Errorf("認証エラー: ユーザーIDの型が不正です")
-	}
-
-	if userIdInt <= 0 {
-		l.Errorf("Invalid user ID: %d", userIdInt)
-		return nil, fmt.Errorf("認証エラー: 無効なユーザーID")
+		return nil, err
 	}
 
 	// 入力値バリデーション
@@ -91,41 +59,42 @@ func (l *CreateOrgLogic) CreateOrg(req *types.CreateOrgReq) (resp *types.Org, er
 		return nil, fmt.Errorf("この組織名は既に使用されています")
 	}
 
-	// 新しい組織をデータベースに挿入
+	// 作成者は組織オーナーとして org_members にも登録する
+	ownerRole, err := findOrgRoleByName(l.ctx, l.svcCtx, orgperm.RoleOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	// 新しい組織とオーナーのメンバーシップを同一トランザクションで登録
 	newOrg := &model.Orgs{
 		Name:    strings.TrimSpace(req.Name),
 		OwnerId: uint64(userIdInt),
 	}
 
-	result, err := l.svcCtx.OrgsModel.Insert(l.ctx, newOrg)
+	orgId, err := l.svcCtx.OrgsModel.InsertWithOwner(l.ctx, newOrg, ownerRole.Id)
 	if err != nil {
 		l.Errorf("Failed to create organization: %v", err)
 		return nil, fmt.Errorf("組織の作成に失敗しました")
 	}
 
-	// 挿入されたレコードのIDを取得
-	orgId, err := result.LastInsertId()
-	if err != nil {
-		l.Errorf("Failed to get last insert ID: %v", err)
-		return nil, fmt.Errorf("組織の作成に失敗しました")
-	}
-
 	// 作成されたデータを再取得してレスポンスを構築
-	createdOrg, err := l.svcCtx.OrgsModel.FindOne(l.ctx, uint64(orgId))
+	createdOrg, err := l.svcCtx.OrgsModel.FindOne(l.ctx, orgId)
 	if err != nil {
 		l.Errorf("Failed to retrieve created organization: %v", err)
 		return nil, fmt.Errorf("組織の作成に失敗しました")
 	}
 
+	l.svcCtx.Audit.Record(l.ctx, audit.Entry{
+		Action:     audit.ActionOrgCreate,
+		OrgId:      int64(orgId),
+		TargetType: audit.TargetOrg,
+		TargetId:   orgId,
+		After:      audit.SnapshotOrg(createdOrg),
+	})
+
 	// レスポンス型に変換
-	resp = &types.Org{
-		Id:        int64(createdOrg.Id),
-		Name:      createdOrg.Name,
-		OwnerId:   int64(createdOrg.OwnerId),
-		CreatedAt: createdOrg.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
-		UpdatedAt: createdOrg.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
-	}
+	org := toOrg(createdOrg)
 
 	l.Infof("Successfully created organization: %s (ID: %d) by user %d", req.Name, orgId, userIdInt)
-	return resp, nil
+	return &org, nil
 }
@@ -0,0 +1,51 @@
+package org
+
+import (
+	"context"
+	"fmt"
+
+	"user_service/internal/audit"
+	"user_service/internal/model"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type DeclineInvitationLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewDeclineInvitationLogic(ctx context.Context, svcCtx *svc.ServiceContext) *DeclineInvitationLogic {
+	return &DeclineInvitationLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *DeclineInvitationLogic) DeclineInvitation(req *types.InvitationTokenReq) (resp *types.CommonRes, err error) {
+	inv, err := findPendingInvitation(l.ctx, l.svcCtx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	ok, err := l.svcCtx.OrgInvitationsModel.Transition(l.ctx, inv, model.InvitationStatusDeclined)
+	if err != nil {
+		l.Errorf("Failed to decline invitation %d: %v", inv.Id, err)
+		return nil, fmt.Errorf("招待の辞退に失敗しました")
+	}
+	if !ok {
+		return nil, errInvitationUsed
+	}
+
+	l.Infof("Invitation %d to org %d declined", inv.Id, inv.OrgId)
+	recordStatusChange(l.ctx, l.svcCtx, audit.ActionInvitationDecline, inv.OrgId, audit.TargetInvitation, inv.Id,
+		model.InvitationStatusPending, model.InvitationStatusDeclined)
+	return &types.CommonRes{
+		Message: "招待を辞退しました",
+		Success: true,
+	}, nil
+}
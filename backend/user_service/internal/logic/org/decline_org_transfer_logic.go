@@ -0,0 +1,59 @@
+package org
+
+import (
+	"context"
+	"fmt"
+
+	"user_service/internal/audit"
+	"user_service/internal/model"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type DeclineOrgTransferLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewDeclineOrgTransferLogic(ctx context.Context, svcCtx *svc.ServiceContext) *DeclineOrgTransferLogic {
+	return &DeclineOrgTransferLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *DeclineOrgTransferLogic) DeclineOrgTransfer(req *types.GetOrgReq) (resp *types.CommonRes, err error) {
+	access, err := loadOrgAccess(l.ctx, l.svcCtx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	transfer, err := findPendingTransfer(l.ctx, l.svcCtx, access.org.Id)
+	if err != nil {
+		return nil, err
+	}
+	if transfer.ToUserId != uint64(access.userId) {
+		return nil, errOrgForbidden
+	}
+
+	ok, err := l.svcCtx.OrgTransfersModel.Transition(l.ctx, transfer, model.TransferStatusDeclined)
+	if err != nil {
+		l.Errorf("Failed to decline ownership transfer %d: %v", transfer.Id, err)
+		return nil, fmt.Errorf("所有権移譲の辞退に失敗しました")
+	}
+	if !ok {
+		return nil, errTransferNotFound
+	}
+
+	l.Infof("Ownership transfer %d of org %d declined by user %d", transfer.Id, req.Id, access.userId)
+	recordStatusChange(l.ctx, l.svcCtx, audit.ActionTransferDecline, transfer.OrgId, audit.TargetTransfer, transfer.Id,
+		model.TransferStatusPending, model.TransferStatusDeclined)
+	return &types.CommonRes{
+		Message: "所有権移譲の依頼を辞退しました",
+		Success: true,
+	}, nil
+}
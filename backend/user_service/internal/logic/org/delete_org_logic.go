@@ -2,7 +2,11 @@ package org
 
 import (
 	"context"
+	"fmt"
+	"time"
 
+	"user_service/internal/audit"
+	"user_service/internal/orgperm"
 	"user_service/internal/svc"
 	"user_service/internal/types"
 
@@ -24,7 +28,37 @@ func NewDeleteOrgLogic(ctx context.Context, svcCtx *svc.ServiceContext) *DeleteO
 }
 
 func (l *DeleteOrgLogic) DeleteOrg(req *types.GetOrgReq) (resp *types.CommonRes, err error) {
-	// todo: add your logic here and delete this line
+	access, err := loadOrgAccess(l.ctx, l.svcCtx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	// 削除はオーナーとシステム管理者のみ（組織管理者は不可）
+	if !access.can(orgperm.OrgDelete) {
+		return nil, errOrgForbidden
+	}
+
+	// ソフトデリート: 復元期間を過ぎるとパージジョブが完全に削除する
+	if err := l.svcCtx.OrgsModel.SoftDelete(l.ctx, access.org.Id); err != nil {
+		l.Errorf("Failed to delete organization %d: %v", req.Id, err)
+		return nil, fmt.Errorf("組織の削除に失敗しました")
+	}
+
+	deletedAt := time.Now()
+	l.Infof("Organization %d deleted by user %d", req.Id, access.userId)
+	after := audit.SnapshotOrg(access.org)
+	after.DeletedAt = deletedAt.Format("2006-01-02T15:04:05Z07:00")
+	l.svcCtx.Audit.Record(l.ctx, audit.Entry{
+		Action:     audit.ActionOrgDelete,
+		OrgId:      req.Id,
+		TargetType: audit.TargetOrg,
+		TargetId:   req.Id,
+		Before:     audit.SnapshotOrg(access.org),
+		After:      after,
+	})
 
-	return
+	return &types.CommonRes{
+		Message: fmt.Sprintf("組織を削除しました（%s まで復元できます）",
+			restoreDeadline(l.svcCtx, deletedAt).Format("2006-01-02 15:04")),
+		Success: true,
+	}, nil
 }
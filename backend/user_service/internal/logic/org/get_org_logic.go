@@ -24,7 +24,11 @@ func NewGetOrgLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetOrgLogi
 }
 
 func (l *GetOrgLogic) GetOrg(req *types.GetOrgReq) (resp *types.Org, err error) {
-	// todo: add your logic here and delete this line
+	access, err := loadOrgAccess(l.ctx, l.svcCtx, req.Id)
+	if err != nil {
+		return nil, err
+	}
 
-	return
+	org := toOrg(access.org)
+	return &org, nil
 }
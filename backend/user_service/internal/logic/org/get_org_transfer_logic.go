@@ -0,0 +1,44 @@
+package org
+
+import (
+	"context"
+
+	"user_service/internal/orgperm"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetOrgTransferLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetOrgTransferLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetOrgTransferLogic {
+	return &GetOrgTransferLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *GetOrgTransferLogic) GetOrgTransfer(req *types.GetOrgReq) (resp *types.OrgTransfer, err error) {
+	access, err := loadOrgAccess(l.ctx, l.svcCtx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	transfer, err := findPendingTransfer(l.ctx, l.svcCtx, access.org.Id)
+	if err != nil {
+		return nil, err
+	}
+	// 依頼内容はオーナー（システム管理者）と移譲先ユーザーのみ参照できる
+	if !access.can(orgperm.OrgTransfer) && transfer.ToUserId != uint64(access.userId) {
+		return nil, errTransferNotFound
+	}
+
+	result := toOrgTransfer(transfer)
+	return &result, nil
+}
@@ -0,0 +1,163 @@
+package org
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+
+	"user_service/internal/audit"
+	"user_service/internal/model"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+
+	"github.com/winyx/backend/common/notify"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// 招待トークンのランダムバイト長
+const invitationTokenBytes = 32
+
+var (
+	errInvitationNotFound = errors.New("招待が見つかりません")
+	errInvitationUsed     = errors.New("この招待は既に使用済みか、取り消されています")
+	errInvitationExpired  = errors.New("招待の有効期限が切れています")
+)
+
+// newInvitationToken 招待トークンと、保存用のハッシュを生成
+func newInvitationToken() (token, hash string, err error) {
+	buf := make([]byte, invitationTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+
+	token = base64.RawURLEncoding.EncodeToString(buf)
+	return token, hashInvitationToken(token), nil
+}
+
+// hashInvitationToken DB にはトークンそのものではなく SHA-256 を保存する
+func hashInvitationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// normalizeEmail 招待先の照合に使うメールアドレスの正規形
+func normalizeEmail(email string) (string, error) {
+	addr, err := mail.ParseAddress(strings.TrimSpace(email))
+	if err != nil || addr.Name != "" {
+		return "", fmt.Errorf("メールアドレスの形式が正しくありません")
+	}
+
+	return strings.ToLower(addr.Address), nil
+}
+
+func toOrgInvitation(inv *model.OrgInvitations, roleName string) types.OrgInvitation {
+	return types.OrgInvitation{
+		Id:        int64(inv.Id),
+		OrgId:     int64(inv.OrgId),
+		Email:     inv.Email,
+		RoleName:  roleName,
+		Status:    inv.Status,
+		InvitedBy: int64(inv.InvitedBy),
+		ExpiresAt: inv.ExpiresAt.Format("2006-01-02T15:04:05Z07:00"),
+		CreatedAt: inv.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
+
+// sendInvitation 招待先へ承諾用リンクを通知する
+func sendInvitation(ctx context.Context, svcCtx *svc.ServiceContext, org *model.Orgs, inv *model.OrgInvitations, token string) error {
+	link := svcCtx.Config.Invitation.AcceptUrl
+	if strings.Contains(link, "?") {
+		link += "&token=" + url.QueryEscape(token)
+	} else {
+		link += "?token=" + url.QueryEscape(token)
+	}
+
+	return svcCtx.Notifier.Send(ctx, notify.Message{
+		To:      inv.Email,
+		Subject: fmt.Sprintf("組織「%s」への招待", org.Name),
+		Body: fmt.Sprintf("組織「%s」に招待されました。\n以下のリンクから招待を承諾してください（有効期限: %s）。\n%s\n",
+			org.Name, inv.ExpiresAt.Format("2006-01-02 15:04"), link),
+	})
+}
+
+// findPendingInvitation トークンから回答可能な招待を取得
+// 期限切れの招待はその場で expired に更新する
+func findPendingInvitation(ctx context.Context, svcCtx *svc.ServiceContext, token string) (*model.OrgInvitations, error) {
+	if token == "" {
+		return nil, errInvitationNotFound
+	}
+
+	inv, err := svcCtx.OrgInvitationsModel.FindOneByTokenHash(ctx, hashInvitationToken(token))
+	if err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			return nil, errInvitationNotFound
+		}
+		logx.WithContext(ctx).Errorf("Failed to fetch invitation by token: %v", err)
+		return nil, fmt.Errorf("招待情報の取得に失敗しました")
+	}
+
+	if inv.Status != model.InvitationStatusPending {
+		return nil, errInvitationUsed
+	}
+	if !inv.ExpiresAt.After(time.Now()) {
+		if _, err := svcCtx.OrgInvitationsModel.Transition(ctx, inv, model.InvitationStatusExpired); err != nil {
+			logx.WithContext(ctx).Errorf("Failed to expire invitation %d: %v", inv.Id, err)
+		}
+		return nil, errInvitationExpired
+	}
+
+	return inv, nil
+}
+
+// AcceptInvitationOnRegister 登録直後のユーザーを、招待メールのトークンで招待先の組織に参加させる
+// メールアドレスの確認を経ないため、宛先のアドレスで登録しただけでは参加させず、トークンの所持を条件にする。
+// 招待の処理に失敗してもユーザー登録は成功として扱うため、エラーはログに残し、参加できたかのみ返す
+func AcceptInvitationOnRegister(ctx context.Context, svcCtx *svc.ServiceContext, userId uint64, email, token string) bool {
+	logger := logx.WithContext(ctx)
+
+	inv, err := findPendingInvitation(ctx, svcCtx, token)
+	if err != nil {
+		logger.Infof("Invitation token given on registration of user %d was not accepted: %v", userId, err)
+		return false
+	}
+	// トークンが漏れても別のアドレスで登録したアカウントでは参加できない
+	if !strings.EqualFold(strings.TrimSpace(email), inv.Email) {
+		logger.Infof("Invitation %d is addressed to another email than user %d", inv.Id, userId)
+		return false
+	}
+	// 削除済み（復元待ち）の組織への招待は、復元後の承諾に委ねる
+	if _, err := findActiveOrg(ctx, svcCtx, int64(inv.OrgId)); err != nil {
+		return false
+	}
+
+	ok, err := svcCtx.OrgInvitationsModel.Accept(ctx, inv, userId)
+	if err != nil {
+		logger.Errorf("Failed to accept invitation %d for user %d: %v", inv.Id, userId, err)
+		return false
+	}
+	if !ok {
+		return false
+	}
+
+	logger.Infof("User %d joined org %d via invitation %d on registration", userId, inv.OrgId, inv.Id)
+	// 登録直後でトークンが無いため、操作者は登録したユーザーとして記録する
+	svcCtx.Audit.Record(ctx, audit.Entry{
+		Action:     audit.ActionInvitationAccept,
+		ActorId:    int64(userId),
+		OrgId:      int64(inv.OrgId),
+		TargetType: audit.TargetInvitation,
+		TargetId:   inv.Id,
+		Before:     statusSnapshot{Status: model.InvitationStatusPending},
+		After:      statusSnapshot{Status: model.InvitationStatusAccepted},
+	})
+	return true
+}
@@ -0,0 +1,48 @@
+package org
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"user_service/internal/svc"
+	"user_service/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListDeletedOrgsLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListDeletedOrgsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListDeletedOrgsLogic {
+	return &ListDeletedOrgsLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ListDeletedOrgsLogic) ListDeletedOrgs() (resp []types.Org, err error) {
+	userId, err := currentUserId(l.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// 復元期間内に削除されたものだけを返す（期間を過ぎたものはパージ待ち）
+	deletedAfter := time.Now().Add(-time.Duration(l.svcCtx.Config.Org.RestoreDays) * 24 * time.Hour)
+	orgs, err := l.svcCtx.OrgsModel.FindDeletedByOwnerId(l.ctx, uint64(userId), deletedAfter)
+	if err != nil {
+		l.Errorf("Failed to fetch deleted organizations of user %d: %v", userId, err)
+		return nil, fmt.Errorf("削除済み組織一覧の取得に失敗しました")
+	}
+
+	resp = make([]types.Org, 0, len(orgs))
+	for _, org := range orgs {
+		resp = append(resp, toOrg(org))
+	}
+
+	return resp, nil
+}
@@ -2,6 +2,7 @@ package org
 
 import (
 	"context"
+	"fmt"
 
 	"user_service/internal/svc"
 	"user_service/internal/types"
@@ -24,7 +25,21 @@ func NewListMyOrgsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListMy
 }
 
 func (l *ListMyOrgsLogic) ListMyOrgs() (resp []types.Org, err error) {
-	// todo: add your logic here and delete this line
+	userId, err := currentUserId(l.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	orgs, err := l.svcCtx.OrgsModel.FindByMemberUserId(l.ctx, uint64(userId))
+	if err != nil {
+		l.Errorf("Failed to fetch organizations of user %d: %v", userId, err)
+		return nil, fmt.Errorf("組織一覧の取得に失敗しました")
+	}
+
+	resp = make([]types.Org, 0, len(orgs))
+	for _, org := range orgs {
+		resp = append(resp, toOrg(org))
+	}
 
-	return
+	return resp, nil
 }
@@ -0,0 +1,49 @@
+package org
+
+import (
+	"context"
+	"fmt"
+
+	"user_service/internal/orgperm"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListOrgApiKeysLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListOrgApiKeysLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListOrgApiKeysLogic {
+	return &ListOrgApiKeysLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ListOrgApiKeysLogic) ListOrgApiKeys(req *types.GetOrgReq) (resp *types.OrgApiKeyListRes, err error) {
+	access, err := loadOrgAccess(l.ctx, l.svcCtx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if !access.can(orgperm.ApiKeysManage) {
+		return nil, errOrgForbidden
+	}
+
+	keys, err := l.svcCtx.OrgApiKeysModel.FindActiveByOrgId(l.ctx, access.org.Id)
+	if err != nil {
+		l.Errorf("Failed to fetch API keys of org %d: %v", req.Id, err)
+		return nil, fmt.Errorf("API キー一覧の取得に失敗しました")
+	}
+
+	result := make([]types.OrgApiKey, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, toOrgApiKey(key))
+	}
+
+	return &types.OrgApiKeyListRes{ApiKeys: result}, nil
+}
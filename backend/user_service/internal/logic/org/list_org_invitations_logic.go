@@ -0,0 +1,62 @@
+package org
+
+import (
+	"context"
+	"fmt"
+
+	"user_service/internal/orgperm"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListOrgInvitationsLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListOrgInvitationsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListOrgInvitationsLogic {
+	return &ListOrgInvitationsLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ListOrgInvitationsLogic) ListOrgInvitations(req *types.GetOrgReq) (resp *types.OrgInvitationListRes, err error) {
+	access, err := loadOrgAccess(l.ctx, l.svcCtx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if !access.can(orgperm.InvitationsManage) {
+		return nil, errOrgForbidden
+	}
+
+	invitations, err := l.svcCtx.OrgInvitationsModel.FindPendingByOrgId(l.ctx, access.org.Id)
+	if err != nil {
+		l.Errorf("Failed to fetch invitations of org %d: %v", req.Id, err)
+		return nil, fmt.Errorf("招待一覧の取得に失敗しました")
+	}
+
+	roleNames := make(map[int64]string)
+	resp = &types.OrgInvitationListRes{
+		Invitations: make([]types.OrgInvitation, 0, len(invitations)),
+	}
+	for _, inv := range invitations {
+		name, ok := roleNames[inv.RoleId]
+		if !ok {
+			role, err := l.svcCtx.OrgRolesModel.FindOne(l.ctx, inv.RoleId)
+			if err != nil {
+				l.Errorf("Failed to fetch role %d: %v", inv.RoleId, err)
+				return nil, fmt.Errorf("招待一覧の取得に失敗しました")
+			}
+			name = role.Name
+			roleNames[inv.RoleId] = name
+		}
+		resp.Invitations = append(resp.Invitations, toOrgInvitation(inv, name))
+	}
+
+	return resp, nil
+}
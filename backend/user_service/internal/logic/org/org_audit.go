@@ -0,0 +1,39 @@
+package org
+
+import (
+	"context"
+
+	"user_service/internal/audit"
+	"user_service/internal/svc"
+)
+
+// memberSnapshot 監査ログに記録する組織メンバーのロール
+type memberSnapshot struct {
+	Role string `json:"role"`
+}
+
+// invitationSnapshot 監査ログに記録する招待の内容（宛先のメールアドレスは Digest のみ）
+type invitationSnapshot struct {
+	EmailDigest string `json:"email_digest"`
+	Role        string `json:"role"`
+	ExpiresAt   string `json:"expires_at"`
+}
+
+// statusSnapshot 招待・所有権移譲依頼・API キーの状態
+type statusSnapshot struct {
+	Status  string `json:"status"`
+	OwnerId uint64 `json:"owner_id,omitempty"` // 所有権移譲の承諾時のみ
+}
+
+// recordStatusChange 招待・所有権移譲依頼・API キーの状態遷移を監査ログに記録
+func recordStatusChange(ctx context.Context, svcCtx *svc.ServiceContext, action string, orgId uint64,
+	targetType string, targetId uint64, from, to string) {
+	svcCtx.Audit.Record(ctx, audit.Entry{
+		Action:     action,
+		OrgId:      int64(orgId),
+		TargetType: targetType,
+		TargetId:   targetId,
+		Before:     statusSnapshot{Status: from},
+		After:      statusSnapshot{Status: to},
+	})
+}
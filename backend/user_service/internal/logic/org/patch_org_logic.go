@@ -0,0 +1,117 @@
+package org
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"user_service/internal/audit"
+	"user_service/internal/etag"
+	"user_service/internal/mergepatch"
+	"user_service/internal/model"
+	"user_service/internal/orgperm"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+// OrgPatch PATCH /orgs/:id のボディ
+type OrgPatch struct {
+	Name mergepatch.Field[string] `json:"name"`
+}
+
+type PatchOrgLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewPatchOrgLogic(ctx context.Context, svcCtx *svc.ServiceContext) *PatchOrgLogic {
+	return &PatchOrgLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// PatchOrg パッチに含まれる項目だけを更新し、更新後の組織を返す
+func (l *PatchOrgLogic) PatchOrg(req *types.PatchOrgReq, patch *OrgPatch) (resp *types.Org, err error) {
+	access, err := loadOrgAccess(l.ctx, l.svcCtx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if !access.can(orgperm.OrgUpdate) {
+		return nil, errOrgForbidden
+	}
+	if err := etag.Require(req.IfMatch); err != nil {
+		return nil, err
+	}
+	if !etag.Match(req.IfMatch, etag.Org(access.org)) {
+		return nil, orgConflict(access.org)
+	}
+
+	errs := make(mergepatch.FieldErrors)
+	if patch.Name.Null {
+		errs.Add("name", "組織名は削除できません")
+	} else if patch.Name.Set {
+		patch.Name.Value = strings.TrimSpace(patch.Name.Value)
+		if n := utf8.RuneCountInString(patch.Name.Value); n < 2 || n > 100 {
+			errs.Add("name", "組織名は2〜100文字で入力してください")
+		}
+	}
+	if err := errs.Err(); err != nil {
+		return nil, err
+	}
+	if !patch.Name.Set {
+		org := toOrg(access.org)
+		return &org, nil
+	}
+
+	// 組織名の重複チェック（自身は除く）
+	existingOrg, err := l.svcCtx.OrgsModel.FindOneByName(l.ctx, patch.Name.Value)
+	switch {
+	case err == nil && existingOrg.Id != access.org.Id:
+		return nil, mergepatch.FieldErrors{"name": "この組織名は既に使用されています"}
+	case err != nil && !errors.Is(err, sqlx.ErrNotFound):
+		l.Errorf("Failed to check organization name duplication: %v", err)
+		return nil, fmt.Errorf("システムエラーが発生しました")
+	}
+
+	before := audit.SnapshotOrg(access.org)
+	access.org.Name = patch.Name.Value
+	err = l.svcCtx.OrgsModel.UpdateWithVersion(l.ctx, access.org)
+	switch {
+	case errors.Is(err, model.ErrVersionConflict):
+		current, err := l.svcCtx.OrgsModel.FindOne(l.ctx, access.org.Id)
+		if err != nil {
+			l.Errorf("Failed to retrieve organization %d: %v", req.Id, err)
+			return nil, fmt.Errorf("組織の更新に失敗しました")
+		}
+		return nil, orgConflict(current)
+	case err != nil:
+		l.Errorf("Failed to patch organization %d: %v", req.Id, err)
+		return nil, fmt.Errorf("組織の更新に失敗しました")
+	}
+
+	updatedOrg, err := l.svcCtx.OrgsModel.FindOne(l.ctx, access.org.Id)
+	if err != nil {
+		l.Errorf("Failed to retrieve updated organization %d: %v", req.Id, err)
+		return nil, fmt.Errorf("組織の更新に失敗しました")
+	}
+
+	l.Infof("Organization %d patched by user %d", req.Id, access.userId)
+	l.svcCtx.Audit.Record(l.ctx, audit.Entry{
+		Action:     audit.ActionOrgUpdate,
+		OrgId:      req.Id,
+		TargetType: audit.TargetOrg,
+		TargetId:   req.Id,
+		Before:     before,
+		After:      audit.SnapshotOrg(updatedOrg),
+	})
+	org := toOrg(updatedOrg)
+	return &org, nil
+}
@@ -2,7 +2,12 @@ package org
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
+	"user_service/internal/audit"
+	"user_service/internal/model"
+	"user_service/internal/orgperm"
 	"user_service/internal/svc"
 	"user_service/internal/types"
 
@@ -24,7 +29,53 @@ func NewRemoveOrgMemberLogic(ctx context.Context, svcCtx *svc.ServiceContext) *R
 }
 
 func (l *RemoveOrgMemberLogic) RemoveOrgMember(req *types.RemoveOrgMemberReq) (resp *types.CommonRes, err error) {
-	// todo: add your logic here and delete this line
+	access, err := loadOrgAccess(l.ctx, l.svcCtx, req.OrgId)
+	if err != nil {
+		return nil, err
+	}
+	// 自分自身の脱退はメンバーであれば可能
+	self := req.UserId == access.userId
+	if !access.can(orgperm.MembersManage) && !self {
+		return nil, errOrgForbidden
+	}
+
+	member, err := l.svcCtx.OrgMembersModel.FindOneByOrgIdUserId(l.ctx, access.org.Id, uint64(req.UserId))
+	if err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			return nil, fmt.Errorf("指定したユーザーは組織のメンバーではありません")
+		}
+		l.Errorf("Failed to fetch membership of user %d in org %d: %v", req.UserId, req.OrgId, err)
+		return nil, fmt.Errorf("メンバーの削除に失敗しました")
+	}
+
+	if member.UserId == access.org.OwnerId {
+		return nil, fmt.Errorf("組織オーナーは削除できません")
+	}
+	role, err := findMemberRole(l.ctx, l.svcCtx, member)
+	if err != nil {
+		return nil, err
+	}
+	if !self {
+		if !access.outranks(role) {
+			return nil, fmt.Errorf("自分と同等以上のロールを持つメンバーは削除できません")
+		}
+	}
+
+	if err := l.svcCtx.OrgMembersModel.Delete(l.ctx, member.Id); err != nil {
+		l.Errorf("Failed to remove user %d from org %d: %v", req.UserId, req.OrgId, err)
+		return nil, fmt.Errorf("メンバーの削除に失敗しました")
+	}
 
-	return
+	l.Infof("User %d removed from org %d by user %d", req.UserId, req.OrgId, access.userId)
+	l.svcCtx.Audit.Record(l.ctx, audit.Entry{
+		Action:     audit.ActionOrgMemberRemove,
+		OrgId:      req.OrgId,
+		TargetType: audit.TargetUser,
+		TargetId:   req.UserId,
+		Before:     memberSnapshot{Role: role.Name},
+	})
+	return &types.CommonRes{
+		Message: "メンバーを削除しました",
+		Success: true,
+	}, nil
 }
@@ -0,0 +1,114 @@
+package org
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"user_service/internal/audit"
+	"user_service/internal/model"
+	"user_service/internal/orgperm"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type RequestOrgTransferLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewRequestOrgTransferLogic(ctx context.Context, svcCtx *svc.ServiceContext) *RequestOrgTransferLogic {
+	return &RequestOrgTransferLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *RequestOrgTransferLogic) RequestOrgTransfer(req *types.TransferOrgReq) (resp *types.OrgTransfer, err error) {
+	access, err := loadOrgAccess(l.ctx, l.svcCtx, req.OrgId)
+	if err != nil {
+		return nil, err
+	}
+	if !access.can(orgperm.OrgTransfer) {
+		return nil, errOrgForbidden
+	}
+
+	// 移譲先は既存メンバーに限る（承諾後はそのままオーナーロールに切り替わる）
+	if uint64(req.NewOwnerId) == access.org.OwnerId {
+		return nil, fmt.Errorf("既に組織のオーナーです")
+	}
+	if _, err := l.svcCtx.OrgMembersModel.FindOneByOrgIdUserId(l.ctx, access.org.Id, uint64(req.NewOwnerId)); err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			return nil, fmt.Errorf("移譲先は組織のメンバーである必要があります")
+		}
+		l.Errorf("Failed to fetch membership of user %d in org %d: %v", req.NewOwnerId, req.OrgId, err)
+		return nil, fmt.Errorf("所有権移譲の依頼に失敗しました")
+	}
+	newOwner, err := l.svcCtx.UsersModel.FindOne(l.ctx, uint64(req.NewOwnerId))
+	if err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			return nil, fmt.Errorf("ユーザーが見つかりません")
+		}
+		l.Errorf("Failed to fetch user %d: %v", req.NewOwnerId, err)
+		return nil, fmt.Errorf("所有権移譲の依頼に失敗しました")
+	}
+	if newOwner.Status != 1 {
+		return nil, fmt.Errorf("無効化されたユーザーには移譲できません")
+	}
+
+	// 未回答の依頼は取り消して作り直す（同時に有効な依頼は1件のみ）
+	pending, err := l.svcCtx.OrgTransfersModel.FindPendingByOrgId(l.ctx, access.org.Id)
+	switch {
+	case err == nil:
+		if _, err := l.svcCtx.OrgTransfersModel.Transition(l.ctx, pending, model.TransferStatusCancelled); err != nil {
+			l.Errorf("Failed to cancel ownership transfer %d: %v", pending.Id, err)
+			return nil, fmt.Errorf("所有権移譲の依頼に失敗しました")
+		}
+		recordStatusChange(l.ctx, l.svcCtx, audit.ActionTransferCancel, pending.OrgId, audit.TargetTransfer, pending.Id,
+			model.TransferStatusPending, model.TransferStatusCancelled)
+	case !errors.Is(err, model.ErrNotFound):
+		l.Errorf("Failed to fetch ownership transfer of org %d: %v", req.OrgId, err)
+		return nil, fmt.Errorf("所有権移譲の依頼に失敗しました")
+	}
+
+	transfer := &model.OrgOwnershipTransfers{
+		OrgId:      access.org.Id,
+		FromUserId: access.org.OwnerId,
+		ToUserId:   newOwner.Id,
+		Status:     model.TransferStatusPending,
+		ExpiresAt:  time.Now().Add(time.Duration(l.svcCtx.Config.Org.TransferExpireHours) * time.Hour),
+	}
+	res, err := l.svcCtx.OrgTransfersModel.Insert(l.ctx, transfer)
+	if err != nil {
+		l.Errorf("Failed to insert ownership transfer for org %d: %v", req.OrgId, err)
+		return nil, fmt.Errorf("所有権移譲の依頼に失敗しました")
+	}
+	transferId, err := res.LastInsertId()
+	if err != nil {
+		l.Errorf("Failed to get ownership transfer id: %v", err)
+		return nil, fmt.Errorf("所有権移譲の依頼に失敗しました")
+	}
+	created, err := l.svcCtx.OrgTransfersModel.FindOne(l.ctx, uint64(transferId))
+	if err != nil {
+		l.Errorf("Failed to fetch ownership transfer %d: %v", transferId, err)
+		return nil, fmt.Errorf("所有権移譲の依頼に失敗しました")
+	}
+
+	notifyTransfer(l.ctx, l.svcCtx, access.org, created, newOwner)
+
+	l.Infof("User %d requested ownership transfer of org %d to user %d", access.userId, req.OrgId, newOwner.Id)
+	result := toOrgTransfer(created)
+	l.svcCtx.Audit.Record(l.ctx, audit.Entry{
+		Action:     audit.ActionTransferRequest,
+		OrgId:      req.OrgId,
+		TargetType: audit.TargetTransfer,
+		TargetId:   created.Id,
+		After:      result,
+	})
+	return &result, nil
+}
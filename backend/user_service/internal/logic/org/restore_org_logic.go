@@ -0,0 +1,97 @@
+package org
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"user_service/internal/audit"
+	"user_service/internal/model"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+type RestoreOrgLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewRestoreOrgLogic(ctx context.Context, svcCtx *svc.ServiceContext) *RestoreOrgLogic {
+	return &RestoreOrgLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *RestoreOrgLogic) RestoreOrg(req *types.GetOrgReq) (resp *types.Org, err error) {
+	userId, err := currentUserId(l.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	org, err := l.svcCtx.OrgsModel.FindOne(l.ctx, uint64(req.Id))
+	if err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			return nil, errOrgNotFound
+		}
+		l.Errorf("Failed to fetch organization %d: %v", req.Id, err)
+		return nil, fmt.Errorf("組織情報の取得に失敗しました")
+	}
+
+	// 削除済み組織の権限はメンバーシップから解決できないため、オーナーかどうかで判定する
+	if org.OwnerId != uint64(userId) {
+		isAdmin, err := l.svcCtx.OrgPermissions.IsSystemAdmin(l.ctx, uint64(userId))
+		if err != nil {
+			l.Errorf("Failed to check system admin of user %d: %v", userId, err)
+			return nil, fmt.Errorf("組織情報の取得に失敗しました")
+		}
+		if !isAdmin {
+			return nil, errOrgNotFound
+		}
+	}
+	if !org.DeletedAt.Valid {
+		return nil, fmt.Errorf("この組織は削除されていません")
+	}
+	if !restoreDeadline(l.svcCtx, org.DeletedAt.Time).After(time.Now()) {
+		return nil, fmt.Errorf("復元期間を過ぎているため復元できません")
+	}
+
+	// 削除中に同名の組織が作られていた場合は復元しない
+	existingOrg, err := l.svcCtx.OrgsModel.FindOneByName(l.ctx, org.Name)
+	switch {
+	case err == nil && existingOrg.Id != org.Id:
+		return nil, fmt.Errorf("同じ名前の組織が既に存在するため復元できません")
+	case err != nil && !errors.Is(err, sqlx.ErrNotFound):
+		l.Errorf("Failed to check organization name duplication: %v", err)
+		return nil, fmt.Errorf("組織の復元に失敗しました")
+	}
+
+	if err := l.svcCtx.OrgsModel.Restore(l.ctx, org.Id); err != nil {
+		l.Errorf("Failed to restore organization %d: %v", req.Id, err)
+		return nil, fmt.Errorf("組織の復元に失敗しました")
+	}
+
+	restored, err := l.svcCtx.OrgsModel.FindOne(l.ctx, org.Id)
+	if err != nil {
+		l.Errorf("Failed to fetch organization %d: %v", req.Id, err)
+		return nil, fmt.Errorf("組織情報の取得に失敗しました")
+	}
+
+	l.Infof("Organization %d restored by user %d", req.Id, userId)
+	l.svcCtx.Audit.Record(l.ctx, audit.Entry{
+		Action:     audit.ActionOrgRestore,
+		OrgId:      req.Id,
+		TargetType: audit.TargetOrg,
+		TargetId:   req.Id,
+		Before:     audit.SnapshotOrg(org),
+		After:      audit.SnapshotOrg(restored),
+	})
+	result := toOrg(restored)
+	return &result, nil
+}
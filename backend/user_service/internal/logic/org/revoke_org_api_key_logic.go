@@ -0,0 +1,68 @@
+package org
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"user_service/internal/audit"
+	"user_service/internal/model"
+	"user_service/internal/orgperm"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type RevokeOrgApiKeyLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewRevokeOrgApiKeyLogic(ctx context.Context, svcCtx *svc.ServiceContext) *RevokeOrgApiKeyLogic {
+	return &RevokeOrgApiKeyLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *RevokeOrgApiKeyLogic) RevokeOrgApiKey(req *types.OrgApiKeyReq) (resp *types.CommonRes, err error) {
+	access, err := loadOrgAccess(l.ctx, l.svcCtx, req.OrgId)
+	if err != nil {
+		return nil, err
+	}
+	if !access.can(orgperm.ApiKeysManage) {
+		return nil, errOrgForbidden
+	}
+
+	key, err := l.svcCtx.OrgApiKeysModel.FindOne(l.ctx, uint64(req.KeyId))
+	if err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			return nil, errApiKeyNotFound
+		}
+		l.Errorf("Failed to fetch API key %d: %v", req.KeyId, err)
+		return nil, fmt.Errorf("API キー情報の取得に失敗しました")
+	}
+	if key.OrgId != access.org.Id {
+		return nil, errApiKeyNotFound
+	}
+
+	ok, err := l.svcCtx.OrgApiKeysModel.Revoke(l.ctx, key)
+	if err != nil {
+		l.Errorf("Failed to revoke API key %d: %v", key.Id, err)
+		return nil, fmt.Errorf("API キーの失効に失敗しました")
+	}
+	if !ok {
+		return nil, fmt.Errorf("この API キーは既に失効しています")
+	}
+
+	l.Infof("API key %d of org %d revoked by user %d", key.Id, req.OrgId, access.userId)
+	recordStatusChange(l.ctx, l.svcCtx, audit.ActionApiKeyRevoke, key.OrgId, audit.TargetApiKey, key.Id,
+		"active", "revoked")
+	return &types.CommonRes{
+		Message: "API キーを失効しました",
+		Success: true,
+	}, nil
+}
@@ -0,0 +1,68 @@
+package org
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"user_service/internal/audit"
+	"user_service/internal/model"
+	"user_service/internal/orgperm"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type RevokeOrgInvitationLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewRevokeOrgInvitationLogic(ctx context.Context, svcCtx *svc.ServiceContext) *RevokeOrgInvitationLogic {
+	return &RevokeOrgInvitationLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *RevokeOrgInvitationLogic) RevokeOrgInvitation(req *types.OrgInvitationReq) (resp *types.CommonRes, err error) {
+	access, err := loadOrgAccess(l.ctx, l.svcCtx, req.OrgId)
+	if err != nil {
+		return nil, err
+	}
+	if !access.can(orgperm.InvitationsManage) {
+		return nil, errOrgForbidden
+	}
+
+	inv, err := l.svcCtx.OrgInvitationsModel.FindOne(l.ctx, uint64(req.InvitationId))
+	if err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			return nil, errInvitationNotFound
+		}
+		l.Errorf("Failed to fetch invitation %d: %v", req.InvitationId, err)
+		return nil, fmt.Errorf("招待情報の取得に失敗しました")
+	}
+	if inv.OrgId != access.org.Id {
+		return nil, errInvitationNotFound
+	}
+
+	ok, err := l.svcCtx.OrgInvitationsModel.Transition(l.ctx, inv, model.InvitationStatusRevoked)
+	if err != nil {
+		l.Errorf("Failed to revoke invitation %d: %v", inv.Id, err)
+		return nil, fmt.Errorf("招待の取り消しに失敗しました")
+	}
+	if !ok {
+		return nil, errInvitationUsed
+	}
+
+	l.Infof("Invitation %d to org %d revoked by user %d", inv.Id, req.OrgId, access.userId)
+	recordStatusChange(l.ctx, l.svcCtx, audit.ActionInvitationRevoke, inv.OrgId, audit.TargetInvitation, inv.Id,
+		model.InvitationStatusPending, model.InvitationStatusRevoked)
+	return &types.CommonRes{
+		Message: "招待を取り消しました",
+		Success: true,
+	}, nil
+}
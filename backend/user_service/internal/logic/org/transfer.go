@@ -0,0 +1,69 @@
+package org
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"user_service/internal/model"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+
+	"github.com/winyx/backend/common/notify"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+var (
+	errTransferNotFound = errors.New("未回答の所有権移譲依頼がありません")
+	errTransferExpired  = errors.New("所有権移譲依頼の有効期限が切れています")
+)
+
+func toOrgTransfer(transfer *model.OrgOwnershipTransfers) types.OrgTransfer {
+	return types.OrgTransfer{
+		Id:         int64(transfer.Id),
+		OrgId:      int64(transfer.OrgId),
+		FromUserId: int64(transfer.FromUserId),
+		ToUserId:   int64(transfer.ToUserId),
+		Status:     transfer.Status,
+		ExpiresAt:  transfer.ExpiresAt.Format("2006-01-02T15:04:05Z07:00"),
+		CreatedAt:  transfer.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
+
+// findPendingTransfer 組織の未回答の所有権移譲依頼を取得
+// 期限切れの依頼はその場で expired に更新し、存在しないものとして扱う
+func findPendingTransfer(ctx context.Context, svcCtx *svc.ServiceContext, orgId uint64) (*model.OrgOwnershipTransfers, error) {
+	transfer, err := svcCtx.OrgTransfersModel.FindPendingByOrgId(ctx, orgId)
+	if err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			return nil, errTransferNotFound
+		}
+		logx.WithContext(ctx).Errorf("Failed to fetch ownership transfer of org %d: %v", orgId, err)
+		return nil, fmt.Errorf("所有権移譲依頼の取得に失敗しました")
+	}
+
+	if !transfer.ExpiresAt.After(time.Now()) {
+		if _, err := svcCtx.OrgTransfersModel.Transition(ctx, transfer, model.TransferStatusExpired); err != nil {
+			logx.WithContext(ctx).Errorf("Failed to expire ownership transfer %d: %v", transfer.Id, err)
+		}
+		return nil, errTransferExpired
+	}
+
+	return transfer, nil
+}
+
+// notifyTransfer 移譲先ユーザーへ所有権移譲の依頼を通知する
+// 通知に失敗しても依頼自体は有効（移譲先は組織画面から確認できる）なため、エラーはログに残すのみ
+func notifyTransfer(ctx context.Context, svcCtx *svc.ServiceContext, org *model.Orgs, transfer *model.OrgOwnershipTransfers, to *model.Users) {
+	err := svcCtx.Notifier.Send(ctx, notify.Message{
+		To:      to.Email,
+		Subject: fmt.Sprintf("組織「%s」の所有権移譲の依頼", org.Name),
+		Body: fmt.Sprintf("組織「%s」の所有権の移譲を依頼されました。\n%s までに承諾または辞退してください。\n",
+			org.Name, transfer.ExpiresAt.Format("2006-01-02 15:04")),
+	})
+	if err != nil {
+		logx.WithContext(ctx).Errorf("Failed to notify ownership transfer %d to user %d: %v", transfer.Id, to.Id, err)
+	}
+}
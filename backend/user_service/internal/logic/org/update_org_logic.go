@@ -2,11 +2,19 @@ package org
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 
+	"user_service/internal/audit"
+	"user_service/internal/etag"
+	"user_service/internal/model"
+	"user_service/internal/orgperm"
 	"user_service/internal/svc"
 	"user_service/internal/types"
 
 	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
 )
 
 type UpdateOrgLogic struct {
@@ -24,7 +32,73 @@ func NewUpdateOrgLogic(ctx context.Context, svcCtx *svc.ServiceContext) *UpdateO
 }
 
 func (l *UpdateOrgLogic) UpdateOrg(req *types.UpdateOrgReq) (resp *types.Org, err error) {
-	// todo: add your logic here and delete this line
+	access, err := loadOrgAccess(l.ctx, l.svcCtx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if !access.can(orgperm.OrgUpdate) {
+		return nil, errOrgForbidden
+	}
+	if err := etag.Require(req.IfMatch); err != nil {
+		return nil, err
+	}
+	if !etag.Match(req.IfMatch, etag.Org(access.org)) {
+		return nil, orgConflict(access.org)
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if len(name) < 2 || len(name) > 100 {
+		return nil, fmt.Errorf("組織名は2〜100文字で入力してください")
+	}
+
+	// 組織名の重複チェック（自身は除く）
+	existingOrg, err := l.svcCtx.OrgsModel.FindOneByName(l.ctx, name)
+	switch {
+	case err == nil && existingOrg.Id != access.org.Id:
+		return nil, fmt.Errorf("この組織名は既に使用されています")
+	case err != nil && !errors.Is(err, sqlx.ErrNotFound):
+		l.Errorf("Failed to check organization name duplication: %v", err)
+		return nil, fmt.Errorf("システムエラーが発生しました")
+	}
+
+	before := audit.SnapshotOrg(access.org)
+	access.org.Name = name
+	err = l.svcCtx.OrgsModel.UpdateWithVersion(l.ctx, access.org)
+	switch {
+	case errors.Is(err, model.ErrVersionConflict):
+		// 読み込んでから更新するまでの間に他の更新が入った
+		current, err := l.svcCtx.OrgsModel.FindOne(l.ctx, access.org.Id)
+		if err != nil {
+			l.Errorf("Failed to retrieve organization %d: %v", req.Id, err)
+			return nil, fmt.Errorf("組織の更新に失敗しました")
+		}
+		return nil, orgConflict(current)
+	case err != nil:
+		l.Errorf("Failed to update organization %d: %v", req.Id, err)
+		return nil, fmt.Errorf("組織の更新に失敗しました")
+	}
+
+	updatedOrg, err := l.svcCtx.OrgsModel.FindOne(l.ctx, access.org.Id)
+	if err != nil {
+		l.Errorf("Failed to retrieve updated organization %d: %v", req.Id, err)
+		return nil, fmt.Errorf("組織の更新に失敗しました")
+	}
+
+	l.Infof("Organization %d updated by user %d", req.Id, access.userId)
+	l.svcCtx.Audit.Record(l.ctx, audit.Entry{
+		Action:     audit.ActionOrgUpdate,
+		OrgId:      req.Id,
+		TargetType: audit.TargetOrg,
+		TargetId:   req.Id,
+		Before:     before,
+		After:      audit.SnapshotOrg(updatedOrg),
+	})
+	org := toOrg(updatedOrg)
+	return &org, nil
+}
 
-	return
+// orgConflict 最新の組織を 412 のボディとして返すエラー
+func orgConflict(current *model.Orgs) error {
+	org := toOrg(current)
+	return &etag.PreconditionFailedError{ETag: org.Etag, Current: &org}
 }
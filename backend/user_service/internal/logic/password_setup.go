@@ -0,0 +1,75 @@
+package logic
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"user_service/internal/model"
+	"user_service/internal/svc"
+
+	"github.com/winyx/backend/common/notify"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// パスワード設定トークンの Redis キー（値はユーザー ID）
+// トークンそのものではなく SHA-256 をキーにし、Redis の内容からリンクを復元できないようにする
+const passwordSetupKeyPrefix = "winyx:user_service:password_setup:"
+
+// パスワード設定トークンのランダムバイト長
+const passwordSetupTokenBytes = 32
+
+func passwordSetupKey(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return passwordSetupKeyPrefix + hex.EncodeToString(sum[:])
+}
+
+// unusablePassword 一括登録したユーザーの仮のパスワードハッシュ
+// 元の値は誰にも渡さないため、パスワードを設定するまでログインできない
+func unusablePassword() (string, error) {
+	buf := make([]byte, passwordSetupTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	// 推測できない値のため、ハッシュのコストは最小にして大量登録を速くする
+	hashed, err := bcrypt.GenerateFromPassword([]byte(base64.RawURLEncoding.EncodeToString(buf)), bcrypt.MinCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hashed), nil
+}
+
+// sendPasswordSetup パスワード設定用のトークンを発行し、設定画面へのリンクをユーザーに送る
+func sendPasswordSetup(ctx context.Context, svcCtx *svc.ServiceContext, user *model.Users) error {
+	buf := make([]byte, passwordSetupTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return err
+	}
+	token := base64.RawURLEncoding.EncodeToString(buf)
+
+	expire := time.Duration(svcCtx.Config.Import.SetupExpireHours) * time.Hour
+	if err := svcCtx.Redis.SetexCtx(ctx, passwordSetupKey(token), fmt.Sprint(user.Id), int(expire.Seconds())); err != nil {
+		return err
+	}
+
+	link := svcCtx.Config.Import.SetupUrl
+	if strings.Contains(link, "?") {
+		link += "&token=" + url.QueryEscape(token)
+	} else {
+		link += "?token=" + url.QueryEscape(token)
+	}
+
+	return svcCtx.Notifier.Send(ctx, notify.Message{
+		To:      user.Email,
+		Subject: "アカウントが作成されました",
+		Body: fmt.Sprintf("%s 様\nアカウントが作成されました。\n以下のリンクからパスワードを設定してください（有効期限: %s）。\n%s\n",
+			user.Name, time.Now().Add(expire).Format("2006-01-02 15:04"), link),
+	})
+}
@@ -0,0 +1,100 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"user_service/internal/dataloader"
+	"user_service/internal/etag"
+	"user_service/internal/model"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+
+	"github.com/winyx/backend/common/auth"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type PatchProfileLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewPatchProfileLogic(ctx context.Context, svcCtx *svc.ServiceContext) *PatchProfileLogic {
+	return &PatchProfileLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// PatchProfile ログイン中のユーザーのプロフィールを部分更新する（未作成の場合は作成する）
+func (l *PatchProfileLogic) PatchProfile(req *types.ProfilePatchReq, patch *ProfilePatch) (resp *types.ProfileRes, err error) {
+	userId, ok := auth.UserIdFromContext(l.ctx)
+	if !ok {
+		return nil, fmt.Errorf("認証エラー: ユーザーIDが取得できません")
+	}
+	if err := patch.validate(); err != nil {
+		return nil, err
+	}
+
+	var profile *model.UserProfiles
+	err = l.svcCtx.UnitOfWork.Do(l.ctx, func(ctx context.Context, tx *model.Tx) error {
+		if _, err := l.svcCtx.UsersModel.LockTx(ctx, tx, uint64(userId)); err != nil {
+			return err
+		}
+		locked, err := l.svcCtx.UserProfilesModel.LockByUserIdTx(ctx, tx, uint64(userId))
+		switch {
+		case err == nil:
+			profile = locked
+		case !errors.Is(err, model.ErrNotFound):
+			return err
+		}
+
+		// If-Match は任意。指定された場合はまだプロフィールが無ければ一致しない
+		if req.IfMatch != "" && (profile == nil || !etag.Match(req.IfMatch, etag.Profile(profile))) {
+			return model.ErrVersionConflict
+		}
+
+		if profile == nil {
+			profile = &model.UserProfiles{
+				UserId:      uint64(userId),
+				SocialLinks: "null",
+				Preferences: "null",
+			}
+		} else if patch.empty() {
+			return nil
+		}
+		patch.apply(profile)
+		return l.svcCtx.UserProfilesModel.UpsertTx(ctx, tx, profile)
+	})
+	switch {
+	case err == nil:
+	case errors.Is(err, model.ErrVersionConflict):
+		return nil, profileConflict(l.ctx, l.svcCtx, userId)
+	default:
+		l.Errorf("Failed to patch profile of user %d: %v", userId, err)
+		return nil, err
+	}
+
+	return &types.ProfileRes{
+		Profile: *dataloader.ProfileData(profile),
+		Etag:    etag.Profile(profile),
+	}, nil
+}
+
+// profileConflict 最新のプロフィールを 412 のボディとして返すエラー
+func profileConflict(ctx context.Context, svcCtx *svc.ServiceContext, userId int64) error {
+	current, err := svcCtx.UserProfilesModel.FindOneByUserId(ctx, uint64(userId))
+	switch {
+	case errors.Is(err, model.ErrNotFound):
+		return &etag.PreconditionFailedError{Current: &types.ProfileRes{}}
+	case err != nil:
+		return err
+	}
+
+	tag := etag.Profile(current)
+	return &etag.PreconditionFailedError{ETag: tag, Current: &types.ProfileRes{Profile: *dataloader.ProfileData(current), Etag: tag}}
+}
@@ -0,0 +1,153 @@
+package privacy
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"user_service/internal/audit"
+	"user_service/internal/model"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ApproveErasureRequestLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewApproveErasureRequestLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ApproveErasureRequestLogic {
+	return &ApproveErasureRequestLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// ApproveErasureRequest 消去リクエストを承認し、ユーザーの個人データを匿名化する
+// ユーザーの行・監査ログは残し、プロフィール・ロール・メンバーシップ・セッション履歴は削除する。
+// 完了記録とその SHA-256 をリクエストに保存し、ハッシュを監査ログにも記録する
+func (l *ApproveErasureRequestLogic) ApproveErasureRequest(req *types.ErasureReviewReq) (resp *types.ErasureRequest, err error) {
+	operatorId, err := requireSystemAdmin(l.ctx, l.svcCtx)
+	if err != nil {
+		return nil, err
+	}
+	note, err := reviewNote(req.Note)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := findErasureRequest(l.ctx, l.svcCtx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if request.Status != model.ErasureStatusPending {
+		return nil, errErasureNotPending
+	}
+	// 管理者が自分の消去を承認できると、リクエストと承認を 1 人で完結できてしまう
+	if request.UserId == uint64(operatorId) {
+		return nil, fmt.Errorf("自分の消去リクエストは承認できません")
+	}
+	before := snapshotErasure(request)
+
+	user, err := l.svcCtx.UsersModel.FindOne(l.ctx, request.UserId)
+	switch {
+	case errors.Is(err, model.ErrNotFound):
+		return nil, fmt.Errorf("対象のユーザーが見つかりません")
+	case err != nil:
+		l.Errorf("Failed to fetch user %d: %v", request.UserId, err)
+		return nil, fmt.Errorf("消去リクエストの承認に失敗しました")
+	}
+	exports, err := l.svcCtx.DataExportsModel.FindByUserId(l.ctx, user.Id)
+	if err != nil {
+		l.Errorf("Failed to fetch data exports of user %d: %v", user.Id, err)
+		return nil, fmt.Errorf("消去リクエストの承認に失敗しました")
+	}
+	var avatarUrl string
+	profile, err := l.svcCtx.UserProfilesModel.FindOneByUserId(l.ctx, user.Id)
+	switch {
+	case err == nil:
+		avatarUrl = profile.AvatarUrl
+	case !errors.Is(err, model.ErrNotFound):
+		l.Errorf("Failed to fetch profile of user %d: %v", user.Id, err)
+		return nil, fmt.Errorf("消去リクエストの承認に失敗しました")
+	}
+
+	now := time.Now()
+	request.ReviewedBy = uint64(operatorId)
+	request.ReviewNote = note
+	request.ReviewedAt = sql.NullTime{Time: now, Valid: true}
+	request.CompletedAt = sql.NullTime{Time: now, Valid: true}
+	if err := issueCertificate(request, uint64(operatorId), now); err != nil {
+		l.Errorf("Failed to issue certificate of erasure request %d: %v", request.Id, err)
+		return nil, fmt.Errorf("消去リクエストの承認に失敗しました")
+	}
+
+	err = l.svcCtx.UnitOfWork.Do(l.ctx, func(ctx context.Context, tx *model.Tx) error {
+		if err := l.svcCtx.UsersModel.AnonymizeTx(ctx, tx, user.Id); err != nil {
+			return err
+		}
+		if err := l.svcCtx.OrgMembersModel.DeleteByUserIdTx(ctx, tx, user.Id); err != nil {
+			return err
+		}
+		if err := l.svcCtx.UserRolesModel.DeleteByUserIdTx(ctx, tx, int64(user.Id)); err != nil {
+			return err
+		}
+		if err := l.svcCtx.UserProfilesModel.DeleteByUserIdTx(ctx, tx, user.Id); err != nil {
+			return err
+		}
+		ok, err := l.svcCtx.ErasureRequestsModel.CompleteTx(ctx, tx, request)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errErasureNotPending
+		}
+		return nil
+	})
+	switch {
+	case err == nil:
+	case errors.Is(err, errErasureNotPending):
+		return nil, err
+	case errors.Is(err, model.ErrNotFound):
+		return nil, fmt.Errorf("対象のユーザーが見つかりません")
+	case errors.Is(err, model.ErrUserOwnsOrgs):
+		return nil, fmt.Errorf("組織のオーナーであるユーザーは消去できません。所有権を移譲するか組織を削除してから再度実行してください")
+	default:
+		l.Errorf("Failed to erase user %d: %v", user.Id, err)
+		return nil, fmt.Errorf("消去リクエストの承認に失敗しました")
+	}
+
+	// 作成済みのアーカイブも個人データのため、期限を待たずに消す
+	for _, export := range exports {
+		if _, err := l.svcCtx.Redis.DelCtx(l.ctx, dataExportKey(export.Id)); err != nil {
+			l.Errorf("Failed to delete archive of data export %d: %v", export.Id, err)
+		}
+	}
+
+	if avatarUrl != "" {
+		deleteAvatar(l.ctx, l.svcCtx, avatarUrl)
+	}
+
+	l.Infof("Erasure request %d approved by admin %d; user %d anonymized (certificate %s)",
+		request.Id, operatorId, user.Id, request.CertificateHash)
+	l.svcCtx.Audit.Record(l.ctx, audit.Entry{
+		Action:     audit.ActionErasureComplete,
+		TargetType: audit.TargetErasure,
+		TargetId:   request.Id,
+		Before:     before,
+		After:      snapshotErasure(request),
+	})
+
+	// 匿名化後は連絡先が残らないため、消去前のメールアドレスに完了を知らせる
+	notifyErasure(l.ctx, l.svcCtx, user.Email, "個人データの消去が完了しました",
+		fmt.Sprintf("%s 様\n個人データの消去が完了しました。\n完了記録のハッシュ（SHA-256）: %s\n", user.Name, request.CertificateHash))
+
+	completed := toErasureRequest(request)
+	return &completed, nil
+}
@@ -0,0 +1,66 @@
+package privacy
+
+import (
+	"context"
+	"fmt"
+
+	"user_service/internal/audit"
+	"user_service/internal/model"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type CancelErasureRequestLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewCancelErasureRequestLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CancelErasureRequestLogic {
+	return &CancelErasureRequestLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// CancelErasureRequest 承認前の本人の消去リクエストを取り消す
+func (l *CancelErasureRequestLogic) CancelErasureRequest(req *types.ErasureRequestIdReq) (resp *types.ErasureRequest, err error) {
+	userId, err := currentUserId(l.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := findErasureRequest(l.ctx, l.svcCtx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if request.UserId != uint64(userId) {
+		return nil, errErasureNotFound
+	}
+	before := snapshotErasure(request)
+
+	request.Status = model.ErasureStatusCancelled
+	ok, err := l.svcCtx.ErasureRequestsModel.Close(l.ctx, request)
+	if err != nil {
+		l.Errorf("Failed to cancel erasure request %d: %v", request.Id, err)
+		return nil, fmt.Errorf("消去リクエストの取り消しに失敗しました")
+	}
+	if !ok {
+		return nil, errErasureNotPending
+	}
+
+	l.Infof("Erasure request %d cancelled by user %d", request.Id, userId)
+	l.svcCtx.Audit.Record(l.ctx, audit.Entry{
+		Action:     audit.ActionErasureCancel,
+		TargetType: audit.TargetErasure,
+		TargetId:   request.Id,
+		Before:     before,
+		After:      snapshotErasure(request),
+	})
+
+	cancelled := toErasureRequest(request)
+	return &cancelled, nil
+}
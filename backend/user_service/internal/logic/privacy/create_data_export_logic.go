@@ -0,0 +1,93 @@
+package privacy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"user_service/internal/audit"
+	"user_service/internal/model"
+	"user_service/internal/personaldata"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/threading"
+)
+
+type CreateDataExportLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewCreateDataExportLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CreateDataExportLogic {
+	return &CreateDataExportLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// CreateDataExport 本人の個人データのエクスポートを作成し、アーカイブの作成をバックグラウンドで始める
+// 作成待ち・作成中のエクスポートがある場合は新しく作らずにそれを返す
+func (l *CreateDataExportLogic) CreateDataExport(req *types.DataExportReq) (resp *types.DataExport, err error) {
+	userId, err := currentUserId(l.ctx)
+	if err != nil {
+		return nil, err
+	}
+	format, err := personaldata.ParseFormat(req.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	active, err := l.svcCtx.DataExportsModel.FindActiveByUserId(l.ctx, uint64(userId))
+	switch {
+	case err == nil:
+		return toDataExport(active), nil
+	case !errors.Is(err, model.ErrNotFound):
+		l.Errorf("Failed to fetch active data export of user %d: %v", userId, err)
+		return nil, fmt.Errorf("エクスポートの開始に失敗しました")
+	}
+
+	export := &model.UserDataExports{
+		UserId: uint64(userId),
+		Status: model.ExportStatusPending,
+		Format: string(format),
+	}
+	result, err := l.svcCtx.DataExportsModel.Insert(l.ctx, export)
+	if err != nil {
+		l.Errorf("Failed to create data export of user %d: %v", userId, err)
+		return nil, fmt.Errorf("エクスポートの開始に失敗しました")
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		l.Errorf("Failed to get data export id: %v", err)
+		return nil, fmt.Errorf("エクスポートの開始に失敗しました")
+	}
+	if export, err = l.svcCtx.DataExportsModel.FindOne(l.ctx, uint64(id)); err != nil {
+		l.Errorf("Failed to fetch data export %d: %v", id, err)
+		return nil, fmt.Errorf("エクスポートの開始に失敗しました")
+	}
+
+	l.Infof("Data export %d created by user %d (%s)", export.Id, userId, format)
+	l.svcCtx.Audit.Record(l.ctx, audit.Entry{
+		Action:     audit.ActionDataExport,
+		TargetType: audit.TargetDataExport,
+		TargetId:   export.Id,
+		After:      dataExportSnapshot{UserId: export.UserId, Format: export.Format},
+	})
+
+	ctx := context.WithoutCancel(l.ctx)
+	threading.GoSafe(func() {
+		newDataExport(ctx, l.svcCtx, export).run()
+	})
+
+	return toDataExport(export), nil
+}
+
+// dataExportSnapshot 監査ログに記録するエクスポートの内容
+type dataExportSnapshot struct {
+	UserId uint64 `json:"user_id"`
+	Format string `json:"format"`
+}
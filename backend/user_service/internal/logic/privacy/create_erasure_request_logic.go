@@ -0,0 +1,111 @@
+package privacy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"user_service/internal/audit"
+	"user_service/internal/model"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// 消去の理由の最大文字数（user_erasure_requests.reason）
+const maxErasureReasonLength = 500
+
+type CreateErasureRequestLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewCreateErasureRequestLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CreateErasureRequestLogic {
+	return &CreateErasureRequestLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// CreateErasureRequest 本人の個人データの消去をリクエストする
+// トークンの盗用による消去を防ぐためパスワードを再確認し、承認待ちのリクエストは 1 人 1 件までとする
+func (l *CreateErasureRequestLogic) CreateErasureRequest(req *types.ErasureRequestReq) (resp *types.ErasureRequest, err error) {
+	userId, err := currentUserId(l.ctx)
+	if err != nil {
+		return nil, err
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if utf8.RuneCountInString(reason) > maxErasureReasonLength {
+		return nil, fmt.Errorf("理由は %d 文字以内で入力してください", maxErasureReasonLength)
+	}
+
+	user, err := l.svcCtx.UsersModel.FindOne(l.ctx, uint64(userId))
+	switch {
+	case errors.Is(err, model.ErrNotFound):
+		return nil, fmt.Errorf("ユーザーが見つかりません")
+	case err != nil:
+		l.Errorf("Failed to fetch user %d: %v", userId, err)
+		return nil, fmt.Errorf("消去リクエストの作成に失敗しました")
+	}
+	if bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)) != nil {
+		return nil, fmt.Errorf("パスワードが正しくありません")
+	}
+
+	_, err = l.svcCtx.ErasureRequestsModel.FindPendingByUserId(l.ctx, user.Id)
+	switch {
+	case err == nil:
+		return nil, fmt.Errorf("承認待ちの消去リクエストがあります")
+	case !errors.Is(err, model.ErrNotFound):
+		l.Errorf("Failed to fetch pending erasure request of user %d: %v", userId, err)
+		return nil, fmt.Errorf("消去リクエストの作成に失敗しました")
+	}
+
+	// 承認時にも確認するが、オーナーのままでは承認できないため先に知らせる
+	orgs, err := l.svcCtx.OrgsModel.FindByOwnerId(l.ctx, user.Id)
+	if err != nil {
+		l.Errorf("Failed to fetch orgs owned by user %d: %v", userId, err)
+		return nil, fmt.Errorf("消去リクエストの作成に失敗しました")
+	}
+	for _, org := range orgs {
+		if !org.DeletedAt.Valid {
+			return nil, fmt.Errorf("組織のオーナーは消去をリクエストできません。所有権を移譲するか組織を削除してから再度実行してください")
+		}
+	}
+
+	request := &model.UserErasureRequests{
+		UserId: user.Id,
+		Status: model.ErasureStatusPending,
+		Reason: reason,
+	}
+	result, err := l.svcCtx.ErasureRequestsModel.Insert(l.ctx, request)
+	if err != nil {
+		l.Errorf("Failed to create erasure request of user %d: %v", userId, err)
+		return nil, fmt.Errorf("消去リクエストの作成に失敗しました")
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		l.Errorf("Failed to get erasure request id: %v", err)
+		return nil, fmt.Errorf("消去リクエストの作成に失敗しました")
+	}
+	if request, err = l.svcCtx.ErasureRequestsModel.FindOne(l.ctx, uint64(id)); err != nil {
+		l.Errorf("Failed to fetch erasure request %d: %v", id, err)
+		return nil, fmt.Errorf("消去リクエストの作成に失敗しました")
+	}
+
+	l.Infof("Erasure request %d created by user %d", request.Id, userId)
+	l.svcCtx.Audit.Record(l.ctx, audit.Entry{
+		Action:     audit.ActionErasureRequest,
+		TargetType: audit.TargetErasure,
+		TargetId:   request.Id,
+		After:      snapshotErasure(request),
+	})
+
+	created := toErasureRequest(request)
+	return &created, nil
+}
@@ -0,0 +1,266 @@
+package privacy
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"user_service/internal/model"
+	"user_service/internal/personaldata"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// アーカイブ本体の Redis キー（値はアーカイブのバイト列）
+// 個人データを長く残さないよう、MySQL には置かず期限付きで保存する
+const dataExportKeyPrefix = "winyx:user_service:data_export:"
+
+var errDataExportNotFound = errors.New("データエクスポートが見つかりません")
+
+func dataExportKey(id uint64) string {
+	return fmt.Sprintf("%s%d", dataExportKeyPrefix, id)
+}
+
+// findOwnDataExport 操作ユーザー本人のエクスポートを取得（他人のものは存在しないものとして扱う）
+func findOwnDataExport(ctx context.Context, svcCtx *svc.ServiceContext, userId, id int64) (*model.UserDataExports, error) {
+	if id <= 0 {
+		return nil, errDataExportNotFound
+	}
+	export, err := svcCtx.DataExportsModel.FindOne(ctx, uint64(id))
+	switch {
+	case errors.Is(err, model.ErrNotFound):
+		return nil, errDataExportNotFound
+	case err != nil:
+		logx.WithContext(ctx).Errorf("Failed to fetch data export %d: %v", id, err)
+		return nil, fmt.Errorf("データエクスポートの取得に失敗しました")
+	}
+	if export.UserId != uint64(userId) {
+		return nil, errDataExportNotFound
+	}
+	return export, nil
+}
+
+func toDataExport(export *model.UserDataExports) *types.DataExport {
+	resp := &types.DataExport{
+		Id:        int64(export.Id),
+		Status:    export.Status,
+		Format:    export.Format,
+		Size:      int64(export.Size),
+		Sha256:    export.Sha256,
+		Error:     export.Error,
+		CreatedAt: export.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if export.ExpiresAt.Valid {
+		resp.ExpiresAt = export.ExpiresAt.Time.Format("2006-01-02T15:04:05Z07:00")
+	}
+	if export.StartedAt.Valid {
+		resp.StartedAt = export.StartedAt.Time.Format("2006-01-02T15:04:05Z07:00")
+	}
+	if export.FinishedAt.Valid {
+		resp.FinishedAt = export.FinishedAt.Time.Format("2006-01-02T15:04:05Z07:00")
+	}
+	return resp
+}
+
+// dataExport 1 つのエクスポートジョブの実行
+type dataExport struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	export *model.UserDataExports
+}
+
+func newDataExport(ctx context.Context, svcCtx *svc.ServiceContext, export *model.UserDataExports) *dataExport {
+	return &dataExport{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		export: export,
+	}
+}
+
+func (d *dataExport) run() {
+	started, err := d.svcCtx.DataExportsModel.Start(d.ctx, d.export)
+	if err != nil {
+		d.Errorf("Failed to start data export %d: %v", d.export.Id, err)
+		return
+	}
+	if !started {
+		return
+	}
+
+	if err := d.execute(); err != nil {
+		d.Errorf("Data export %d failed: %v", d.export.Id, err)
+		d.export.Status = model.ExportStatusFailed
+		d.export.Error = "エクスポート中にエラーが発生しました"
+	} else {
+		d.export.Status = model.ExportStatusCompleted
+	}
+	d.export.FinishedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	if err := d.svcCtx.DataExportsModel.Update(d.ctx, d.export); err != nil {
+		d.Errorf("Failed to save data export %d: %v", d.export.Id, err)
+		return
+	}
+
+	d.Infof("Data export %d of user %d %s (%d bytes)", d.export.Id, d.export.UserId, d.export.Status, d.export.Size)
+}
+
+// execute アーカイブを作成して Redis に保存し、サイズ・ハッシュ・期限をジョブに設定する
+func (d *dataExport) execute() error {
+	archive, err := buildArchive(d.ctx, d.svcCtx, d.export.UserId)
+	if err != nil {
+		return err
+	}
+	data, err := archive.Encode(personaldata.Format(d.export.Format))
+	if err != nil {
+		return fmt.Errorf("encode archive: %w", err)
+	}
+
+	expire := time.Duration(d.svcCtx.Config.Privacy.ExportExpireHours) * time.Hour
+	if err := d.svcCtx.Redis.SetexCtx(d.ctx, dataExportKey(d.export.Id), string(data), int(expire.Seconds())); err != nil {
+		return fmt.Errorf("store archive: %w", err)
+	}
+
+	sum := sha256.Sum256(data)
+	d.export.Size = uint64(len(data))
+	d.export.Sha256 = hex.EncodeToString(sum[:])
+	d.export.ExpiresAt = sql.NullTime{Time: time.Now().Add(expire), Valid: true}
+	return nil
+}
+
+// buildArchive ユーザーの個人データを読み込んでアーカイブにまとめる
+func buildArchive(ctx context.Context, svcCtx *svc.ServiceContext, userId uint64) (*personaldata.Archive, error) {
+	user, err := svcCtx.UsersModel.FindOne(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("load user: %w", err)
+	}
+	archive := &personaldata.Archive{
+		GeneratedAt: time.Now().Format(time.RFC3339),
+		Account: personaldata.Account{
+			Id:        user.Id,
+			Name:      user.Name,
+			Email:     user.Email,
+			Status:    user.Status,
+			CreatedAt: user.CreatedAt.Format(time.RFC3339),
+			UpdatedAt: user.UpdatedAt.Format(time.RFC3339),
+		},
+		Roles:          []personaldata.Role{},
+		OrgMemberships: []personaldata.OrgMembership{},
+		SessionHistory: []personaldata.Session{},
+	}
+	if user.LastLoginAt.Valid {
+		archive.Account.LastLoginAt = user.LastLoginAt.Time.Format(time.RFC3339)
+	}
+
+	profile, err := svcCtx.UserProfilesModel.FindOneByUserId(ctx, userId)
+	switch {
+	case errors.Is(err, model.ErrNotFound):
+	case err != nil:
+		return nil, fmt.Errorf("load profile: %w", err)
+	default:
+		archive.Profile = toArchiveProfile(profile)
+	}
+
+	roles, err := svcCtx.UserRolesModel.FindByUserIdWithRole(ctx, int64(userId))
+	if err != nil {
+		return nil, fmt.Errorf("load roles: %w", err)
+	}
+	for _, role := range roles {
+		archive.Roles = append(archive.Roles, personaldata.Role{
+			Name:       role.RoleName,
+			AssignedAt: role.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	if archive.OrgMemberships, err = loadMemberships(ctx, svcCtx, userId); err != nil {
+		return nil, err
+	}
+
+	sessions, err := svcCtx.UserSessionsModel.FindByUserId(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("load sessions: %w", err)
+	}
+	for _, session := range sessions {
+		entry := personaldata.Session{
+			IpAddress: session.IpAddress.String,
+			UserAgent: session.UserAgent.String,
+			LoginAt:   session.LoginAt.Format(time.RFC3339),
+			ExpiresAt: session.ExpiresAt.Format(time.RFC3339),
+			Status:    session.Status,
+		}
+		if session.LogoutAt.Valid {
+			entry.LogoutAt = session.LogoutAt.Time.Format(time.RFC3339)
+		}
+		archive.SessionHistory = append(archive.SessionHistory, entry)
+	}
+
+	return archive, nil
+}
+
+func toArchiveProfile(profile *model.UserProfiles) *personaldata.Profile {
+	result := &personaldata.Profile{
+		AvatarUrl:   profile.AvatarUrl,
+		Bio:         profile.Bio,
+		Phone:       profile.Phone,
+		Address:     profile.Address,
+		Gender:      profile.Gender,
+		Occupation:  profile.Occupation,
+		Website:     profile.Website,
+		SocialLinks: rawJSON(profile.SocialLinks),
+		Preferences: rawJSON(profile.Preferences),
+		CreatedAt:   profile.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   profile.UpdatedAt.Format(time.RFC3339),
+	}
+	if profile.BirthDate.Valid {
+		result.BirthDate = profile.BirthDate.Time.Format("2006-01-02")
+	}
+	return result
+}
+
+// rawJSON JSON 列の値をそのまま埋め込む（空・null・不正な値は省略する）
+func rawJSON(value string) json.RawMessage {
+	if value == "" || value == "null" || !json.Valid([]byte(value)) {
+		return nil
+	}
+	return json.RawMessage(value)
+}
+
+// loadMemberships 所属組織と組織内ロールの名前を読み込む
+func loadMemberships(ctx context.Context, svcCtx *svc.ServiceContext, userId uint64) ([]personaldata.OrgMembership, error) {
+	members, err := svcCtx.OrgMembersModel.FindByUserId(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("load memberships: %w", err)
+	}
+
+	memberships := make([]personaldata.OrgMembership, 0, len(members))
+	roleNames := make(map[int64]string)
+	for _, member := range members {
+		org, err := svcCtx.OrgsModel.FindOne(ctx, member.OrgId)
+		if err != nil {
+			return nil, fmt.Errorf("load org %d: %w", member.OrgId, err)
+		}
+		name, ok := roleNames[member.RoleId]
+		if !ok {
+			role, err := svcCtx.OrgRolesModel.FindOne(ctx, member.RoleId)
+			if err != nil {
+				return nil, fmt.Errorf("load org role %d: %w", member.RoleId, err)
+			}
+			name = role.Name
+			roleNames[member.RoleId] = name
+		}
+		memberships = append(memberships, personaldata.OrgMembership{
+			OrgId:    org.Id,
+			OrgName:  org.Name,
+			Role:     name,
+			JoinedAt: member.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	return memberships, nil
+}
@@ -0,0 +1,75 @@
+package privacy
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"user_service/internal/model"
+	"user_service/internal/personaldata"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type DownloadDataExportLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewDownloadDataExportLogic(ctx context.Context, svcCtx *svc.ServiceContext) *DownloadDataExportLogic {
+	return &DownloadDataExportLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// DataExportFile ダウンロードするアーカイブ
+type DataExportFile struct {
+	Format personaldata.Format
+	Sha256 string
+	Data   []byte
+}
+
+// FileName ダウンロードのファイル名
+func (f *DataExportFile) FileName(id int64) string {
+	return fmt.Sprintf("personal-data-%d.%s", id, f.Format.Extension())
+}
+
+// DownloadDataExport 完成した本人のアーカイブを返す
+func (l *DownloadDataExportLogic) DownloadDataExport(req *types.DataExportIdReq) (*DataExportFile, error) {
+	userId, err := currentUserId(l.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	export, err := findOwnDataExport(l.ctx, l.svcCtx, userId, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if export.Status != model.ExportStatusCompleted {
+		return nil, fmt.Errorf("エクスポートはまだ完了していません")
+	}
+	if !export.ExpiresAt.Valid || !export.ExpiresAt.Time.After(time.Now()) {
+		return nil, fmt.Errorf("ダウンロードの期限が切れています。もう一度エクスポートしてください")
+	}
+
+	// 期限は Redis の TTL と同じため、ここで見つからない場合も期限切れとして扱う
+	data, err := l.svcCtx.Redis.GetCtx(l.ctx, dataExportKey(export.Id))
+	if err != nil {
+		l.Errorf("Failed to load archive of data export %d: %v", export.Id, err)
+		return nil, fmt.Errorf("アーカイブの取得に失敗しました")
+	}
+	if data == "" {
+		return nil, fmt.Errorf("ダウンロードの期限が切れています。もう一度エクスポートしてください")
+	}
+
+	return &DataExportFile{
+		Format: personaldata.Format(export.Format),
+		Sha256: export.Sha256,
+		Data:   []byte(data),
+	}, nil
+}
@@ -0,0 +1,167 @@
+package privacy
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"user_service/internal/avatar"
+	"user_service/internal/model"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+
+	"github.com/winyx/backend/common/notify"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+var (
+	errErasureNotFound   = errors.New("消去リクエストが見つかりません")
+	errErasureNotPending = errors.New("承認待ちの消去リクエストではありません")
+)
+
+// 匿名化・削除する個人データ（完了記録に載せる）
+var erasedData = []string{
+	"users.name", "users.email", "users.password", "users.last_login_at",
+	"user_profiles", "user_roles", "org_members", "user_session_history", "password_resets",
+	"user_data_exports.archive", "user_erasure_requests.reason", "org_invitations.email",
+}
+
+// 消去後も残すデータ（完了記録に載せる）
+// 監査ログには氏名・メールアドレスを鍵付きハッシュでのみ記録しているため、消去後も残す
+var retainedData = []string{"users.id", "audit_logs"}
+
+func toErasureRequest(request *model.UserErasureRequests) types.ErasureRequest {
+	result := types.ErasureRequest{
+		Id:              int64(request.Id),
+		UserId:          int64(request.UserId),
+		Status:          request.Status,
+		Reason:          request.Reason,
+		ReviewedBy:      int64(request.ReviewedBy),
+		ReviewNote:      request.ReviewNote,
+		Certificate:     request.Certificate,
+		CertificateHash: request.CertificateHash,
+		CreatedAt:       request.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if request.ReviewedAt.Valid {
+		result.ReviewedAt = request.ReviewedAt.Time.Format("2006-01-02T15:04:05Z07:00")
+	}
+	if request.CompletedAt.Valid {
+		result.CompletedAt = request.CompletedAt.Time.Format("2006-01-02T15:04:05Z07:00")
+	}
+	return result
+}
+
+func toErasureRequests(requests []*model.UserErasureRequests) []types.ErasureRequest {
+	result := make([]types.ErasureRequest, 0, len(requests))
+	for _, request := range requests {
+		result = append(result, toErasureRequest(request))
+	}
+	return result
+}
+
+// findErasureRequest 消去リクエストを取得
+func findErasureRequest(ctx context.Context, svcCtx *svc.ServiceContext, id int64) (*model.UserErasureRequests, error) {
+	if id <= 0 {
+		return nil, errErasureNotFound
+	}
+	request, err := svcCtx.ErasureRequestsModel.FindOne(ctx, uint64(id))
+	switch {
+	case errors.Is(err, model.ErrNotFound):
+		return nil, errErasureNotFound
+	case err != nil:
+		logx.WithContext(ctx).Errorf("Failed to fetch erasure request %d: %v", id, err)
+		return nil, fmt.Errorf("消去リクエストの取得に失敗しました")
+	}
+	return request, nil
+}
+
+// erasureSnapshot 監査ログに記録する消去リクエストの項目（理由などの本人の入力は含めない）
+type erasureSnapshot struct {
+	UserId          uint64 `json:"user_id"`
+	Status          string `json:"status"`
+	ReviewedBy      uint64 `json:"reviewed_by,omitempty"`
+	CertificateHash string `json:"certificate_hash,omitempty"`
+}
+
+func snapshotErasure(request *model.UserErasureRequests) erasureSnapshot {
+	return erasureSnapshot{
+		UserId:          request.UserId,
+		Status:          request.Status,
+		ReviewedBy:      request.ReviewedBy,
+		CertificateHash: request.CertificateHash,
+	}
+}
+
+// erasureCertificate 消去の完了記録
+// 個人データそのものは含めず、誰の・いつの・どのリクエストで何を消したかだけを残す
+type erasureCertificate struct {
+	RequestId   uint64   `json:"request_id"`
+	UserId      uint64   `json:"user_id"`
+	RequestedAt string   `json:"requested_at"`
+	ApprovedBy  uint64   `json:"approved_by"`
+	CompletedAt string   `json:"completed_at"`
+	Erased      []string `json:"erased"`
+	Retained    []string `json:"retained"`
+}
+
+// issueCertificate 完了記録を作成し、その JSON と SHA-256 をリクエストに設定する
+func issueCertificate(request *model.UserErasureRequests, approvedBy uint64, completedAt time.Time) error {
+	data, err := json.Marshal(erasureCertificate{
+		RequestId:   request.Id,
+		UserId:      request.UserId,
+		RequestedAt: request.CreatedAt.UTC().Format(time.RFC3339),
+		ApprovedBy:  approvedBy,
+		CompletedAt: completedAt.UTC().Format(time.RFC3339),
+		Erased:      erasedData,
+		Retained:    retainedData,
+	})
+	if err != nil {
+		return err
+	}
+
+	sum := sha256.Sum256(data)
+	request.Certificate = string(data)
+	request.CertificateHash = hex.EncodeToString(sum[:])
+	return nil
+}
+
+// notifyErasure 消去リクエストの結果を本人に通知する
+// 結果は API でも確認できるため、通知の失敗はログに残すのみ
+func notifyErasure(ctx context.Context, svcCtx *svc.ServiceContext, email, subject, body string) {
+	err := svcCtx.Notifier.Send(ctx, notify.Message{
+		To:      email,
+		Subject: subject,
+		Body:    body,
+	})
+	if err != nil {
+		logx.WithContext(ctx).Errorf("Failed to notify erasure result: %v", err)
+	}
+}
+
+// deleteAvatar 消去したユーザーのアバター画像をストレージから消す
+// 同じ画像をアップロードした他のユーザーが使っている場合は残す。失敗はログに残して続行する
+func deleteAvatar(ctx context.Context, svcCtx *svc.ServiceContext, avatarUrl string) {
+	names := avatar.Names(svcCtx.Config.Avatar.PublicUrl, avatarUrl)
+	if names == nil {
+		return
+	}
+	count, err := svcCtx.UserProfilesModel.CountByAvatarUrl(ctx, avatarUrl)
+	if err != nil {
+		logx.WithContext(ctx).Errorf("Failed to count profiles using avatar %s: %v", avatarUrl, err)
+		return
+	}
+	if count > 0 {
+		return
+	}
+
+	for _, name := range names {
+		if err := svcCtx.Blobs.Delete(ctx, avatar.Key(name)); err != nil {
+			logx.WithContext(ctx).Errorf("Failed to delete avatar %s: %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package privacy
+
+import (
+	"context"
+
+	"user_service/internal/svc"
+	"user_service/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetDataExportLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetDataExportLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetDataExportLogic {
+	return &GetDataExportLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// GetDataExport 本人のエクスポートの状態を返す
+func (l *GetDataExportLogic) GetDataExport(req *types.DataExportIdReq) (resp *types.DataExport, err error) {
+	userId, err := currentUserId(l.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	export, err := findOwnDataExport(l.ctx, l.svcCtx, userId, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return toDataExport(export), nil
+}
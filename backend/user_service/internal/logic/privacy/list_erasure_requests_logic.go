@@ -0,0 +1,48 @@
+package privacy
+
+import (
+	"context"
+	"fmt"
+
+	"user_service/internal/svc"
+	"user_service/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListErasureRequestsLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListErasureRequestsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListErasureRequestsLogic {
+	return &ListErasureRequestsLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// ListErasureRequests 状態を指定して消去リクエストを古い順に返す
+func (l *ListErasureRequestsLogic) ListErasureRequests(req *types.ErasureRequestListReq) (resp *types.ErasureRequestListRes, err error) {
+	if _, err := requireSystemAdmin(l.ctx, l.svcCtx); err != nil {
+		return nil, err
+	}
+
+	requests, err := l.svcCtx.ErasureRequestsModel.FindByStatus(l.ctx, req.Status, req.Limit, req.Offset)
+	if err != nil {
+		l.Errorf("Failed to fetch %s erasure requests: %v", req.Status, err)
+		return nil, fmt.Errorf("消去リクエストの取得に失敗しました")
+	}
+	total, err := l.svcCtx.ErasureRequestsModel.CountByStatus(l.ctx, req.Status)
+	if err != nil {
+		l.Errorf("Failed to count %s erasure requests: %v", req.Status, err)
+		return nil, fmt.Errorf("消去リクエストの取得に失敗しました")
+	}
+
+	return &types.ErasureRequestListRes{
+		Requests: toErasureRequests(requests),
+		Total:    total,
+	}, nil
+}
@@ -0,0 +1,44 @@
+package privacy
+
+import (
+	"context"
+	"fmt"
+
+	"user_service/internal/svc"
+	"user_service/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListMyErasureRequestsLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListMyErasureRequestsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListMyErasureRequestsLogic {
+	return &ListMyErasureRequestsLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// ListMyErasureRequests 本人の消去リクエストを新しい順に返す
+func (l *ListMyErasureRequestsLogic) ListMyErasureRequests() (resp *types.ErasureRequestListRes, err error) {
+	userId, err := currentUserId(l.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	requests, err := l.svcCtx.ErasureRequestsModel.FindByUserId(l.ctx, uint64(userId))
+	if err != nil {
+		l.Errorf("Failed to fetch erasure requests of user %d: %v", userId, err)
+		return nil, fmt.Errorf("消去リクエストの取得に失敗しました")
+	}
+
+	return &types.ErasureRequestListRes{
+		Requests: toErasureRequests(requests),
+		Total:    int64(len(requests)),
+	}, nil
+}
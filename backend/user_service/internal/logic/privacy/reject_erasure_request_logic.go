@@ -0,0 +1,96 @@
+package privacy
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"user_service/internal/audit"
+	"user_service/internal/model"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type RejectErasureRequestLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewRejectErasureRequestLogic(ctx context.Context, svcCtx *svc.ServiceContext) *RejectErasureRequestLogic {
+	return &RejectErasureRequestLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// RejectErasureRequest 承認待ちの消去リクエストを却下し、理由を本人に通知する
+func (l *RejectErasureRequestLogic) RejectErasureRequest(req *types.ErasureReviewReq) (resp *types.ErasureRequest, err error) {
+	operatorId, err := requireSystemAdmin(l.ctx, l.svcCtx)
+	if err != nil {
+		return nil, err
+	}
+	note, err := reviewNote(req.Note)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := findErasureRequest(l.ctx, l.svcCtx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	before := snapshotErasure(request)
+
+	request.Status = model.ErasureStatusRejected
+	request.ReviewedBy = uint64(operatorId)
+	request.ReviewNote = note
+	request.ReviewedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	ok, err := l.svcCtx.ErasureRequestsModel.Close(l.ctx, request)
+	if err != nil {
+		l.Errorf("Failed to reject erasure request %d: %v", request.Id, err)
+		return nil, fmt.Errorf("消去リクエストの却下に失敗しました")
+	}
+	if !ok {
+		return nil, errErasureNotPending
+	}
+
+	l.Infof("Erasure request %d rejected by admin %d", request.Id, operatorId)
+	l.svcCtx.Audit.Record(l.ctx, audit.Entry{
+		Action:     audit.ActionErasureReject,
+		TargetType: audit.TargetErasure,
+		TargetId:   request.Id,
+		Before:     before,
+		After:      snapshotErasure(request),
+	})
+
+	user, err := l.svcCtx.UsersModel.FindOne(l.ctx, request.UserId)
+	switch {
+	case err == nil:
+		body := "個人データの消去リクエストは却下されました。\n"
+		if note != "" {
+			body += "理由: " + note + "\n"
+		}
+		notifyErasure(l.ctx, l.svcCtx, user.Email, "個人データの消去リクエストについて", body)
+	case !errors.Is(err, model.ErrNotFound):
+		l.Errorf("Failed to fetch user %d to notify: %v", request.UserId, err)
+	}
+
+	rejected := toErasureRequest(request)
+	return &rejected, nil
+}
+
+// reviewNote 承認・却下のコメントを検証する
+func reviewNote(note string) (string, error) {
+	note = strings.TrimSpace(note)
+	if utf8.RuneCountInString(note) > maxErasureReasonLength {
+		return "", fmt.Errorf("コメントは %d 文字以内で入力してください", maxErasureReasonLength)
+	}
+	return note, nil
+}
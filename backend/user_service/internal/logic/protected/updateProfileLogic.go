@@ -1,30 +0,0 @@
-package protected
-
-import (
-	"context"
-
-	"user_service/internal/svc"
-	"user_service/internal/types"
-
-	"github.com/zeromicro/go-zero/core/logx"
-)
-
-type UpdateProfileLogic struct {
-	logx.Logger
-	ctx    context.Context
-	svcCtx *svc.ServiceContext
-}
-
-func NewUpdateProfileLogic(ctx context.Context, svcCtx *svc.ServiceContext) *UpdateProfileLogic {
-	return &UpdateProfileLogic{
-		Logger: logx.WithContext(ctx),
-		ctx:    ctx,
-		svcCtx: svcCtx,
-	}
-}
-
-func (l *UpdateProfileLogic) UpdateProfile(req *types.RegisterReq) (resp *types.RegisterRes, err error) {
-	// todo: add your logic here and delete this line
-
-	return
-}
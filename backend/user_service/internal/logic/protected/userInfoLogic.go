@@ -1,30 +0,0 @@
-package protected
-
-import (
-	"context"
-
-	"user_service/internal/svc"
-	"user_service/internal/types"
-
-	"github.com/zeromicro/go-zero/core/logx"
-)
-
-type UserInfoLogic struct {
-	logx.Logger
-	ctx    context.Context
-	svcCtx *svc.ServiceContext
-}
-
-func NewUserInfoLogic(ctx context.Context, svcCtx *svc.ServiceContext) *UserInfoLogic {
-	return &UserInfoLogic{
-		Logger: logx.WithContext(ctx),
-		ctx:    ctx,
-		svcCtx: svcCtx,
-	}
-}
-
-func (l *UserInfoLogic) UserInfo() (resp *types.UserInfoRes, err error) {
-	// todo: add your logic here and delete this line
-
-	return
-}
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"time"
 
+	"user_service/internal/audit"
+	"user_service/internal/logic/org"
 	"user_service/internal/model"
 	"user_service/internal/svc"
 	"user_service/internal/types"
@@ -40,6 +42,16 @@ func (l *RegisterLogic) Register(req *types.RegisterReq) (resp *types.RegisterRe
 		logx.Errorf("ユーザー検索エラー: %v", err)
 		return nil, errors.New("ユーザー登録処理中にエラーが発生しました")
 	}
+	// 削除済みのユーザーがいることは登録者に伝えず、使用中として扱う
+	held, err := emailHeldByDeletedUser(l.ctx, l.svcCtx, req.Email)
+	if err != nil {
+		logx.Errorf("ユーザー検索エラー: %v", err)
+		return nil, errors.New("ユーザー登録処理中にエラーが発生しました")
+	}
+	if held {
+		logx.Errorf("ユーザー登録失敗: メールアドレスが削除済みのユーザーで使用されています: %s", req.Email)
+		return nil, errors.New("このメールアドレスは既に使用されています")
+	}
 
 	// パスワードをハッシュ化
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
@@ -73,6 +85,18 @@ func (l *RegisterLogic) Register(req *types.RegisterReq) (resp *types.RegisterRe
 	}
 
 	logx.Infof("ユーザー登録成功: %s (ID: %d)", req.Email, userId)
+	l.svcCtx.Audit.Record(l.ctx, audit.Entry{
+		Action:     audit.ActionUserRegister,
+		ActorId:    userId,
+		TargetType: audit.TargetUser,
+		TargetId:   userId,
+		After:      l.svcCtx.Audit.SnapshotUser(user),
+	})
+
+	// 招待メールから登録した場合は、その招待の組織に参加させる
+	if req.InvitationToken != "" && org.AcceptInvitationOnRegister(l.ctx, l.svcCtx, uint64(userId), req.Email, req.InvitationToken) {
+		logx.Infof("招待により組織に参加しました (ID: %d)", userId)
+	}
 
 	return &types.RegisterRes{
 		Id:    userId,
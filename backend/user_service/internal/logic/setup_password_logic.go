@@ -0,0 +1,102 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"user_service/internal/audit"
+	"user_service/internal/model"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/stores/redis"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var errPasswordSetupInvalid = errors.New("パスワード設定のリンクが無効か、有効期限が切れています")
+
+type SetupPasswordLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewSetupPasswordLogic(ctx context.Context, svcCtx *svc.ServiceContext) *SetupPasswordLogic {
+	return &SetupPasswordLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *SetupPasswordLogic) SetupPassword(req *types.PasswordSetupReq) (resp *types.CommonRes, err error) {
+	if req.Token == "" {
+		return nil, errPasswordSetupInvalid
+	}
+	// トークンを使い切る前に検証し、入力の誤りでリンクが無効にならないようにする
+	if len(req.Password) < 6 {
+		return nil, fmt.Errorf("パスワードは6文字以上で入力してください")
+	}
+	// 無効なトークンでハッシュ計算の負荷をかけられないよう、先にトークンを確認する
+	value, err := l.svcCtx.Redis.GetCtx(l.ctx, passwordSetupKey(req.Token))
+	if err != nil {
+		l.Errorf("Failed to read password setup token: %v", err)
+		return nil, fmt.Errorf("パスワードの設定に失敗しました")
+	}
+	if value == "" {
+		return nil, errPasswordSetupInvalid
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		l.Errorf("Failed to hash password: %v", err)
+		return nil, fmt.Errorf("パスワードの設定に失敗しました")
+	}
+
+	// 取得と同時に削除し、同じリンクでは一度しか設定できないようにする
+	// ハッシュ計算の間に他のリクエストが使い切った場合は無効として扱う
+	value, err = l.svcCtx.Redis.GetDelCtx(l.ctx, passwordSetupKey(req.Token))
+	if err != nil && !errors.Is(err, redis.Nil) {
+		l.Errorf("Failed to read password setup token: %v", err)
+		return nil, fmt.Errorf("パスワードの設定に失敗しました")
+	}
+	userId, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return nil, errPasswordSetupInvalid
+	}
+
+	err = l.svcCtx.UnitOfWork.Do(l.ctx, func(ctx context.Context, tx *model.Tx) error {
+		user, err := l.svcCtx.UsersModel.LockTx(ctx, tx, userId)
+		if err != nil {
+			return err
+		}
+		user.Password = string(hashedPassword)
+		user.UpdatedAt = time.Now()
+		return l.svcCtx.UsersModel.UpdateTx(ctx, tx, user)
+	})
+	if errors.Is(err, model.ErrNotFound) {
+		return nil, errPasswordSetupInvalid
+	}
+	if err != nil {
+		l.Errorf("Failed to set password of user %d: %v", userId, err)
+		return nil, fmt.Errorf("パスワードの設定に失敗しました")
+	}
+
+	l.Infof("Password of user %d set via setup link", userId)
+	// 未ログインの操作のため、操作者は対象ユーザー本人として記録する
+	l.svcCtx.Audit.Record(l.ctx, audit.Entry{
+		Action:     audit.ActionPasswordSetup,
+		ActorId:    int64(userId),
+		TargetType: audit.TargetUser,
+		TargetId:   userId,
+	})
+
+	return &types.CommonRes{
+		Message: "パスワードを設定しました",
+		Success: true,
+	}, nil
+}
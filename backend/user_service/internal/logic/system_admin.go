@@ -0,0 +1,30 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+
+	"user_service/internal/svc"
+
+	"github.com/winyx/backend/common/auth"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// requireSystemAdmin 操作者がシステム管理者か確認し、そのユーザーIDを返す
+func requireSystemAdmin(ctx context.Context, svcCtx *svc.ServiceContext) (int64, error) {
+	operatorId, ok := auth.UserIdFromContext(ctx)
+	if !ok {
+		return 0, fmt.Errorf("認証エラー: ユーザーIDが取得できません")
+	}
+	isAdmin, err := svcCtx.OrgPermissions.IsSystemAdmin(ctx, uint64(operatorId))
+	if err != nil {
+		logx.WithContext(ctx).Errorf("Failed to check system role of user %d: %v", operatorId, err)
+		return 0, fmt.Errorf("権限の確認に失敗しました")
+	}
+	if !isAdmin {
+		return 0, fmt.Errorf("システム管理者権限が必要です")
+	}
+
+	return operatorId, nil
+}
@@ -0,0 +1,44 @@
+package tenant
+
+import (
+	"context"
+	"fmt"
+
+	"user_service/internal/svc"
+	"user_service/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetTenantLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetTenantLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetTenantLogic {
+	return &GetTenantLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *GetTenantLogic) GetTenant() (resp *types.TenantRes, err error) {
+	t, grant, err := currentTenant(l.ctx, l.svcCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	org, err := l.svcCtx.OrgsModel.FindOne(l.ctx, uint64(t.OrgId))
+	if err != nil {
+		l.Errorf("Failed to fetch organization %d: %v", t.OrgId, err)
+		return nil, fmt.Errorf("組織情報の取得に失敗しました")
+	}
+
+	return &types.TenantRes{
+		Org:         toOrg(org),
+		Role:        t.Role,
+		Permissions: grant.Permissions(),
+	}, nil
+}
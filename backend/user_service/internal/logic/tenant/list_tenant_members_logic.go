@@ -0,0 +1,116 @@
+package tenant
+
+import (
+	"context"
+	"fmt"
+
+	"user_service/internal/cursor"
+	"user_service/internal/model"
+	"user_service/internal/orgperm"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListTenantMembersLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListTenantMembersLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListTenantMembersLogic {
+	return &ListTenantMembersLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// defaultMemberPageLimit cursor だけが指定された場合の件数
+const defaultMemberPageLimit = 50
+
+func (l *ListTenantMembersLogic) ListTenantMembers(req *types.TenantMemberListReq) (resp *types.TenantMemberListRes, err error) {
+	t, grant, err := currentTenant(l.ctx, l.svcCtx)
+	if err != nil {
+		return nil, err
+	}
+	if !grant.Has(orgperm.MembersView) {
+		return nil, fmt.Errorf("メンバー一覧の参照権限がありません")
+	}
+
+	// org_id はリクエストのテナントから付与される（他組織のメンバーは取得できない）
+	var members []*model.OrgMembers
+	resp = &types.TenantMemberListRes{}
+	if req.Limit == 0 && req.Cursor == "" {
+		members, err = l.svcCtx.OrgMembersModel.FindByTenant(l.ctx)
+	} else {
+		limit := req.Limit
+		if limit == 0 {
+			limit = defaultMemberPageLimit
+		}
+		// カーソルは発行したテナントの一覧でだけ使える
+		scope := cursor.Scope("tenant_members", t.OrgId)
+		var from *cursor.Position
+		if from, err = l.svcCtx.Cursors.Parse(scope, req.Cursor); err != nil {
+			return nil, err
+		}
+		members, err = l.svcCtx.OrgMembersModel.FindPageByTenant(l.ctx, from.KeysetPage(), limit+1)
+		if err == nil {
+			members, resp.NextCursor, resp.PrevCursor = cursor.Paginate(l.svcCtx.Cursors, scope, from, 0, members, limit,
+				func(member *model.OrgMembers) cursor.Position {
+					return cursor.Position{Key: member.CreatedAt, Id: member.Id}
+				})
+		}
+	}
+	if err != nil {
+		l.Errorf("Failed to fetch members of org %d: %v", t.OrgId, err)
+		return nil, fmt.Errorf("メンバー一覧の取得に失敗しました")
+	}
+
+	// ユーザーは 1 クエリでまとめて取得する。一覧の取得後に削除されたユーザーは除く
+	userIds := make([]uint64, len(members))
+	for i, member := range members {
+		userIds[i] = member.UserId
+	}
+	found, err := l.svcCtx.UsersModel.FindByIds(l.ctx, userIds)
+	if err != nil {
+		l.Errorf("Failed to fetch users of org %d: %v", t.OrgId, err)
+		return nil, fmt.Errorf("メンバー一覧の取得に失敗しました")
+	}
+	users := make(map[uint64]*model.Users, len(found))
+	for _, user := range found {
+		users[user.Id] = user
+	}
+
+	roleNames := make(map[int64]string)
+	result := make([]types.TenantMember, 0, len(members))
+	for _, member := range members {
+		user, ok := users[member.UserId]
+		if !ok {
+			continue
+		}
+
+		roleName, ok := roleNames[member.RoleId]
+		if !ok {
+			role, err := l.svcCtx.OrgRolesModel.FindOne(l.ctx, member.RoleId)
+			if err != nil {
+				l.Errorf("Failed to fetch org role %d: %v", member.RoleId, err)
+				return nil, fmt.Errorf("メンバー一覧の取得に失敗しました")
+			}
+			roleName = role.Name
+			roleNames[member.RoleId] = roleName
+		}
+
+		result = append(result, types.TenantMember{
+			UserId:   int64(user.Id),
+			Name:     user.Name,
+			Email:    user.Email,
+			Role:     roleName,
+			JoinedAt: member.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		})
+	}
+
+	resp.Members = result
+	return resp, nil
+}
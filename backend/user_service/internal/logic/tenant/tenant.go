@@ -0,0 +1,41 @@
+package tenant
+
+import (
+	"context"
+	"fmt"
+
+	"user_service/internal/model"
+	"user_service/internal/orgperm"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+
+	tenantctx "github.com/winyx/backend/common/tenant"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// currentTenant TenantMiddleware が確認済みの操作対象組織と、その組織での権限を取得
+func currentTenant(ctx context.Context, svcCtx *svc.ServiceContext) (tenantctx.Tenant, *orgperm.Grant, error) {
+	t, ok := tenantctx.FromContext(ctx)
+	if !ok {
+		return t, nil, tenantctx.ErrOrgRequired
+	}
+
+	grant, err := svcCtx.OrgPermissions.Resolve(ctx, uint64(t.OrgId), uint64(t.UserId))
+	if err != nil {
+		logx.WithContext(ctx).Errorf("Failed to resolve permissions of user %d in org %d: %v", t.UserId, t.OrgId, err)
+		return t, nil, fmt.Errorf("組織情報の取得に失敗しました")
+	}
+
+	return t, grant, nil
+}
+
+func toOrg(org *model.Orgs) types.Org {
+	return types.Org{
+		Id:        int64(org.Id),
+		Name:      org.Name,
+		OwnerId:   int64(org.OwnerId),
+		CreatedAt: org.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt: org.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
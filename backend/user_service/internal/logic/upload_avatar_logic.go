@@ -0,0 +1,100 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"user_service/internal/avatar"
+	"user_service/internal/dataloader"
+	"user_service/internal/etag"
+	"user_service/internal/imaging"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+
+	"github.com/winyx/backend/common/auth"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type UploadAvatarLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewUploadAvatarLogic(ctx context.Context, svcCtx *svc.ServiceContext) *UploadAvatarLogic {
+	return &UploadAvatarLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// UploadAvatar ログイン中のユーザーのアバター画像をアップロードする
+// 画像は形式を内容から判定し、EXIF などのメタデータを除いた標準サイズのサムネイルに作り直して保存する。
+// プロフィールの avatar_url には ProfileSize のサムネイルの URL を設定する
+func (l *UploadAvatarLogic) UploadAvatar(req *types.AvatarReq, body io.Reader, contentType string) (resp *types.AvatarRes, err error) {
+	userId, ok := auth.UserIdFromContext(l.ctx)
+	if !ok {
+		return nil, fmt.Errorf("認証エラー: ユーザーIDが取得できません")
+	}
+
+	data, err := readAvatarFile(body, contentType)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return nil, fmt.Errorf("ファイルサイズは %d バイト以内にしてください", tooLarge.Limit)
+		}
+		if errors.Is(err, errAvatarFileRequired) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("アップロードされたファイルを読み込めませんでした")
+	}
+
+	thumbnails, err := avatar.Generate(data, l.svcCtx.Config.Avatar.MaxPixels)
+	if err != nil {
+		var tooLarge *imaging.TooLargeError
+		if errors.Is(err, imaging.ErrUnsupportedFormat) || errors.Is(err, imaging.ErrInvalidImage) || errors.As(err, &tooLarge) {
+			return nil, err
+		}
+		l.Errorf("Failed to generate avatar thumbnails of user %d: %v", userId, err)
+		return nil, fmt.Errorf("画像の変換に失敗しました")
+	}
+
+	// 同じ画像は同じキーになるため、保存済みのサイズは書き込まない
+	var profileName string
+	for _, thumbnail := range thumbnails {
+		exists, err := l.svcCtx.Blobs.Exists(l.ctx, thumbnail.Key())
+		if err == nil && !exists {
+			err = l.svcCtx.Blobs.Put(l.ctx, thumbnail.Key(), thumbnail.Data, thumbnail.ContentType)
+		}
+		if err != nil {
+			l.Errorf("Failed to store avatar %s: %v", thumbnail.Key(), err)
+			return nil, fmt.Errorf("画像の保存に失敗しました")
+		}
+		if thumbnail.Size == avatar.ProfileSize {
+			profileName = thumbnail.Name
+		}
+	}
+
+	publicUrl := l.svcCtx.Config.Avatar.PublicUrl
+	profile, err := setAvatarUrl(l.ctx, l.svcCtx, userId, req.IfMatch, avatar.Url(publicUrl, profileName))
+	if err != nil {
+		var failed *etag.PreconditionFailedError
+		if errors.As(err, &failed) {
+			return nil, err
+		}
+		l.Errorf("Failed to set avatar of user %d: %v", userId, err)
+		return nil, fmt.Errorf("アバター画像の設定に失敗しました")
+	}
+
+	l.Infof("User %d uploaded avatar %s", userId, profileName)
+	return &types.AvatarRes{
+		Profile:    *dataloader.ProfileData(profile),
+		Thumbnails: avatarThumbnails(publicUrl, thumbnails),
+		Etag:       etag.Profile(profile),
+	}, nil
+}
@@ -0,0 +1,29 @@
+package logic
+
+import (
+	"context"
+	"sort"
+
+	"user_service/internal/svc"
+)
+
+// rolesSnapshot 監査ログに記録するグローバルロールの付与状況
+type rolesSnapshot struct {
+	Roles []string `json:"roles"`
+}
+
+// loadRolesSnapshot ユーザーに付与されているグローバルロール（差分が順序に左右されないよう名前順）
+func loadRolesSnapshot(ctx context.Context, svcCtx *svc.ServiceContext, userId int64) (rolesSnapshot, error) {
+	roles, err := svcCtx.UserRolesModel.FindByUserIdWithRole(ctx, userId)
+	if err != nil {
+		return rolesSnapshot{}, err
+	}
+
+	names := make([]string, 0, len(roles))
+	for _, role := range roles {
+		names = append(names, role.RoleName)
+	}
+	sort.Strings(names)
+
+	return rolesSnapshot{Roles: names}, nil
+}
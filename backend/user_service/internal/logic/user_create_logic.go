@@ -6,10 +6,14 @@ import (
 	"strconv"
 	"time"
 
+	"user_service/internal/audit"
+	"user_service/internal/dataloader"
 	"user_service/internal/model"
 	"user_service/internal/svc"
 	"user_service/internal/types"
 
+	"github.com/winyx/backend/common/auth"
+
 	"github.com/zeromicro/go-zero/core/logx"
 	"golang.org/x/crypto/bcrypt"
 )
@@ -36,6 +40,13 @@ func (l *UserCreateLogic) UserCreate(req *types.UserCreateReq) (resp *types.User
 	if err == nil && existingUser != nil {
 		return nil, fmt.Errorf("email already exists: %s", req.Email)
 	}
+	held, err := emailHeldByDeletedUser(l.ctx, l.svcCtx, req.Email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check email: %w", err)
+	}
+	if held {
+		return nil, errEmailHeldByDeletedUser
+	}
 
 	// Hash password
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
@@ -51,96 +62,82 @@ func (l *UserCreateLogic) UserCreate(req *types.UserCreateReq) (resp *types.User
 		}
 	}
 
-	// Create user model
-	newUser := &model.Users{
-		Name:     req.Name,
-		Email:    req.Email,
-		Password: string(hashedPassword),
-		Status:   status,
-	}
-
-	// Insert user into database
-	result, err := l.svcCtx.UsersModel.Insert(l.ctx, newUser)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create user: %w", err)
-	}
-
-	// Get the created user ID
-	userId, err := result.LastInsertId()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get user ID: %w", err)
-	}
-
-	logx.Infof("User created successfully with ID: %d", userId)
-
 	// Assign default role if no roles provided
 	roles := req.Roles
 	if len(roles) == 0 {
 		roles = []string{"user"}
 	}
+	roleIds, err := resolveRoleIds(l.ctx, l.svcCtx, roles)
+	if err != nil {
+		return nil, err
+	}
+	assignedBy, _ := auth.UserIdFromContext(l.ctx)
 
-	// Assign roles to user
-	for _, roleName := range roles {
-		// Get role ID
-		var roleId int64
-		getRoleIdQuery := `SELECT id FROM roles WHERE name = ?`
-		err = l.svcCtx.DB.QueryRowPartial(&roleId, getRoleIdQuery, roleName)
-		if err == nil {
-			// Insert user role
-			insertRoleQuery := `INSERT INTO user_roles (user_id, role_id) VALUES (?, ?)`
-			_, err = l.svcCtx.DB.Exec(insertRoleQuery, userId, roleId)
-			if err != nil {
-				logx.Errorf("Failed to assign role %s to user %d: %v", roleName, userId, err)
-			} else {
-				logx.Infof("Successfully assigned role %s to user %d", roleName, userId)
-			}
-		} else {
-			logx.Errorf("Role %s not found: %v", roleName, err)
-		}
+	// Create user model
+	now := time.Now()
+	newUser := &model.Users{
+		Name:      req.Name,
+		Email:     req.Email,
+		Password:  string(hashedPassword),
+		Status:    status,
+		CreatedAt: now,
+		UpdatedAt: now,
 	}
 
 	// Create user profile if provided
+	var profileModel *model.UserProfiles
 	if req.Profile != nil {
-		logx.Infof("Creating profile for user %d", userId)
-		
-		// Parse birth date
-		var birthDate time.Time
-		if req.Profile.BirthDate != "" {
-			if parsedDate, err := time.Parse("2006-01-02", req.Profile.BirthDate); err == nil {
-				birthDate = parsedDate
-			} else {
-				birthDate = time.Now() // fallback
-			}
-		} else {
-			birthDate = time.Now() // fallback
-		}
-
-		// Create profile model
-		profileModel := &model.UserProfiles{
-			UserId:      uint64(userId),
-			Bio:         req.Profile.Bio,
-			Phone:       req.Profile.Phone,
-			Address:     req.Profile.Address,
-			BirthDate:   birthDate,
-			Gender:      req.Profile.Gender,
-			Occupation:  req.Profile.Occupation,
-			Website:     req.Profile.Website,
-			SocialLinks: func() string {
-				if req.Profile.SocialLinks == "" {
-					return "null"
-				}
-				return req.Profile.SocialLinks
-			}(),
+		profileModel = &model.UserProfiles{
 			Preferences: "null", // Default JSON null
 		}
+		applyProfile(profileModel, req.Profile)
+	}
 
-		// Insert profile
-		_, err = l.svcCtx.UserProfilesModel.Insert(l.ctx, profileModel)
+	// The user, the roles and the profile are stored all or nothing
+	var userId int64
+	err = l.svcCtx.UnitOfWork.Do(l.ctx, func(ctx context.Context, tx *model.Tx) error {
+		result, err := l.svcCtx.UsersModel.InsertTx(ctx, tx, newUser)
 		if err != nil {
-			logx.Errorf("Failed to create profile for user %d: %v", userId, err)
-		} else {
-			logx.Infof("Profile created successfully for user %d", userId)
+			return fmt.Errorf("failed to create user: %w", err)
+		}
+		if userId, err = result.LastInsertId(); err != nil {
+			return fmt.Errorf("failed to get user ID: %w", err)
 		}
+
+		if err := l.svcCtx.UserRolesModel.ReplaceByUserIdTx(ctx, tx, userId, roleIds, assignedBy); err != nil {
+			return fmt.Errorf("failed to assign roles: %w", err)
+		}
+
+		if profileModel != nil {
+			profileModel.UserId = uint64(userId)
+			if err := l.svcCtx.UserProfilesModel.UpsertTx(ctx, tx, profileModel); err != nil {
+				return fmt.Errorf("failed to create profile: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		l.Errorf("Failed to create user %s: %v", req.Email, err)
+		return nil, err
+	}
+
+	logx.Infof("User created successfully with ID: %d", userId)
+	l.svcCtx.Audit.Record(l.ctx, audit.Entry{
+		Action:     audit.ActionUserCreate,
+		TargetType: audit.TargetUser,
+		TargetId:   userId,
+		After:      l.svcCtx.Audit.SnapshotUser(newUser),
+	})
+
+	if assigned, err := loadRolesSnapshot(l.ctx, l.svcCtx, userId); err != nil {
+		logx.Errorf("Failed to load roles of user %d for audit log: %v", userId, err)
+	} else {
+		l.svcCtx.Audit.Record(l.ctx, audit.Entry{
+			Action:     audit.ActionUserRolesAssign,
+			TargetType: audit.TargetUser,
+			TargetId:   userId,
+			After:      assigned,
+		})
 	}
 
 	// Get the created user with all details for response
@@ -154,12 +151,12 @@ func (l *UserCreateLogic) UserCreate(req *types.UserCreateReq) (resp *types.User
 	query := `SELECT r.name FROM roles r 
 	          INNER JOIN user_roles ur ON r.id = ur.role_id 
 	          WHERE ur.user_id = ?`
-	
+
 	err = l.svcCtx.DB.QueryRowsPartial(&userRoles, query, userId)
 	if err != nil {
 		logx.Errorf("Failed to get user roles for response: %v", err)
 	}
-	
+
 	// If no roles found, assign default role
 	if len(userRoles) == 0 {
 		userRoles = []string{"user"}
@@ -169,16 +166,7 @@ func (l *UserCreateLogic) UserCreate(req *types.UserCreateReq) (resp *types.User
 	var profile *types.UserProfileData
 	userProfile, err := l.svcCtx.UserProfilesModel.FindOneByUserId(l.ctx, uint64(userId))
 	if err == nil && userProfile != nil {
-		profile = &types.UserProfileData{
-			Bio:         userProfile.Bio,
-			Phone:       userProfile.Phone,
-			Address:     userProfile.Address,
-			BirthDate:   userProfile.BirthDate.Format("2006-01-02"),
-			Gender:      userProfile.Gender,
-			Occupation:  userProfile.Occupation,
-			Website:     userProfile.Website,
-			SocialLinks: userProfile.SocialLinks,
-		}
+		profile = dataloader.ProfileData(userProfile)
 	}
 
 	// Build response
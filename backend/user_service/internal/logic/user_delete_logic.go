@@ -2,7 +2,12 @@ package logic
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
+	"user_service/internal/audit"
+	"user_service/internal/model"
 	"user_service/internal/svc"
 	"user_service/internal/types"
 
@@ -24,7 +29,56 @@ func NewUserDeleteLogic(ctx context.Context, svcCtx *svc.ServiceContext) *UserDe
 }
 
 func (l *UserDeleteLogic) UserDelete(req *types.UserDeleteReq) (resp *types.UserDeleteRes, err error) {
-	// todo: add your logic here and delete this line
+	operatorId, err := requireSystemAdmin(l.ctx, l.svcCtx)
+	if err != nil {
+		return nil, err
+	}
+	if req.UserId == operatorId {
+		return nil, fmt.Errorf("自分自身は削除できません")
+	}
+
+	user, err := l.svcCtx.UsersModel.FindOne(l.ctx, uint64(req.UserId))
+	switch {
+	case errors.Is(err, model.ErrNotFound):
+		return nil, fmt.Errorf("ユーザーが見つかりません")
+	case err != nil:
+		l.Errorf("Failed to fetch user %d: %v", req.UserId, err)
+		return nil, fmt.Errorf("ユーザーの削除に失敗しました")
+	}
+
+	// ソフトデリート: プロフィール・ロール・メンバーシップは復元に備えて残し、復元期間を過ぎるとパージジョブが完全に削除する
+	err = l.svcCtx.UnitOfWork.Do(l.ctx, func(ctx context.Context, tx *model.Tx) error {
+		return l.svcCtx.UsersModel.SoftDeleteTx(ctx, tx, uint64(req.UserId))
+	})
+	switch {
+	case err == nil:
+	case errors.Is(err, model.ErrNotFound):
+		return nil, fmt.Errorf("ユーザーが見つかりません")
+	case errors.Is(err, model.ErrUserOwnsOrgs):
+		return nil, fmt.Errorf("組織のオーナーであるユーザーは削除できません。所有権を移譲するか組織を削除してから再度実行してください")
+	default:
+		l.Errorf("Failed to delete user %d: %v", req.UserId, err)
+		return nil, fmt.Errorf("ユーザーの削除に失敗しました")
+	}
+
+	deletedAt := time.Now()
+	l.Infof("User %d deleted by admin %d", req.UserId, operatorId)
+	after := l.svcCtx.Audit.SnapshotUser(user)
+	after.DeletedAt = deletedAt.Format("2006-01-02T15:04:05Z07:00")
+	l.svcCtx.Audit.Record(l.ctx, audit.Entry{
+		Action:     audit.ActionUserDelete,
+		TargetType: audit.TargetUser,
+		TargetId:   req.UserId,
+		Before:     l.svcCtx.Audit.SnapshotUser(user),
+		After:      after,
+	})
+	return &types.UserDeleteRes{
+		Message: fmt.Sprintf("ユーザーを削除しました（%s まで復元できます）",
+			userRestoreDeadline(l.svcCtx, deletedAt).Format("2006-01-02 15:04")),
+	}, nil
+}
 
-	return
+// userRestoreDeadline deletedAt に削除されたユーザーを復元できる期限
+func userRestoreDeadline(svcCtx *svc.ServiceContext, deletedAt time.Time) time.Time {
+	return deletedAt.Add(time.Duration(svcCtx.Config.User.RestoreDays) * 24 * time.Hour)
 }
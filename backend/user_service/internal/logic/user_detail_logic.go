@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"user_service/internal/dataloader"
 	"user_service/internal/svc"
 	"user_service/internal/types"
 
@@ -31,13 +32,9 @@ func (l *UserDetailLogic) UserDetail(req *types.UserDetailReq) (resp *types.User
 		return nil, err
 	}
 	
-	// Get user roles using raw SQL
-	var roles []string
-	query := `SELECT r.name FROM roles r 
-	          INNER JOIN user_roles ur ON r.id = ur.role_id 
-	          WHERE ur.user_id = ?`
-	
-	err = l.svcCtx.DB.QueryRowsPartial(&roles, query, req.UserId)
+	// ロールとプロフィールはリクエスト用のローダーから取得する
+	users := l.svcCtx.Loaders.Users(l.ctx)
+	roles, err := users.Roles(l.ctx, user.Id)
 	if err != nil {
 		logx.Errorf("Failed to get user roles: %v", err)
 	}
@@ -49,18 +46,9 @@ func (l *UserDetailLogic) UserDetail(req *types.UserDetailReq) (resp *types.User
 	
 	// Get user profile using model
 	var profile *types.UserProfileData
-	userProfile, err := l.svcCtx.UserProfilesModel.FindOneByUserId(l.ctx, uint64(req.UserId))
+	userProfile, err := users.Profile(l.ctx, user.Id)
 	if err == nil && userProfile != nil {
-		profile = &types.UserProfileData{
-			Bio:         userProfile.Bio,
-			Phone:       userProfile.Phone,
-			Address:     userProfile.Address,
-			BirthDate:   userProfile.BirthDate.Format("2006-01-02"),
-			Gender:      userProfile.Gender,
-			Occupation:  userProfile.Occupation,
-			Website:     userProfile.Website,
-			SocialLinks: userProfile.SocialLinks,
-		}
+		profile = dataloader.ProfileData(userProfile)
 	} else {
 		// プロフィールが存在しない場合はログ出力
 		logx.Infof("No profile found for user %d: %v", req.UserId, err)
@@ -0,0 +1,445 @@
+package logic
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"user_service/internal/audit"
+	"user_service/internal/bulk"
+	"user_service/internal/mergepatch"
+	"user_service/internal/model"
+	"user_service/internal/orgperm"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// 行の結果
+const (
+	importRowSkipped = "skipped"
+	importRowFailed  = "failed"
+)
+
+// importProgressInterval 進捗をジョブの行に書き込む間隔（処理した行数）
+const importProgressInterval = 100
+
+// インポートで付与できる組織内ロール（オーナーは組織ごとに 1 人のため対象外）
+var importableOrgRoles = map[string]bool{
+	orgperm.RoleAdmin:  true,
+	orgperm.RoleMember: true,
+	orgperm.RoleViewer: true,
+}
+
+// userImport 1 つのインポートジョブの実行
+type userImport struct {
+	logx.Logger
+	ctx     context.Context
+	svcCtx  *svc.ServiceContext
+	job     *model.UserImportJobs
+	records []bulk.Record
+	results []types.UserImportRowResult
+
+	roleIds    map[string]int64
+	orgs       map[uint64]bool // 組織 ID → 登録先にできるか（存在し、削除されていない）
+	orgRoleIds map[string]int64
+}
+
+// importRow 検証を通過した行
+type importRow struct {
+	record  *bulk.Record
+	user    *model.Users
+	profile *ProfilePatch
+	roles   []string
+	roleIds []int64
+	orgRole string
+}
+
+func newUserImport(ctx context.Context, svcCtx *svc.ServiceContext, job *model.UserImportJobs, records []bulk.Record) *userImport {
+	return &userImport{
+		Logger:  logx.WithContext(ctx),
+		ctx:     ctx,
+		svcCtx:  svcCtx,
+		job:     job,
+		records: records,
+	}
+}
+
+func (u *userImport) run() {
+	started, err := u.svcCtx.UserImportJobsModel.Start(u.ctx, u.job)
+	if err != nil {
+		u.Errorf("Failed to start user import job %d: %v", u.job.Id, err)
+		return
+	}
+	if !started {
+		return
+	}
+
+	if err := u.execute(); err != nil {
+		u.Errorf("User import job %d failed: %v", u.job.Id, err)
+		u.job.Status = model.ImportStatusFailed
+		u.job.Error = "インポート中にエラーが発生しました"
+	} else {
+		u.job.Status = model.ImportStatusCompleted
+	}
+	u.job.FinishedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	u.save()
+
+	u.Infof("User import job %d %s: %d rows, %d valid, %d created, %d skipped, %d failed", u.job.Id, u.job.Status,
+		u.job.TotalRows, u.job.ValidRows, u.job.CreatedRows, u.job.SkippedRows, u.job.FailedRows)
+}
+
+// execute 全行を検証し、dry_run でなければ検証を通過した行を登録する
+// 行ごとの誤りは結果に記録して続け、参照先の読み込みなどジョブ全体を続けられない場合だけエラーを返す
+func (u *userImport) execute() error {
+	if err := u.loadReferences(); err != nil {
+		return err
+	}
+
+	rows, err := u.validate()
+	if err != nil {
+		return err
+	}
+	u.job.ValidRows = uint64(len(rows))
+	if u.job.DryRun {
+		u.job.ProcessedRows = u.job.TotalRows
+		return nil
+	}
+
+	// 検証で登録しないと決まった行は処理済みとして数える
+	u.job.ProcessedRows = u.job.TotalRows - uint64(len(rows))
+	for i, row := range rows {
+		if err := u.create(row); err != nil {
+			u.Errorf("Failed to import row %d of job %d: %v", row.record.Line, u.job.Id, err)
+			u.addResult(row.record, importRowFailed, map[string]string{"row": "登録に失敗しました"})
+		} else {
+			u.job.CreatedRows++
+		}
+		u.job.ProcessedRows++
+		if (i+1)%importProgressInterval == 0 {
+			u.save()
+		}
+	}
+	return nil
+}
+
+// loadReferences ファイル内のロール・組織・組織内ロールをまとめて読み込む
+func (u *userImport) loadReferences() error {
+	roleNames := []string{defaultImportRole}
+	orgIds := make(map[uint64]bool)
+	orgRoleNames := map[string]bool{orgperm.RoleMember: true}
+	for i := range u.records {
+		record := &u.records[i]
+		roleNames = append(roleNames, record.Roles...)
+		if record.OrgId > 0 {
+			orgIds[record.OrgId] = true
+		}
+		if importableOrgRoles[record.OrgRole] {
+			orgRoleNames[record.OrgRole] = true
+		}
+	}
+
+	roles, err := u.svcCtx.RolesModel.FindAllByNames(u.ctx, roleNames)
+	if err != nil {
+		return fmt.Errorf("load roles: %w", err)
+	}
+	u.roleIds = make(map[string]int64, len(roles))
+	for _, role := range roles {
+		u.roleIds[role.Name] = role.Id
+	}
+
+	u.orgs = make(map[uint64]bool, len(orgIds))
+	for id := range orgIds {
+		org, err := u.svcCtx.OrgsModel.FindOne(u.ctx, id)
+		switch {
+		case errors.Is(err, model.ErrNotFound):
+			u.orgs[id] = false
+		case err != nil:
+			return fmt.Errorf("load org %d: %w", id, err)
+		default:
+			u.orgs[id] = !org.DeletedAt.Valid
+		}
+	}
+
+	u.orgRoleIds = make(map[string]int64, len(orgRoleNames))
+	for name := range orgRoleNames {
+		role, err := u.svcCtx.OrgRolesModel.FindOneByName(u.ctx, name)
+		if err != nil {
+			return fmt.Errorf("load org role %s: %w", name, err)
+		}
+		u.orgRoleIds[name] = role.Id
+	}
+	return nil
+}
+
+// defaultImportRole roles を指定しない行に付与するロール（ユーザー作成 API と同じ）
+const defaultImportRole = "user"
+
+// validate 各行を検証し、登録する行を返す
+// メールアドレスが登録済みの行と、ファイル内で先に出てきた行と重複する行はスキップする
+func (u *userImport) validate() ([]*importRow, error) {
+	rows := make([]*importRow, 0, len(u.records))
+	firstLine := make(map[string]int, len(u.records))
+	for i := range u.records {
+		record := &u.records[i]
+		row, errs := u.validateRow(record)
+		if len(errs) > 0 {
+			u.addResult(record, importRowFailed, errs)
+			continue
+		}
+
+		email := strings.ToLower(row.user.Email)
+		if line, ok := firstLine[email]; ok {
+			u.addResult(record, importRowSkipped, map[string]string{
+				bulk.ColumnEmail: fmt.Sprintf("%d 行目と重複しています", line),
+			})
+			continue
+		}
+		firstLine[email] = record.Line
+
+		_, err := u.svcCtx.UsersModel.FindOneByEmail(u.ctx, row.user.Email)
+		switch {
+		case err == nil:
+			u.addResult(record, importRowSkipped, map[string]string{bulk.ColumnEmail: "登録済みのメールアドレスです"})
+			continue
+		case !errors.Is(err, model.ErrNotFound):
+			return nil, fmt.Errorf("find user by email: %w", err)
+		}
+		held, err := emailHeldByDeletedUser(u.ctx, u.svcCtx, row.user.Email)
+		if err != nil {
+			return nil, fmt.Errorf("find deleted user by email: %w", err)
+		}
+		if held {
+			u.addResult(record, importRowFailed, map[string]string{bulk.ColumnEmail: errEmailHeldByDeletedUser.Error()})
+			continue
+		}
+
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// validateRow 1 行を検証する。検証にはユーザー更新 API（PATCH）と同じ規則を使う
+func (u *userImport) validateRow(record *bulk.Record) (*importRow, map[string]string) {
+	errs := make(mergepatch.FieldErrors)
+	for field, message := range record.Errors {
+		errs.Add(field, message)
+	}
+
+	status := record.Status
+	switch strings.ToLower(status) {
+	case "", "active":
+		status = "1"
+	case "inactive":
+		status = "0"
+	}
+	patch := UserPatch{
+		Name:   mergepatch.Field[string]{Set: true, Value: record.Name},
+		Email:  mergepatch.Field[string]{Set: true, Value: record.Email},
+		Status: mergepatch.Field[string]{Set: true, Value: status},
+	}
+	var fieldErrs mergepatch.FieldErrors
+	if errors.As(patch.validate(), &fieldErrs) {
+		for field, message := range fieldErrs {
+			errs.Add(field, message)
+		}
+	}
+
+	var profile *ProfilePatch
+	if record.HasProfile() {
+		profile = &ProfilePatch{
+			Bio:         mergepatch.Field[string]{Set: true, Value: record.Bio},
+			Phone:       mergepatch.Field[string]{Set: true, Value: record.Phone},
+			Address:     mergepatch.Field[string]{Set: true, Value: record.Address},
+			BirthDate:   mergepatch.Field[string]{Set: true, Value: record.BirthDate},
+			Gender:      mergepatch.Field[string]{Set: true, Value: record.Gender},
+			Occupation:  mergepatch.Field[string]{Set: true, Value: record.Occupation},
+			Website:     mergepatch.Field[string]{Set: true, Value: record.Website},
+			SocialLinks: mergepatch.Field[string]{Set: true, Value: record.SocialLinks},
+		}
+		profile.validateInto(errs, "")
+	}
+
+	roles := record.Roles
+	if len(roles) == 0 {
+		roles = []string{defaultImportRole}
+	}
+	roleIds := make([]int64, 0, len(roles))
+	seen := make(map[string]bool, len(roles))
+	for _, name := range roles {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		id, ok := u.roleIds[name]
+		if !ok {
+			errs.Add(bulk.ColumnRoles, fmt.Sprintf("ロールが存在しません: %s", name))
+			continue
+		}
+		roleIds = append(roleIds, id)
+	}
+
+	orgRole := record.OrgRole
+	if record.OrgId > 0 {
+		if !u.orgs[record.OrgId] {
+			errs.Add(bulk.ColumnOrgId, "組織が見つかりません")
+		}
+		if orgRole == "" {
+			orgRole = orgperm.RoleMember
+		}
+		if !importableOrgRoles[orgRole] {
+			errs.Add(bulk.ColumnOrgRole, fmt.Sprintf("組織内ロールは %s / %s / %s のいずれかを指定してください",
+				orgperm.RoleAdmin, orgperm.RoleMember, orgperm.RoleViewer))
+		}
+	} else if orgRole != "" {
+		errs.Add(bulk.ColumnOrgRole, "org_id を指定してください")
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	user := &model.Users{}
+	patch.apply(user)
+	return &importRow{
+		record:  record,
+		user:    user,
+		profile: profile,
+		roles:   roles,
+		roleIds: roleIds,
+		orgRole: orgRole,
+	}, nil
+}
+
+// create 1 行分のユーザー・ロール・プロフィール・組織のメンバーをまとめて登録する
+func (u *userImport) create(row *importRow) error {
+	password, err := unusablePassword()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	user := row.user
+	user.Password = password
+	user.CreatedAt = now
+	user.UpdatedAt = now
+
+	err = u.svcCtx.UnitOfWork.Do(u.ctx, func(ctx context.Context, tx *model.Tx) error {
+		result, err := u.svcCtx.UsersModel.InsertTx(ctx, tx, user)
+		if err != nil {
+			return fmt.Errorf("insert user: %w", err)
+		}
+		id, err := result.LastInsertId()
+		if err != nil {
+			return err
+		}
+		user.Id = uint64(id)
+
+		if err := u.svcCtx.UserRolesModel.ReplaceByUserIdTx(ctx, tx, id, row.roleIds, int64(u.job.CreatedBy)); err != nil {
+			return fmt.Errorf("assign roles: %w", err)
+		}
+		if row.profile != nil {
+			profile := &model.UserProfiles{UserId: user.Id, Preferences: "null"}
+			row.profile.apply(profile)
+			if err := u.svcCtx.UserProfilesModel.UpsertTx(ctx, tx, profile); err != nil {
+				return fmt.Errorf("create profile: %w", err)
+			}
+		}
+		if row.record.OrgId > 0 {
+			member := &model.OrgMembers{OrgId: row.record.OrgId, UserId: user.Id, RoleId: u.orgRoleIds[row.orgRole]}
+			if err := u.svcCtx.OrgMembersModel.InsertTx(ctx, tx, member); err != nil {
+				return fmt.Errorf("add org member: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	u.record(row)
+	if u.job.SendInvitations {
+		// 招待メールは再送できるよう、送信に失敗しても登録は取り消さない
+		if err := sendPasswordSetup(u.ctx, u.svcCtx, user); err != nil {
+			u.Errorf("Failed to send password setup link to user %d: %v", user.Id, err)
+		}
+	}
+	return nil
+}
+
+// record 登録した内容を監査ログに記録する（ユーザー作成 API と同じ操作として残す）
+func (u *userImport) record(row *importRow) {
+	u.svcCtx.Audit.Record(u.ctx, audit.Entry{
+		Action:     audit.ActionUserCreate,
+		TargetType: audit.TargetUser,
+		TargetId:   row.user.Id,
+		After:      u.svcCtx.Audit.SnapshotUser(row.user),
+	})
+
+	names := make([]string, 0, len(row.roleIds))
+	seen := make(map[string]bool, len(row.roles))
+	for _, name := range row.roles {
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	u.svcCtx.Audit.Record(u.ctx, audit.Entry{
+		Action:     audit.ActionUserRolesAssign,
+		TargetType: audit.TargetUser,
+		TargetId:   row.user.Id,
+		After:      rolesSnapshot{Roles: names},
+	})
+
+	if row.record.OrgId > 0 {
+		u.svcCtx.Audit.Record(u.ctx, audit.Entry{
+			Action:     audit.ActionOrgMemberAdd,
+			OrgId:      int64(row.record.OrgId),
+			TargetType: audit.TargetUser,
+			TargetId:   row.user.Id,
+			After:      orgMemberSnapshot{Role: row.orgRole},
+		})
+	}
+}
+
+// orgMemberSnapshot 監査ログに記録する組織内ロール（組織 API のメンバー追加と同じ形）
+type orgMemberSnapshot struct {
+	Role string `json:"role"`
+}
+
+func (u *userImport) addResult(record *bulk.Record, status string, errs map[string]string) {
+	u.results = append(u.results, types.UserImportRowResult{
+		Row:    record.Line,
+		Email:  record.Email,
+		Status: status,
+		Errors: errs,
+	})
+	if status == importRowSkipped {
+		u.job.SkippedRows++
+	} else {
+		u.job.FailedRows++
+	}
+}
+
+// save 進捗と行ごとの結果をジョブの行に書き込む（失敗してもジョブは続ける）
+func (u *userImport) save() {
+	results := u.results
+	if results == nil {
+		results = []types.UserImportRowResult{}
+	}
+	encoded, err := json.Marshal(results)
+	if err != nil {
+		u.Errorf("Failed to encode results of user import job %d: %v", u.job.Id, err)
+		return
+	}
+	u.job.RowResults = string(encoded)
+	if err := u.svcCtx.UserImportJobsModel.Update(u.ctx, u.job); err != nil {
+		u.Errorf("Failed to save user import job %d: %v", u.job.Id, err)
+	}
+}
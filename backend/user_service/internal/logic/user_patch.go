@@ -0,0 +1,172 @@
+package logic
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"user_service/internal/mergepatch"
+	"user_service/internal/model"
+)
+
+// UserPatch PATCH /v1/admin/users/:id のボディ
+// roles の null は全ロールの解除、profile の null はプロフィールの削除を表す
+type UserPatch struct {
+	Name    mergepatch.Field[string]       `json:"name"`
+	Email   mergepatch.Field[string]       `json:"email"`
+	Status  mergepatch.Field[string]       `json:"status"`
+	Roles   mergepatch.Field[[]string]     `json:"roles"`
+	Profile mergepatch.Field[ProfilePatch] `json:"profile"`
+}
+
+// ProfilePatch プロフィールのパッチ（PATCH /user/profile のボディ、または UserPatch の profile）
+// 各項目の null は未設定に戻すことを表す
+type ProfilePatch struct {
+	Bio         mergepatch.Field[string] `json:"bio"`
+	Phone       mergepatch.Field[string] `json:"phone"`
+	Address     mergepatch.Field[string] `json:"address"`
+	BirthDate   mergepatch.Field[string] `json:"birth_date"`
+	Gender      mergepatch.Field[string] `json:"gender"`
+	Occupation  mergepatch.Field[string] `json:"occupation"`
+	Website     mergepatch.Field[string] `json:"website"`
+	SocialLinks mergepatch.Field[string] `json:"social_links"`
+}
+
+// empty 変更する項目が無いか
+func (p *UserPatch) empty() bool {
+	return !p.Name.Set && !p.Email.Set && !p.Status.Set && !p.Roles.Set && !p.Profile.Set
+}
+
+// validate 各項目を検証する。name と email は前後の空白を除いた値に置き換える
+func (p *UserPatch) validate() error {
+	errs := make(mergepatch.FieldErrors)
+
+	if p.Name.Null {
+		errs.Add("name", "名前は削除できません")
+	} else if p.Name.Set {
+		p.Name.Value = strings.TrimSpace(p.Name.Value)
+		if n := utf8.RuneCountInString(p.Name.Value); n < 1 || n > 100 {
+			errs.Add("name", "名前は1〜100文字で入力してください")
+		}
+	}
+
+	if p.Email.Null {
+		errs.Add("email", "メールアドレスは削除できません")
+	} else if p.Email.Set {
+		p.Email.Value = strings.TrimSpace(p.Email.Value)
+		addr, err := mail.ParseAddress(p.Email.Value)
+		switch {
+		case err != nil || addr.Name != "" || addr.Address != p.Email.Value:
+			errs.Add("email", "メールアドレスの形式が正しくありません")
+		case len(p.Email.Value) > 255:
+			errs.Add("email", "メールアドレスは255文字以内で入力してください")
+		}
+	}
+
+	if p.Status.Null {
+		errs.Add("status", "ステータスは削除できません")
+	} else if p.Status.Set && p.Status.Value != "0" && p.Status.Value != "1" {
+		errs.Add("status", "ステータスは 0（無効）または 1（有効）を指定してください")
+	}
+
+	if p.Profile.Present() {
+		p.Profile.Value.validateInto(errs, "profile.")
+	}
+
+	return errs.Err()
+}
+
+// apply ユーザーの行にパッチを反映する（validate 済みであること）
+func (p *UserPatch) apply(user *model.Users) {
+	if p.Name.Set {
+		user.Name = p.Name.Value
+	}
+	if p.Email.Set {
+		user.Email = p.Email.Value
+	}
+	if p.Status.Set {
+		if p.Status.Value == "1" {
+			user.Status = 1
+		} else {
+			user.Status = 0
+		}
+	}
+}
+
+// empty 変更する項目が無いか
+func (p *ProfilePatch) empty() bool {
+	return *p == ProfilePatch{}
+}
+
+// validate 各項目を検証する
+func (p *ProfilePatch) validate() error {
+	errs := make(mergepatch.FieldErrors)
+	p.validateInto(errs, "")
+	return errs.Err()
+}
+
+func (p *ProfilePatch) validateInto(errs mergepatch.FieldErrors, prefix string) {
+	maxLength := func(field *mergepatch.Field[string], name string, max int) {
+		if field.Present() && utf8.RuneCountInString(field.Value) > max {
+			errs.Add(prefix+name, "文字数が多すぎます")
+		}
+	}
+	maxLength(&p.Phone, "phone", 20)
+	maxLength(&p.Gender, "gender", 10)
+	maxLength(&p.Occupation, "occupation", 100)
+	maxLength(&p.Website, "website", 255)
+
+	if p.Website.Present() && p.Website.Value != "" {
+		if u, err := url.Parse(p.Website.Value); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs.Add(prefix+"website", "http または https の URL を指定してください")
+		}
+	}
+
+	if p.BirthDate.Present() && p.BirthDate.Value != "" {
+		if birthDate, err := time.Parse("2006-01-02", p.BirthDate.Value); err != nil {
+			errs.Add(prefix+"birth_date", "生年月日は YYYY-MM-DD 形式で入力してください")
+		} else if birthDate.After(time.Now()) {
+			errs.Add(prefix+"birth_date", "生年月日に未来の日付は指定できません")
+		}
+	}
+
+	if p.SocialLinks.Present() && p.SocialLinks.Value != "" && !json.Valid([]byte(p.SocialLinks.Value)) {
+		errs.Add(prefix+"social_links", "JSON として正しい形式で入力してください")
+	}
+}
+
+// apply プロフィールの行にパッチを反映する（validate 済みであること）
+// null と空文字はどちらも未設定として保存する
+func (p *ProfilePatch) apply(profile *model.UserProfiles) {
+	text := func(field *mergepatch.Field[string], dst *string) {
+		if field.Set {
+			*dst = field.Value
+		}
+	}
+	text(&p.Bio, &profile.Bio)
+	text(&p.Phone, &profile.Phone)
+	text(&p.Address, &profile.Address)
+	text(&p.Gender, &profile.Gender)
+	text(&p.Occupation, &profile.Occupation)
+	text(&p.Website, &profile.Website)
+
+	if p.BirthDate.Set {
+		profile.BirthDate = sql.NullTime{}
+		if birthDate, err := time.Parse("2006-01-02", p.BirthDate.Value); err == nil {
+			profile.BirthDate = sql.NullTime{Time: birthDate, Valid: true}
+		}
+	}
+
+	// social_links は JSON 列のため未設定は null にする
+	if p.SocialLinks.Set {
+		if p.SocialLinks.Value == "" {
+			profile.SocialLinks = "null"
+		} else {
+			profile.SocialLinks = p.SocialLinks.Value
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"user_service/internal/audit"
+	"user_service/internal/etag"
+	"user_service/internal/mergepatch"
+	"user_service/internal/model"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+
+	"github.com/winyx/backend/common/auth"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type UserPatchLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewUserPatchLogic(ctx context.Context, svcCtx *svc.ServiceContext) *UserPatchLogic {
+	return &UserPatchLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// UserPatch パッチに含まれる項目だけを更新し、更新後のユーザーを返す
+func (l *UserPatchLogic) UserPatch(req *types.UserPatchReq, patch *UserPatch) (resp *types.UserUpdateRes, err error) {
+	if _, err := requireSystemAdmin(l.ctx, l.svcCtx); err != nil {
+		return nil, err
+	}
+	if err := etag.Require(req.IfMatch); err != nil {
+		return nil, err
+	}
+	if err := patch.validate(); err != nil {
+		return nil, err
+	}
+
+	if patch.Email.Set {
+		existing, err := l.svcCtx.UsersModel.FindOneByEmail(l.ctx, patch.Email.Value)
+		switch {
+		case err == nil && int64(existing.Id) != req.UserId:
+			return nil, mergepatch.FieldErrors{"email": "このメールアドレスは既に使用されています"}
+		case err != nil && !errors.Is(err, model.ErrNotFound):
+			return nil, err
+		}
+		held, err := emailHeldByDeletedUser(l.ctx, l.svcCtx, patch.Email.Value)
+		if err != nil {
+			return nil, err
+		}
+		if held {
+			return nil, mergepatch.FieldErrors{"email": errEmailHeldByDeletedUser.Error()}
+		}
+	}
+
+	var roleIds []int64
+	var beforeRoles rolesSnapshot
+	if patch.Roles.Set {
+		// null は全ロールの解除として扱う
+		roleIds, err = resolveRoleIds(l.ctx, l.svcCtx, patch.Roles.Value)
+		var unknown *unknownRoleError
+		switch {
+		case errors.As(err, &unknown):
+			return nil, mergepatch.FieldErrors{"roles": unknown.Error()}
+		case err != nil:
+			return nil, err
+		}
+		if beforeRoles, err = loadRolesSnapshot(l.ctx, l.svcCtx, req.UserId); err != nil {
+			logx.Errorf("Failed to load roles of user %d for audit log: %v", req.UserId, err)
+		}
+	}
+
+	var before, after audit.UserSnapshot
+	assignedBy, _ := auth.UserIdFromContext(l.ctx)
+	err = l.svcCtx.UnitOfWork.Do(l.ctx, func(ctx context.Context, tx *model.Tx) error {
+		user, err := l.svcCtx.UsersModel.LockTx(ctx, tx, uint64(req.UserId))
+		if err != nil {
+			return err
+		}
+		profile, err := l.svcCtx.UserProfilesModel.LockByUserIdTx(ctx, tx, uint64(req.UserId))
+		switch {
+		case errors.Is(err, model.ErrNotFound):
+			profile = nil
+		case err != nil:
+			return err
+		}
+		if !etag.Match(req.IfMatch, etag.User(user, profile)) {
+			return model.ErrVersionConflict
+		}
+		if patch.empty() {
+			return nil
+		}
+
+		// プロフィールだけの変更でもユーザーのバージョンを進め、
+		// プロフィールを削除して作り直した場合に ETag が以前と同じ値に戻らないようにする
+		before = l.svcCtx.Audit.SnapshotUser(user)
+		patch.apply(user)
+		user.UpdatedAt = time.Now()
+		if err := l.svcCtx.UsersModel.UpdateTx(ctx, tx, user); err != nil {
+			return err
+		}
+		after = l.svcCtx.Audit.SnapshotUser(user)
+
+		if patch.Roles.Set {
+			if err := l.svcCtx.UserRolesModel.ReplaceByUserIdTx(ctx, tx, req.UserId, roleIds, assignedBy); err != nil {
+				return fmt.Errorf("failed to replace roles: %w", err)
+			}
+		}
+
+		switch {
+		case patch.Profile.Null:
+			if err := l.svcCtx.UserProfilesModel.DeleteByUserIdTx(ctx, tx, uint64(req.UserId)); err != nil {
+				return fmt.Errorf("failed to delete profile: %w", err)
+			}
+		case patch.Profile.Set:
+			if profile == nil {
+				profile = &model.UserProfiles{
+					UserId:      uint64(req.UserId),
+					SocialLinks: "null",
+					Preferences: "null",
+				}
+			}
+			patch.Profile.Value.apply(profile)
+			if err := l.svcCtx.UserProfilesModel.UpsertTx(ctx, tx, profile); err != nil {
+				return fmt.Errorf("failed to save profile: %w", err)
+			}
+		}
+		return nil
+	})
+	switch {
+	case err == nil:
+	case errors.Is(err, model.ErrVersionConflict):
+		current, err := loadUserInfo(l.ctx, l.svcCtx, req.UserId)
+		if err != nil {
+			return nil, err
+		}
+		return nil, &etag.PreconditionFailedError{ETag: current.Etag, Current: &types.UserUpdateRes{User: *current}}
+	default:
+		l.Errorf("Failed to patch user %d: %v", req.UserId, err)
+		return nil, err
+	}
+
+	if !patch.empty() {
+		l.svcCtx.Audit.Record(l.ctx, audit.Entry{
+			Action:     audit.ActionUserUpdate,
+			TargetType: audit.TargetUser,
+			TargetId:   req.UserId,
+			Before:     before,
+			After:      after,
+		})
+	}
+	if patch.Roles.Set {
+		if afterRoles, err := loadRolesSnapshot(l.ctx, l.svcCtx, req.UserId); err != nil {
+			logx.Errorf("Failed to load roles of user %d for audit log: %v", req.UserId, err)
+		} else {
+			l.svcCtx.Audit.Record(l.ctx, audit.Entry{
+				Action:     audit.ActionUserRolesAssign,
+				TargetType: audit.TargetUser,
+				TargetId:   req.UserId,
+				Before:     beforeRoles,
+				After:      afterRoles,
+			})
+		}
+	}
+
+	userInfo, err := loadUserInfo(l.ctx, l.svcCtx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.UserUpdateRes{
+		User: *userInfo,
+	}, nil
+}
@@ -2,14 +2,19 @@ package logic
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"time"
 
+	"user_service/internal/audit"
+	"user_service/internal/etag"
 	"user_service/internal/model"
 	"user_service/internal/svc"
 	"user_service/internal/types"
 
+	"github.com/winyx/backend/common/auth"
+
 	"github.com/zeromicro/go-zero/core/logx"
 )
 
@@ -28,205 +33,128 @@ func NewUserUpdateLogic(ctx context.Context, svcCtx *svc.ServiceContext) *UserUp
 }
 
 func (l *UserUpdateLogic) UserUpdate(req *types.UserUpdateReq) (resp *types.UserUpdateRes, err error) {
-	// Get existing user
-	existingUser, err := l.svcCtx.UsersModel.FindOne(l.ctx, uint64(req.UserId))
-	if err != nil {
+	if _, err := requireSystemAdmin(l.ctx, l.svcCtx); err != nil {
 		return nil, err
 	}
-	
-	// Update user fields
-	existingUser.Name = req.Name
-	existingUser.Email = req.Email
-	
-	// Parse status if provided (including "0" for inactive)
-	if req.Status != "" {
-		if status, err := strconv.Atoi(req.Status); err == nil {
-			logx.Infof("Updating status for user %d from %d to %d", req.UserId, existingUser.Status, status)
-			existingUser.Status = int8(status)
-		} else {
-			logx.Errorf("Invalid status format for user %d: %s", req.UserId, req.Status)
-		}
-	} else {
-		logx.Infof("No status provided for user %d, keeping current status: %d", req.UserId, existingUser.Status)
-	}
-	
-	// Update in database
-	err = l.svcCtx.UsersModel.Update(l.ctx, existingUser)
-	if err != nil {
+	if err := etag.Require(req.IfMatch); err != nil {
 		return nil, err
 	}
-	
-	// Update user roles if provided (including empty array to remove all roles)
+
+	// Resolve roles if provided (including empty array to remove all roles)
+	var roleIds []int64
+	var beforeRoles rolesSnapshot
 	if req.Roles != nil {
 		logx.Infof("Updating roles for user %d: %v", req.UserId, req.Roles)
-		
-		// Delete existing roles
-		deleteRolesQuery := `DELETE FROM user_roles WHERE user_id = ?`
-		_, err = l.svcCtx.DB.Exec(deleteRolesQuery, req.UserId)
-		if err != nil {
-			logx.Errorf("Failed to delete existing roles: %v", err)
-		} else {
-			logx.Infof("Successfully deleted existing roles for user %d", req.UserId)
-			// Insert new roles (if any)
-			for _, roleName := range req.Roles {
-				// Get role ID
-				var roleId int64
-				getRoleIdQuery := `SELECT id FROM roles WHERE name = ?`
-				err = l.svcCtx.DB.QueryRowPartial(&roleId, getRoleIdQuery, roleName)
-				if err == nil {
-					// Insert user role
-					insertRoleQuery := `INSERT INTO user_roles (user_id, role_id) VALUES (?, ?)`
-					_, err = l.svcCtx.DB.Exec(insertRoleQuery, req.UserId, roleId)
-					if err != nil {
-						logx.Errorf("Failed to insert role %s: %v", roleName, err)
-					} else {
-						logx.Infof("Successfully assigned role %s to user %d", roleName, req.UserId)
-					}
-				} else {
-					logx.Errorf("Role %s not found: %v", roleName, err)
-				}
-			}
+		if roleIds, err = resolveRoleIds(l.ctx, l.svcCtx, req.Roles); err != nil {
+			return nil, err
+		}
+		if beforeRoles, err = loadRolesSnapshot(l.ctx, l.svcCtx, req.UserId); err != nil {
+			logx.Errorf("Failed to load roles of user %d for audit log: %v", req.UserId, err)
 		}
 	} else {
 		logx.Infof("No roles provided for user %d, keeping existing roles", req.UserId)
 	}
-	
-	// Update user profile if provided
-	if req.Profile != nil {
-		logx.Infof("Updating profile for user %d", req.UserId)
-		
-		// Check if profile exists
-		existingProfile, err := l.svcCtx.UserProfilesModel.FindOneByUserId(l.ctx, uint64(req.UserId))
-		profileExists := err == nil && existingProfile != nil
-		
-		logx.Infof("Profile existence check for user %d: exists=%v, error=%v", req.UserId, profileExists, err)
-		
-		if profileExists {
-			// Update existing profile
-			logx.Infof("Updating existing profile for user %d", req.UserId)
-			existingProfile.Bio = req.Profile.Bio
-			existingProfile.Phone = req.Profile.Phone
-			existingProfile.Address = req.Profile.Address
-			existingProfile.Gender = req.Profile.Gender
-			existingProfile.Occupation = req.Profile.Occupation
-			existingProfile.Website = req.Profile.Website
-			// Handle social_links JSON constraint
-			if req.Profile.SocialLinks == "" {
-				existingProfile.SocialLinks = "null"
+
+	// The user, the roles and the profile are updated all or nothing.
+	// The rows are locked while comparing their versions with If-Match so that
+	// a concurrent edit either fails here or waits for this one to finish.
+	var before, after audit.UserSnapshot
+	assignedBy, _ := auth.UserIdFromContext(l.ctx)
+	err = l.svcCtx.UnitOfWork.Do(l.ctx, func(ctx context.Context, tx *model.Tx) error {
+		user, err := l.svcCtx.UsersModel.LockTx(ctx, tx, uint64(req.UserId))
+		if err != nil {
+			return err
+		}
+		profile, err := l.svcCtx.UserProfilesModel.LockByUserIdTx(ctx, tx, uint64(req.UserId))
+		switch {
+		case errors.Is(err, model.ErrNotFound):
+			profile = nil
+		case err != nil:
+			return err
+		}
+		if !etag.Match(req.IfMatch, etag.User(user, profile)) {
+			return model.ErrVersionConflict
+		}
+
+		before = l.svcCtx.Audit.SnapshotUser(user)
+		user.Name = req.Name
+		user.Email = req.Email
+		// Parse status if provided (including "0" for inactive)
+		if req.Status != "" {
+			if status, err := strconv.Atoi(req.Status); err == nil {
+				logx.Infof("Updating status for user %d from %d to %d", req.UserId, user.Status, status)
+				user.Status = int8(status)
 			} else {
-				existingProfile.SocialLinks = req.Profile.SocialLinks
+				logx.Errorf("Invalid status format for user %d: %s", req.UserId, req.Status)
 			}
-			
-			// Parse birth date
-			if req.Profile.BirthDate != "" {
-				if parsedDate, err := time.Parse("2006-01-02", req.Profile.BirthDate); err == nil {
-					existingProfile.BirthDate = parsedDate
-				}
-			}
-			
-			// Update profile in database
-			err = l.svcCtx.UserProfilesModel.Update(l.ctx, existingProfile)
-			if err != nil {
-				logx.Errorf("Failed to update profile: %v", err)
-			} else {
-				logx.Infof("Profile updated successfully for user %d", req.UserId)
+		}
+		user.UpdatedAt = time.Now()
+		if err := l.svcCtx.UsersModel.UpdateTx(ctx, tx, user); err != nil {
+			return err
+		}
+		after = l.svcCtx.Audit.SnapshotUser(user)
+
+		if req.Roles != nil {
+			if err := l.svcCtx.UserRolesModel.ReplaceByUserIdTx(ctx, tx, req.UserId, roleIds, assignedBy); err != nil {
+				return fmt.Errorf("failed to replace roles: %w", err)
 			}
-		} else {
-			// Create new profile using direct model creation
-			logx.Infof("Creating new profile for user %d", req.UserId)
-			
-			// Parse birth date for new profile
-			var birthDate time.Time
-			if req.Profile.BirthDate != "" {
-				if parsedDate, err := time.Parse("2006-01-02", req.Profile.BirthDate); err == nil {
-					birthDate = parsedDate
-				} else {
-					birthDate = time.Now() // fallback
+		}
+
+		if req.Profile != nil {
+			if profile == nil {
+				profile = &model.UserProfiles{
+					UserId:      uint64(req.UserId),
+					Preferences: "null", // PreferencesフィールドもJSON制約があるためnullを設定
 				}
-			} else {
-				birthDate = time.Now() // fallback
-			}
-			
-			// Create new profile model
-			newProfileModel := &model.UserProfiles{
-				UserId:      uint64(req.UserId),
-				Bio:         req.Profile.Bio,
-				Phone:       req.Profile.Phone,
-				Address:     req.Profile.Address,
-				BirthDate:   birthDate,
-				Gender:      req.Profile.Gender,
-				Occupation:  req.Profile.Occupation,
-				Website:     req.Profile.Website,
-				SocialLinks: func() string {
-					if req.Profile.SocialLinks == "" {
-						return "null"
-					}
-					return req.Profile.SocialLinks
-				}(),
-				Preferences: "null", // PreferencesフィールドもJSON制約があるためnullを設定
 			}
-			
-			// Insert profile in database
-			_, err = l.svcCtx.UserProfilesModel.Insert(l.ctx, newProfileModel)
-			if err != nil {
-				logx.Errorf("Failed to create profile: %v", err)
-			} else {
-				logx.Infof("Profile created successfully for user %d", req.UserId)
+			applyProfile(profile, req.Profile)
+			if err := l.svcCtx.UserProfilesModel.UpsertTx(ctx, tx, profile); err != nil {
+				return fmt.Errorf("failed to save profile: %w", err)
 			}
 		}
-	}
-	
-	// Get updated user info
-	updatedUser, err := l.svcCtx.UsersModel.FindOne(l.ctx, uint64(req.UserId))
-	if err != nil {
+		return nil
+	})
+	switch {
+	case err == nil:
+	case errors.Is(err, model.ErrVersionConflict):
+		// Another admin updated the user since it was fetched; return the latest state with 412
+		current, err := loadUserInfo(l.ctx, l.svcCtx, req.UserId)
+		if err != nil {
+			return nil, err
+		}
+		return nil, &etag.PreconditionFailedError{ETag: current.Etag, Current: &types.UserUpdateRes{User: *current}}
+	default:
+		l.Errorf("Failed to update user %d: %v", req.UserId, err)
 		return nil, err
 	}
-	
-	// Get profile
-	var profile *types.UserProfileData
-	userProfile, err := l.svcCtx.UserProfilesModel.FindOneByUserId(l.ctx, uint64(req.UserId))
-	if err == nil && userProfile != nil {
-		profile = &types.UserProfileData{
-			Bio:         userProfile.Bio,
-			Phone:       userProfile.Phone,
-			Address:     userProfile.Address,
-			BirthDate:   userProfile.BirthDate.Format("2006-01-02"),
-			Gender:      userProfile.Gender,
-			Occupation:  userProfile.Occupation,
-			Website:     userProfile.Website,
-			SocialLinks: userProfile.SocialLinks,
+
+	l.svcCtx.Audit.Record(l.ctx, audit.Entry{
+		Action:     audit.ActionUserUpdate,
+		TargetType: audit.TargetUser,
+		TargetId:   req.UserId,
+		Before:     before,
+		After:      after,
+	})
+	if req.Roles != nil {
+		if afterRoles, err := loadRolesSnapshot(l.ctx, l.svcCtx, req.UserId); err != nil {
+			logx.Errorf("Failed to load roles of user %d for audit log: %v", req.UserId, err)
+		} else {
+			l.svcCtx.Audit.Record(l.ctx, audit.Entry{
+				Action:     audit.ActionUserRolesAssign,
+				TargetType: audit.TargetUser,
+				TargetId:   req.UserId,
+				Before:     beforeRoles,
+				After:      afterRoles,
+			})
 		}
 	}
-	
-	// Get user roles for response using raw SQL
-	var roles []string
-	query := `SELECT r.name FROM roles r 
-	          INNER JOIN user_roles ur ON r.id = ur.role_id 
-	          WHERE ur.user_id = ?`
-	
-	err = l.svcCtx.DB.QueryRowsPartial(&roles, query, req.UserId)
+
+	userInfo, err := loadUserInfo(l.ctx, l.svcCtx, req.UserId)
 	if err != nil {
-		logx.Errorf("Failed to get user roles for response: %v", err)
-	}
-	
-	// If no roles found, assign default role
-	if len(roles) == 0 {
-		roles = []string{"user"}
-	}
-	
-	// Build response
-	userInfo := types.UserInfo{
-		UserId:    int64(updatedUser.Id),
-		Name:      updatedUser.Name,
-		Email:     updatedUser.Email,
-		Status:    fmt.Sprintf("%d", updatedUser.Status),
-		Roles:     roles,
-		Profile:   profile,
-		CreatedAt: updatedUser.CreatedAt.Format("2006-01-02 15:04:05"),
-		UpdatedAt: time.Now().Format("2006-01-02 15:04:05"),
+		return nil, err
 	}
-	
+
 	return &types.UserUpdateRes{
-		User: userInfo,
+		User: *userInfo,
 	}, nil
 }
@@ -0,0 +1,136 @@
+package logic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"user_service/internal/dataloader"
+	"user_service/internal/etag"
+	"user_service/internal/model"
+	"user_service/internal/svc"
+	"user_service/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// unknownRoleError 指定されたロールが存在しない
+type unknownRoleError struct {
+	name string
+}
+
+func (e *unknownRoleError) Error() string {
+	return fmt.Sprintf("存在しないロールです: %s", e.name)
+}
+
+// resolveRoleIds ロール名を ID に変換する（重複は除く）
+// 存在しないロールが含まれる場合は付与内容が黙って欠けないようエラーにする
+func resolveRoleIds(ctx context.Context, svcCtx *svc.ServiceContext, names []string) ([]int64, error) {
+	roles, err := svcCtx.RolesModel.FindAllByNames(ctx, names)
+	if err != nil {
+		return nil, err
+	}
+
+	idByName := make(map[string]int64, len(roles))
+	for _, role := range roles {
+		idByName[role.Name] = role.Id
+	}
+
+	ids := make([]int64, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		id, ok := idByName[name]
+		if !ok {
+			return nil, &unknownRoleError{name: name}
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// applyProfile リクエストのプロフィールを行に反映する
+// 生年月日は解釈できた場合のみ上書きする
+func applyProfile(profile *model.UserProfiles, data *types.UserProfileData) {
+	profile.Bio = data.Bio
+	profile.Phone = data.Phone
+	profile.Address = data.Address
+	profile.Gender = data.Gender
+	profile.Occupation = data.Occupation
+	profile.Website = data.Website
+	// social_links は JSON 列のため空文字は null にする
+	if data.SocialLinks == "" {
+		profile.SocialLinks = "null"
+	} else {
+		profile.SocialLinks = data.SocialLinks
+	}
+	if data.BirthDate != "" {
+		if parsedDate, err := time.Parse("2006-01-02", data.BirthDate); err == nil {
+			profile.BirthDate = sql.NullTime{Time: parsedDate, Valid: true}
+		}
+	}
+}
+
+// errEmailHeldByDeletedUser 削除済みのユーザーのメールアドレスは、一意制約のため物理削除されるまで使えない
+var errEmailHeldByDeletedUser = errors.New("削除済みのユーザーが使用しているメールアドレスです。ユーザーを復元するか、物理削除された後に登録してください")
+
+// emailHeldByDeletedUser メールアドレスが復元可能期間中の削除済みユーザーのものか
+func emailHeldByDeletedUser(ctx context.Context, svcCtx *svc.ServiceContext, email string) (bool, error) {
+	_, err := svcCtx.UsersModel.FindDeletedByEmail(ctx, email)
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, model.ErrNotFound):
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+// loadUserInfo 保存済みのユーザー・プロフィール・ロールからレスポンスを組み立てる（ETag を含む）
+func loadUserInfo(ctx context.Context, svcCtx *svc.ServiceContext, userId int64) (*types.UserInfo, error) {
+	user, err := svcCtx.UsersModel.FindOne(ctx, uint64(userId))
+	if err != nil {
+		return nil, err
+	}
+
+	// 更新の前に同じリクエストで読み込んだ値は使わない
+	users := svcCtx.Loaders.Users(ctx)
+	users.Forget(user.Id)
+
+	// Get profile
+	userProfile, err := users.Profile(ctx, user.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get user roles for response
+	roles, err := users.Roles(ctx, user.Id)
+	if err != nil {
+		logx.Errorf("Failed to get user roles for response: %v", err)
+	}
+
+	// If no roles found, assign default role
+	if len(roles) == 0 {
+		roles = []string{"user"}
+	}
+
+	return &types.UserInfo{
+		UserId:    int64(user.Id),
+		Name:      user.Name,
+		Email:     user.Email,
+		Status:    fmt.Sprintf("%d", user.Status),
+		Roles:     roles,
+		Profile:   dataloader.ProfileData(userProfile),
+		CreatedAt: user.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt: user.UpdatedAt.Format("2006-01-02 15:04:05"),
+		Etag:      etag.User(user, userProfile),
+	}, nil
+}
@@ -0,0 +1,195 @@
+// Package mergepatch は JSON Merge Patch（RFC 7396）形式の部分更新リクエストを扱う
+//
+// パッチは Field を並べた構造体で表す。ボディに含まれた項目だけが Set になり、
+// 明示的な null は Null として区別されるため、「送られていない」と「消去する」を取り違えない。
+package mergepatch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"user_service/internal/etag"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+// ContentType RFC 7396 のメディアタイプ。application/json も同じ扱いで受け付ける
+const ContentType = "application/merge-patch+json"
+
+// maxBodySize パッチとして受け付けるボディの上限
+const maxBodySize = 1 << 20
+
+var (
+	// ErrUnsupportedMediaType Content-Type がパッチとして扱えない
+	ErrUnsupportedMediaType = errors.New("Content-Type は application/merge-patch+json を指定してください")
+	// ErrInvalidDocument ボディが JSON オブジェクトではない
+	ErrInvalidDocument = errors.New("リクエストボディは JSON オブジェクトで指定してください")
+)
+
+// Field パッチの 1 項目
+// Set はボディに含まれていたか、Null は明示的に null が指定されたかを表す。
+// T が構造体の場合は入れ子のパッチとして扱い、その中の項目も同じ規則で読み込む。
+type Field[T any] struct {
+	Set   bool
+	Null  bool
+	Value T
+}
+
+// Present 値が指定されている（null 以外で送られた）か
+func (f *Field[T]) Present() bool {
+	return f.Set && !f.Null
+}
+
+func (f *Field[T]) decode(raw json.RawMessage, path string, errs FieldErrors) {
+	f.Set = true
+	if isNull(raw) {
+		f.Null = true
+		return
+	}
+
+	value := reflect.ValueOf(&f.Value).Elem()
+	if value.Kind() == reflect.Struct {
+		decodeObject(raw, value, path+".", errs)
+		return
+	}
+	if err := json.Unmarshal(raw, &f.Value); err != nil {
+		errs.Add(path, "値の型が正しくありません")
+	}
+}
+
+// fieldDecoder Field[T] を型引数によらず扱うためのインターフェース
+type fieldDecoder interface {
+	decode(raw json.RawMessage, path string, errs FieldErrors)
+}
+
+// FieldErrors 項目ごとの検証エラー（キーは profile.bio のような項目のパス）
+type FieldErrors map[string]string
+
+// Add 項目のエラーを追加する。同じ項目には最初のエラーだけを残す
+func (e FieldErrors) Add(field, message string) {
+	if _, ok := e[field]; !ok {
+		e[field] = message
+	}
+}
+
+// Err エラーが無ければ nil を返す
+func (e FieldErrors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+func (e FieldErrors) Error() string {
+	fields := make([]string, 0, len(e))
+	for field := range e {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	parts := make([]string, len(fields))
+	for i, field := range fields {
+		parts[i] = field + ": " + e[field]
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Decode リクエストボディを dst（Field を並べた構造体へのポインタ）に読み込む
+// 構造体に無い項目や型の合わない値は FieldErrors として返す。
+func Decode(r *http.Request, dst any) error {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || (mediaType != ContentType && mediaType != "application/json") {
+		return ErrUnsupportedMediaType
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize+1))
+	if err != nil {
+		return err
+	}
+	if len(body) > maxBodySize {
+		return ErrInvalidDocument
+	}
+
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(body, &doc); err != nil || doc == nil {
+		return ErrInvalidDocument
+	}
+
+	errs := make(FieldErrors)
+	decodeFields(doc, reflect.ValueOf(dst).Elem(), "", errs)
+	return errs.Err()
+}
+
+func decodeObject(raw json.RawMessage, dst reflect.Value, prefix string, errs FieldErrors) {
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		errs.Add(strings.TrimSuffix(prefix, "."), "オブジェクトで指定してください")
+		return
+	}
+	decodeFields(doc, dst, prefix, errs)
+}
+
+func decodeFields(doc map[string]json.RawMessage, dst reflect.Value, prefix string, errs FieldErrors) {
+	fields := make(map[string]fieldDecoder, dst.NumField())
+	for i := 0; i < dst.NumField(); i++ {
+		name, _, _ := strings.Cut(dst.Type().Field(i).Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		if field, ok := dst.Field(i).Addr().Interface().(fieldDecoder); ok {
+			fields[name] = field
+		}
+	}
+
+	for name, raw := range doc {
+		field, ok := fields[name]
+		if !ok {
+			errs.Add(prefix+name, "更新できない項目です")
+			continue
+		}
+		field.decode(raw, prefix+name, errs)
+	}
+}
+
+func isNull(raw json.RawMessage) bool {
+	return string(raw) == "null"
+}
+
+type errorRes struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Fields  FieldErrors `json:"fields,omitempty"`
+}
+
+// WriteError パッチの読み込み・検証エラーを 400 / 415 / 422 で返す
+// 条件付き更新のエラーを含め、それ以外は etag.WriteError に任せる。
+func WriteError(ctx context.Context, w http.ResponseWriter, err error) {
+	var fieldErrs FieldErrors
+	switch {
+	case errors.As(err, &fieldErrs):
+		httpx.WriteJsonCtx(ctx, w, http.StatusUnprocessableEntity, errorRes{
+			Code:    http.StatusUnprocessableEntity,
+			Message: "入力内容に誤りがあります",
+			Fields:  fieldErrs,
+		})
+	case errors.Is(err, ErrUnsupportedMediaType):
+		httpx.WriteJsonCtx(ctx, w, http.StatusUnsupportedMediaType, errorRes{
+			Code:    http.StatusUnsupportedMediaType,
+			Message: err.Error(),
+		})
+	case errors.Is(err, ErrInvalidDocument):
+		httpx.WriteJsonCtx(ctx, w, http.StatusBadRequest, errorRes{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		})
+	default:
+		etag.WriteError(ctx, w, err)
+	}
+}
@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"net/http"
+
+	"user_service/internal/svc"
+
+	"github.com/winyx/backend/common/auth"
+	"github.com/winyx/backend/common/maintenance"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+const adminRole = "admin"
+
+type MaintenanceMiddleware struct {
+	*maintenance.Middleware
+}
+
+// NewMaintenanceMiddleware メンテナンス期間中は admin ロールのユーザー以外に 503 を返す
+// 管理者の判定は JWT のクレームを使うため、ログイン等の認証前 API は AllowPaths で許可する
+func NewMaintenanceMiddleware(svcCtx *svc.ServiceContext) *MaintenanceMiddleware {
+	isAdmin := func(r *http.Request) bool {
+		userId, ok := auth.UserIdFromContext(r.Context())
+		if !ok {
+			return false
+		}
+
+		admin, err := svcCtx.UserRolesModel.CheckUserRole(r.Context(), userId, adminRole)
+		if err != nil {
+			logx.WithContext(r.Context()).Errorf("管理者ロールの確認に失敗しました: %v", err)
+			return false
+		}
+
+		return admin
+	}
+
+	return &MaintenanceMiddleware{
+		Middleware: maintenance.NewMiddleware(svcCtx.Maintenance, svcCtx.Config.Maintenance, isAdmin),
+	}
+}
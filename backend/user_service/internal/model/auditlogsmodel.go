@@ -0,0 +1,194 @@
+package model
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+// AuditGenesisHash is the prev_hash of the first audit log entry
+var AuditGenesisHash = strings.Repeat("0", 64)
+
+var _ AuditLogsModel = (*customAuditLogsModel)(nil)
+
+type (
+	// AuditLogsModel is an interface to be customized, add more methods here,
+	// and implement the added methods in customAuditLogsModel.
+	// The table is append-only, so Update and Delete of the generated model are not exposed.
+	AuditLogsModel interface {
+		FindOne(ctx context.Context, id uint64) (*AuditLogs, error)
+		Append(ctx context.Context, data *AuditLogs) error
+		Search(ctx context.Context, filter AuditLogFilter) ([]*AuditLogs, error)
+		FindAfter(ctx context.Context, afterId uint64, limit int) ([]*AuditLogs, error)
+		ChainHead(ctx context.Context) (lastId uint64, lastHash string, err error)
+	}
+
+	customAuditLogsModel struct {
+		*defaultAuditLogsModel
+	}
+
+	// AuditLogFilter narrows Search. Zero values are ignored.
+	// Results are ordered newest first; Page is the keyset position (only its Id is used).
+	AuditLogFilter struct {
+		ActorUserId uint64
+		OrgId       uint64
+		Action      string
+		TargetType  string
+		TargetId    string
+		From        time.Time
+		To          time.Time
+		Page        KeysetPage
+		Limit       int
+	}
+)
+
+// NewAuditLogsModel returns a model for the database table.
+// Audit logs are written once and read rarely, so they are not cached.
+func NewAuditLogsModel(conn sqlx.SqlConn) AuditLogsModel {
+	return &customAuditLogsModel{
+		defaultAuditLogsModel: newAuditLogsModel(conn),
+	}
+}
+
+// Append inserts an entry at the end of the hash chain and sets its Id, PrevHash and Hash.
+// The chain head row is locked for the duration of the transaction so concurrent writers are serialized.
+func (m *customAuditLogsModel) Append(ctx context.Context, data *AuditLogs) error {
+	data.OccurredAt = data.OccurredAt.Truncate(time.Microsecond)
+
+	return m.conn.TransactCtx(ctx, func(ctx context.Context, session sqlx.Session) error {
+		var head struct {
+			LastId   uint64 `db:"last_id"`
+			LastHash string `db:"last_hash"`
+		}
+		if err := session.QueryRowCtx(ctx, &head, "select `last_id`, `last_hash` from `audit_log_chain` where `id` = 1 for update"); err != nil {
+			return fmt.Errorf("lock audit log chain: %w", err)
+		}
+
+		data.PrevHash = head.LastHash
+		data.Hash = data.ComputeHash()
+
+		query := fmt.Sprintf("insert into %s (%s) values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)", m.table, auditLogsRowsExpectAutoSet)
+		result, err := session.ExecCtx(ctx, query, data.ActorUserId, data.ActorApiKeyId, data.OrgId, data.Action, data.TargetType,
+			data.TargetId, data.Diff, data.Ip, data.UserAgent, data.RequestId, data.PrevHash, data.Hash, data.OccurredAt)
+		if err != nil {
+			return err
+		}
+		id, err := result.LastInsertId()
+		if err != nil {
+			return err
+		}
+		data.Id = uint64(id)
+
+		_, err = session.ExecCtx(ctx, "update `audit_log_chain` set `last_id` = ?, `last_hash` = ? where `id` = 1", data.Id, data.Hash)
+		return err
+	})
+}
+
+// Search retrieves entries matching the filter, newest first
+func (m *customAuditLogsModel) Search(ctx context.Context, filter AuditLogFilter) ([]*AuditLogs, error) {
+	var (
+		conds []string
+		args  []any
+	)
+	if filter.ActorUserId > 0 {
+		conds = append(conds, "`actor_user_id` = ?")
+		args = append(args, filter.ActorUserId)
+	}
+	if filter.OrgId > 0 {
+		conds = append(conds, "`org_id` = ?")
+		args = append(args, filter.OrgId)
+	}
+	if filter.Action != "" {
+		conds = append(conds, "`action` = ?")
+		args = append(args, filter.Action)
+	}
+	if filter.TargetType != "" {
+		conds = append(conds, "`target_type` = ?")
+		args = append(args, filter.TargetType)
+	}
+	if filter.TargetId != "" {
+		conds = append(conds, "`target_id` = ?")
+		args = append(args, filter.TargetId)
+	}
+	if !filter.From.IsZero() {
+		conds = append(conds, "`occurred_at` >= ?")
+		args = append(args, filter.From)
+	}
+	if !filter.To.IsZero() {
+		conds = append(conds, "`occurred_at` < ?")
+		args = append(args, filter.To)
+	}
+	desc := filter.Page.orderDirection(true)
+	if filter.Page.After != nil {
+		cond, condArgs := keysetCond("", false, desc, *filter.Page.After)
+		conds = append(conds, cond)
+		args = append(args, condArgs...)
+	}
+
+	where := ""
+	if len(conds) > 0 {
+		where = "where " + strings.Join(conds, " and ")
+	}
+	direction := "desc"
+	if !desc {
+		direction = "asc"
+	}
+	query := fmt.Sprintf("select %s from %s %s order by `id` %s limit ?", auditLogsRows, m.table, where, direction)
+	args = append(args, filter.Limit)
+
+	var logs []*AuditLogs
+	if err := m.conn.QueryRowsCtx(ctx, &logs, query, args...); err != nil {
+		return nil, err
+	}
+	return restoreOrder(filter.Page, logs), nil
+}
+
+// FindAfter retrieves up to limit entries following afterId in chain order
+func (m *customAuditLogsModel) FindAfter(ctx context.Context, afterId uint64, limit int) ([]*AuditLogs, error) {
+	var logs []*AuditLogs
+	query := fmt.Sprintf("select %s from %s where `id` > ? order by `id` limit ?", auditLogsRows, m.table)
+	if err := m.conn.QueryRowsCtx(ctx, &logs, query, afterId, limit); err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
+
+// ChainHead returns the id and hash of the last appended entry
+func (m *customAuditLogsModel) ChainHead(ctx context.Context) (uint64, string, error) {
+	var head struct {
+		LastId   uint64 `db:"last_id"`
+		LastHash string `db:"last_hash"`
+	}
+	if err := m.conn.QueryRowCtx(ctx, &head, "select `last_id`, `last_hash` from `audit_log_chain` where `id` = 1"); err != nil {
+		return 0, "", err
+	}
+	return head.LastId, head.LastHash, nil
+}
+
+// ComputeHash returns the chain hash of the entry: SHA-256 over prev_hash and every recorded column.
+// The id is left out because it is assigned by the database after the hash is computed.
+func (a *AuditLogs) ComputeHash() string {
+	// JSON array encoding keeps field boundaries unambiguous
+	payload, _ := json.Marshal([]any{
+		a.PrevHash,
+		a.OccurredAt.UnixMicro(),
+		a.ActorUserId,
+		a.ActorApiKeyId,
+		a.OrgId,
+		a.Action,
+		a.TargetType,
+		a.TargetId,
+		a.Diff,
+		a.Ip,
+		a.UserAgent,
+		a.RequestId,
+	})
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:])
+}
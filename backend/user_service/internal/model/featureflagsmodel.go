@@ -0,0 +1,66 @@
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/winyx/backend/common/featureflag"
+
+	"github.com/zeromicro/go-zero/core/stores/cache"
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+var _ FeatureFlagsModel = (*customFeatureFlagsModel)(nil)
+
+type (
+	// FeatureFlagsModel is an interface to be customized, add more methods here,
+	// and implement the added methods in customFeatureFlagsModel.
+	FeatureFlagsModel interface {
+		featureFlagsModel
+		FindAll(ctx context.Context) ([]*FeatureFlags, error)
+	}
+
+	customFeatureFlagsModel struct {
+		*defaultFeatureFlagsModel
+	}
+)
+
+// NewFeatureFlagsModel returns a model for the database table.
+func NewFeatureFlagsModel(conn sqlx.SqlConn, c cache.CacheConf, opts ...cache.Option) FeatureFlagsModel {
+	m := newFeatureFlagsModel(conn, c, opts...)
+	m.CachedConn = newCachedConn(conn, c, "feature_flags", opts...)
+
+	return &customFeatureFlagsModel{
+		defaultFeatureFlagsModel: m,
+	}
+}
+
+// FindAll retrieves all feature flags ordered by key
+func (m *customFeatureFlagsModel) FindAll(ctx context.Context) ([]*FeatureFlags, error) {
+	var flags []*FeatureFlags
+	query := fmt.Sprintf("select %s from %s order by `flag_key`", featureFlagsRows, m.table)
+	err := m.QueryRowsNoCacheCtx(ctx, &flags, query)
+	if err != nil {
+		return nil, err
+	}
+	return flags, nil
+}
+
+// ToFlag converts the row into the SDK representation
+func (f *FeatureFlags) ToFlag() (featureflag.Flag, error) {
+	var rules featureflag.Rules
+	if f.Rules != "" {
+		if err := json.Unmarshal([]byte(f.Rules), &rules); err != nil {
+			return featureflag.Flag{}, fmt.Errorf("invalid rules for flag %s: %w", f.FlagKey, err)
+		}
+	}
+
+	return featureflag.Flag{
+		Key:               f.FlagKey,
+		Description:       f.Description,
+		Enabled:           f.Enabled == 1,
+		Rules:             rules,
+		RolloutPercentage: int(f.RolloutPercentage),
+	}, nil
+}
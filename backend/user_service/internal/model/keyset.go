@@ -0,0 +1,57 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Keyset is a position in a listing ordered by (sort column, id), used for keyset pagination.
+// Value is the sort column of the row at the position (nil for NULL); it is ignored for listings ordered by id only.
+type Keyset struct {
+	Value any
+	Id    uint64
+}
+
+// KeysetPage selects the rows next to After instead of using an offset.
+// With Backward the rows before After are selected; they are still returned in listing order.
+type KeysetPage struct {
+	After    *Keyset
+	Backward bool
+}
+
+// keysetCond returns the condition selecting the rows after k in the order "column dir, id dir".
+// column is empty for listings ordered by id only. MySQL sorts NULL first, so for a nullable
+// column NULLs come before every value in ascending order and after every value in descending order.
+func keysetCond(column string, nullable, desc bool, k Keyset) (string, []any) {
+	cmp := ">"
+	if desc {
+		cmp = "<"
+	}
+	if column == "" {
+		return fmt.Sprintf("`id` %s ?", cmp), []any{k.Id}
+	}
+
+	switch {
+	case nullable && k.Value == nil && !desc:
+		return fmt.Sprintf("((%s is null and `id` > ?) or %s is not null)", column, column), []any{k.Id}
+	case nullable && k.Value == nil && desc:
+		return fmt.Sprintf("(%s is null and `id` < ?)", column), []any{k.Id}
+	case nullable && desc:
+		return fmt.Sprintf("(%s < ? or (%s = ? and `id` < ?) or %s is null)", column, column, column), []any{k.Value, k.Value, k.Id}
+	default:
+		return fmt.Sprintf("(%s %s ? or (%s = ? and `id` %s ?))", column, cmp, column, cmp), []any{k.Value, k.Value, k.Id}
+	}
+}
+
+// orderDirection returns the direction of the query: a backward page reads the listing in reverse
+func (p KeysetPage) orderDirection(desc bool) bool {
+	return desc != p.Backward
+}
+
+// restore puts the rows of a backward page back into listing order
+func restoreOrder[T any](p KeysetPage, rows []T) []T {
+	if p.Backward {
+		slices.Reverse(rows)
+	}
+	return rows
+}
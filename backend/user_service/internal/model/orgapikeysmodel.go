@@ -0,0 +1,83 @@
+package model
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/stores/cache"
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+var _ OrgApiKeysModel = (*customOrgApiKeysModel)(nil)
+
+type (
+	// OrgApiKeysModel is an interface to be customized, add more methods here,
+	// and implement the added methods in customOrgApiKeysModel.
+	OrgApiKeysModel interface {
+		orgApiKeysModel
+		FindActiveByOrgId(ctx context.Context, orgId uint64) ([]*OrgApiKeys, error)
+		Revoke(ctx context.Context, data *OrgApiKeys) (bool, error)
+		TouchLastUsed(ctx context.Context, data *OrgApiKeys, usedAt time.Time, interval time.Duration) error
+	}
+
+	customOrgApiKeysModel struct {
+		*defaultOrgApiKeysModel
+	}
+)
+
+// NewOrgApiKeysModel returns a model for the database table.
+func NewOrgApiKeysModel(conn sqlx.SqlConn, c cache.CacheConf, opts ...cache.Option) OrgApiKeysModel {
+	m := newOrgApiKeysModel(conn, c, opts...)
+	m.CachedConn = newCachedConn(conn, c, "org_api_keys", opts...)
+
+	return &customOrgApiKeysModel{
+		defaultOrgApiKeysModel: m,
+	}
+}
+
+// FindActiveByOrgId retrieves the unrevoked API keys of an organization, including expired ones
+func (m *customOrgApiKeysModel) FindActiveByOrgId(ctx context.Context, orgId uint64) ([]*OrgApiKeys, error) {
+	var keys []*OrgApiKeys
+	query := fmt.Sprintf("select %s from %s where `org_id` = ? and `revoked_at` is null order by `created_at` desc",
+		orgApiKeysRows, m.table)
+	err := m.QueryRowsNoCacheCtx(ctx, &keys, query, orgId)
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// Revoke marks an API key as revoked. It reports false when the key was already revoked.
+func (m *customOrgApiKeysModel) Revoke(ctx context.Context, data *OrgApiKeys) (bool, error) {
+	orgApiKeysIdKey := fmt.Sprintf("%s%v", cacheOrgApiKeysIdPrefix, data.Id)
+	orgApiKeysPrefixKey := fmt.Sprintf("%s%v", cacheOrgApiKeysPrefixPrefix, data.Prefix)
+	result, err := m.ExecCtx(ctx, func(ctx context.Context, conn sqlx.SqlConn) (sql.Result, error) {
+		query := fmt.Sprintf("update %s set `revoked_at` = ? where `id` = ? and `revoked_at` is null", m.table)
+		return conn.ExecCtx(ctx, query, time.Now(), data.Id)
+	}, orgApiKeysIdKey, orgApiKeysPrefixKey)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// TouchLastUsed records the last use of an API key.
+// Writes are throttled to once per interval so that every authenticated request does not hit the database.
+func (m *customOrgApiKeysModel) TouchLastUsed(ctx context.Context, data *OrgApiKeys, usedAt time.Time, interval time.Duration) error {
+	if data.LastUsedAt.Valid && usedAt.Sub(data.LastUsedAt.Time) < interval {
+		return nil
+	}
+
+	orgApiKeysIdKey := fmt.Sprintf("%s%v", cacheOrgApiKeysIdPrefix, data.Id)
+	_, err := m.ExecCtx(ctx, func(ctx context.Context, conn sqlx.SqlConn) (sql.Result, error) {
+		query := fmt.Sprintf("update %s set `last_used_at` = ? where `id` = ? and (`last_used_at` is null or `last_used_at` < ?)", m.table)
+		return conn.ExecCtx(ctx, query, usedAt, data.Id, usedAt.Add(-interval))
+	}, orgApiKeysIdKey)
+	return err
+}
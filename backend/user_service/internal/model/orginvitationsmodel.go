@@ -0,0 +1,116 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/stores/cache"
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+// 招待の状態
+const (
+	InvitationStatusPending  = "pending"
+	InvitationStatusAccepted = "accepted"
+	InvitationStatusDeclined = "declined"
+	InvitationStatusRevoked  = "revoked"
+	InvitationStatusExpired  = "expired"
+)
+
+var _ OrgInvitationsModel = (*customOrgInvitationsModel)(nil)
+
+type (
+	// OrgInvitationsModel is an interface to be customized, add more methods here,
+	// and implement the added methods in customOrgInvitationsModel.
+	OrgInvitationsModel interface {
+		orgInvitationsModel
+		FindPendingByOrgId(ctx context.Context, orgId uint64) ([]*OrgInvitations, error)
+		Transition(ctx context.Context, data *OrgInvitations, status string) (bool, error)
+		Accept(ctx context.Context, data *OrgInvitations, userId uint64) (bool, error)
+	}
+
+	customOrgInvitationsModel struct {
+		*defaultOrgInvitationsModel
+	}
+)
+
+// NewOrgInvitationsModel returns a model for the database table.
+func NewOrgInvitationsModel(conn sqlx.SqlConn, c cache.CacheConf, opts ...cache.Option) OrgInvitationsModel {
+	m := newOrgInvitationsModel(conn, c, opts...)
+	m.CachedConn = newCachedConn(conn, c, "org_invitations", opts...)
+
+	return &customOrgInvitationsModel{
+		defaultOrgInvitationsModel: m,
+	}
+}
+
+// FindPendingByOrgId retrieves the unexpired pending invitations of an organization
+func (m *customOrgInvitationsModel) FindPendingByOrgId(ctx context.Context, orgId uint64) ([]*OrgInvitations, error) {
+	var invitations []*OrgInvitations
+	query := fmt.Sprintf("select %s from %s where `org_id` = ? and `status` = ? and `expires_at` > ? order by `created_at` desc",
+		orgInvitationsRows, m.table)
+	err := m.QueryRowsNoCacheCtx(ctx, &invitations, query, orgId, InvitationStatusPending, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return invitations, nil
+}
+
+// Transition moves a pending invitation to another status.
+// It reports false when the invitation was no longer pending, so each token can be used only once.
+func (m *customOrgInvitationsModel) Transition(ctx context.Context, data *OrgInvitations, status string) (bool, error) {
+	var affected int64
+	err := m.TransactCtx(ctx, func(ctx context.Context, session sqlx.Session) error {
+		var err error
+		affected, err = m.transition(ctx, session, data, status)
+		return err
+	})
+	if err != nil {
+		return false, err
+	}
+	if err := m.delInvitationCache(ctx, data); err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// Accept marks a pending invitation as accepted and adds the user to the organization in one transaction.
+// Users who are already members keep their current role.
+func (m *customOrgInvitationsModel) Accept(ctx context.Context, data *OrgInvitations, userId uint64) (bool, error) {
+	var affected int64
+	err := m.TransactCtx(ctx, func(ctx context.Context, session sqlx.Session) error {
+		var err error
+		affected, err = m.transition(ctx, session, data, InvitationStatusAccepted)
+		if err != nil || affected == 0 {
+			return err
+		}
+
+		query := fmt.Sprintf("insert into `org_members` (%s) select ?, ?, ? from dual "+
+			"where not exists (select 1 from `org_members` where `org_id` = ? and `user_id` = ?)", orgMembersRowsExpectAutoSet)
+		_, err = session.ExecCtx(ctx, query, data.OrgId, userId, data.RoleId, data.OrgId, userId)
+		return err
+	})
+	if err != nil {
+		return false, err
+	}
+	if err := m.delInvitationCache(ctx, data); err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+func (m *customOrgInvitationsModel) transition(ctx context.Context, session sqlx.Session, data *OrgInvitations, status string) (int64, error) {
+	query := fmt.Sprintf("update %s set `status` = ?, `responded_at` = ? where `id` = ? and `status` = ?", m.table)
+	result, err := session.ExecCtx(ctx, query, status, time.Now(), data.Id, InvitationStatusPending)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (m *customOrgInvitationsModel) delInvitationCache(ctx context.Context, data *OrgInvitations) error {
+	return m.DelCacheCtx(ctx,
+		fmt.Sprintf("%s%v", cacheOrgInvitationsIdPrefix, data.Id),
+		fmt.Sprintf("%s%v", cacheOrgInvitationsTokenHashPrefix, data.TokenHash))
+}
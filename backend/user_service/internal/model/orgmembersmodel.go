@@ -1,17 +1,32 @@
 package model
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/zeromicro/go-zero/core/stores/cache"
+	"github.com/zeromicro/go-zero/core/stores/sqlc"
 	"github.com/zeromicro/go-zero/core/stores/sqlx"
 )
 
 var _ OrgMembersModel = (*customOrgMembersModel)(nil)
 
+// activeMember excludes memberships of soft-deleted users, which are kept until the user is purged
+const activeMember = "`user_id` in (select `id` from `users` where `deleted_at` is null)"
+
 type (
 	// OrgMembersModel is an interface to be customized, add more methods here,
 	// and implement the added methods in customOrgMembersModel.
 	OrgMembersModel interface {
 		orgMembersModel
+		FindByUserId(ctx context.Context, userId uint64) ([]*OrgMembers, error)
+		FindByOrgId(ctx context.Context, orgId uint64) ([]*OrgMembers, error)
+		FindOneByOrgIdUserId(ctx context.Context, orgId, userId uint64) (*OrgMembers, error)
+		CountByOrgIdRoleId(ctx context.Context, orgId uint64, roleId int64) (int64, error)
+		FindByTenant(ctx context.Context) ([]*OrgMembers, error)
+		FindPageByTenant(ctx context.Context, page KeysetPage, limit int) ([]*OrgMembers, error)
+		InsertTx(ctx context.Context, tx *Tx, data *OrgMembers) error
+		DeleteByUserIdTx(ctx context.Context, tx *Tx, userId uint64) error
 	}
 
 	customOrgMembersModel struct {
@@ -21,7 +36,134 @@ type (
 
 // NewOrgMembersModel returns a model for the database table.
 func NewOrgMembersModel(conn sqlx.SqlConn, c cache.CacheConf, opts ...cache.Option) OrgMembersModel {
+	m := newOrgMembersModel(conn, c, opts...)
+	m.CachedConn = newCachedConn(conn, c, "org_members", opts...)
+
 	return &customOrgMembersModel{
-		defaultOrgMembersModel: newOrgMembersModel(conn, c, opts...),
+		defaultOrgMembersModel: m,
+	}
+}
+
+// FindByUserId retrieves the memberships of a user
+func (m *customOrgMembersModel) FindByUserId(ctx context.Context, userId uint64) ([]*OrgMembers, error) {
+	var members []*OrgMembers
+	query := fmt.Sprintf("select %s from %s where `user_id` = ?", orgMembersRows, m.table)
+	err := m.QueryRowsNoCacheCtx(ctx, &members, query, userId)
+	if err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+// FindByOrgId retrieves the members of an organization
+func (m *customOrgMembersModel) FindByOrgId(ctx context.Context, orgId uint64) ([]*OrgMembers, error) {
+	var members []*OrgMembers
+	query := fmt.Sprintf("select %s from %s where `org_id` = ? order by `created_at`", orgMembersRows, m.table)
+	err := m.QueryRowsNoCacheCtx(ctx, &members, query, orgId)
+	if err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+// FindOneByOrgIdUserId retrieves the membership of a user in an organization
+func (m *customOrgMembersModel) FindOneByOrgIdUserId(ctx context.Context, orgId, userId uint64) (*OrgMembers, error) {
+	var member OrgMembers
+	query := fmt.Sprintf("select %s from %s where `org_id` = ? and `user_id` = ? limit 1", orgMembersRows, m.table)
+	err := m.QueryRowNoCacheCtx(ctx, &member, query, orgId, userId)
+	switch err {
+	case nil:
+		return &member, nil
+	case sqlc.ErrNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, err
+	}
+}
+
+// CountByOrgIdRoleId counts the members holding a role in an organization
+func (m *customOrgMembersModel) CountByOrgIdRoleId(ctx context.Context, orgId uint64, roleId int64) (int64, error) {
+	var count int64
+	query := fmt.Sprintf("select count(*) from %s where `org_id` = ? and `role_id` = ?", m.table)
+	err := m.QueryRowNoCacheCtx(ctx, &count, query, orgId, roleId)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// FindByTenant retrieves the members of the organization of the request's tenant, skipping soft-deleted users
+func (m *customOrgMembersModel) FindByTenant(ctx context.Context) ([]*OrgMembers, error) {
+	scope, err := TenantScopeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var members []*OrgMembers
+	where, args := scope.Where(activeMember)
+	query := fmt.Sprintf("select %s from %s where %s order by `created_at`", orgMembersRows, m.table, where)
+	err = m.QueryRowsNoCacheCtx(ctx, &members, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+// FindPageByTenant retrieves a page of the members of the request's tenant in the order of FindByTenant
+func (m *customOrgMembersModel) FindPageByTenant(ctx context.Context, page KeysetPage, limit int) ([]*OrgMembers, error) {
+	scope, err := TenantScopeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	desc := page.orderDirection(false)
+	direction := "asc"
+	if desc {
+		direction = "desc"
+	}
+
+	cond := activeMember
+	var condArgs []any
+	if page.After != nil {
+		var keyset string
+		keyset, condArgs = keysetCond("`created_at`", false, desc, *page.After)
+		cond += " and " + keyset
+	}
+	where, args := scope.Where(cond, condArgs...)
+	query := fmt.Sprintf("select %s from %s where %s order by `created_at` %s, `id` %s limit ?", orgMembersRows, m.table, where, direction, direction)
+	args = append(args, limit)
+
+	var members []*OrgMembers
+	if err := m.QueryRowsNoCacheCtx(ctx, &members, query, args...); err != nil {
+		return nil, err
+	}
+	return restoreOrder(page, members), nil
+}
+
+// InsertTx adds a member in the transaction. The new row has no cached keys to evict.
+func (m *customOrgMembersModel) InsertTx(ctx context.Context, tx *Tx, data *OrgMembers) error {
+	query := fmt.Sprintf("insert into %s (%s) values (?, ?, ?)", m.table, orgMembersRowsExpectAutoSet)
+	_, err := tx.session.ExecCtx(ctx, query, data.OrgId, data.UserId, data.RoleId)
+	return err
+}
+
+// DeleteByUserIdTx deletes the memberships of a user in the transaction
+func (m *customOrgMembersModel) DeleteByUserIdTx(ctx context.Context, tx *Tx, userId uint64) error {
+	var ids []uint64
+	if err := tx.session.QueryRowsCtx(ctx, &ids, fmt.Sprintf("select `id` from %s where `user_id` = ? for update", m.table), userId); err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	if _, err := tx.session.ExecCtx(ctx, fmt.Sprintf("delete from %s where `user_id` = ?", m.table), userId); err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(ids))
+	for _, id := range ids {
+		keys = append(keys, fmt.Sprintf("%s%v", cacheOrgMembersIdPrefix, id))
 	}
+	tx.evictAfterCommit(m, keys...)
+	return nil
 }
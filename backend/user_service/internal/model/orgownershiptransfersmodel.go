@@ -0,0 +1,148 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/stores/cache"
+	"github.com/zeromicro/go-zero/core/stores/sqlc"
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+// 所有権移譲依頼の状態
+const (
+	TransferStatusPending   = "pending"
+	TransferStatusAccepted  = "accepted"
+	TransferStatusDeclined  = "declined"
+	TransferStatusCancelled = "cancelled"
+	TransferStatusExpired   = "expired"
+)
+
+// ErrOwnerChanged 依頼後にオーナーが変わっていた（移譲は行わない）
+var ErrOwnerChanged = errors.New("organization owner has changed since the transfer was requested")
+
+var _ OrgOwnershipTransfersModel = (*customOrgOwnershipTransfersModel)(nil)
+
+type (
+	// OrgOwnershipTransfersModel is an interface to be customized, add more methods here,
+	// and implement the added methods in customOrgOwnershipTransfersModel.
+	OrgOwnershipTransfersModel interface {
+		orgOwnershipTransfersModel
+		FindPendingByOrgId(ctx context.Context, orgId uint64) (*OrgOwnershipTransfers, error)
+		Transition(ctx context.Context, data *OrgOwnershipTransfers, status string) (bool, error)
+		Complete(ctx context.Context, data *OrgOwnershipTransfers, ownerRoleId, previousOwnerRoleId int64) (bool, error)
+	}
+
+	customOrgOwnershipTransfersModel struct {
+		*defaultOrgOwnershipTransfersModel
+	}
+)
+
+// NewOrgOwnershipTransfersModel returns a model for the database table.
+func NewOrgOwnershipTransfersModel(conn sqlx.SqlConn, c cache.CacheConf, opts ...cache.Option) OrgOwnershipTransfersModel {
+	m := newOrgOwnershipTransfersModel(conn, c, opts...)
+	m.CachedConn = newCachedConn(conn, c, "org_ownership_transfers", opts...)
+
+	return &customOrgOwnershipTransfersModel{
+		defaultOrgOwnershipTransfersModel: m,
+	}
+}
+
+// FindPendingByOrgId retrieves the latest pending transfer of an organization
+func (m *customOrgOwnershipTransfersModel) FindPendingByOrgId(ctx context.Context, orgId uint64) (*OrgOwnershipTransfers, error) {
+	var transfer OrgOwnershipTransfers
+	query := fmt.Sprintf("select %s from %s where `org_id` = ? and `status` = ? order by `id` desc limit 1",
+		orgOwnershipTransfersRows, m.table)
+	err := m.QueryRowNoCacheCtx(ctx, &transfer, query, orgId, TransferStatusPending)
+	switch err {
+	case nil:
+		return &transfer, nil
+	case sqlc.ErrNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, err
+	}
+}
+
+// Transition moves a pending transfer to another status.
+// It reports false when the transfer was no longer pending.
+func (m *customOrgOwnershipTransfersModel) Transition(ctx context.Context, data *OrgOwnershipTransfers, status string) (bool, error) {
+	var affected int64
+	err := m.TransactCtx(ctx, func(ctx context.Context, session sqlx.Session) error {
+		var err error
+		affected, err = m.transition(ctx, session, data, status)
+		return err
+	})
+	if err != nil {
+		return false, err
+	}
+	if err := m.DelCacheCtx(ctx, fmt.Sprintf("%s%v", cacheOrgOwnershipTransfersIdPrefix, data.Id)); err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// Complete accepts a pending transfer: the organization owner is switched to the recipient,
+// who gets the owner role, and the previous owner is demoted to previousOwnerRoleId, all in one transaction.
+// It returns ErrOwnerChanged when the owner is no longer the requester.
+func (m *customOrgOwnershipTransfersModel) Complete(ctx context.Context, data *OrgOwnershipTransfers, ownerRoleId, previousOwnerRoleId int64) (bool, error) {
+	var (
+		affected  int64
+		memberIds []uint64
+	)
+	err := m.TransactCtx(ctx, func(ctx context.Context, session sqlx.Session) error {
+		var err error
+		affected, err = m.transition(ctx, session, data, TransferStatusAccepted)
+		if err != nil || affected == 0 {
+			return err
+		}
+
+		result, err := session.ExecCtx(ctx, "update `orgs` set `owner_id` = ?, `version` = `version` + 1 where `id` = ? and `owner_id` = ? and `deleted_at` is null",
+			data.ToUserId, data.OrgId, data.FromUserId)
+		if err != nil {
+			return err
+		}
+		if n, err := result.RowsAffected(); err != nil {
+			return err
+		} else if n == 0 {
+			return ErrOwnerChanged
+		}
+
+		if err := session.QueryRowsCtx(ctx, &memberIds, "select `id` from `org_members` where `org_id` = ? and `user_id` in (?, ?)",
+			data.OrgId, data.ToUserId, data.FromUserId); err != nil {
+			return err
+		}
+		query := "update `org_members` set `role_id` = ? where `org_id` = ? and `user_id` = ?"
+		if _, err := session.ExecCtx(ctx, query, ownerRoleId, data.OrgId, data.ToUserId); err != nil {
+			return err
+		}
+		_, err = session.ExecCtx(ctx, query, previousOwnerRoleId, data.OrgId, data.FromUserId)
+		return err
+	})
+	if err != nil {
+		return false, err
+	}
+
+	keys := []string{
+		fmt.Sprintf("%s%v", cacheOrgOwnershipTransfersIdPrefix, data.Id),
+		fmt.Sprintf("%s%v", cacheOrgsIdPrefix, data.OrgId),
+	}
+	for _, id := range memberIds {
+		keys = append(keys, fmt.Sprintf("%s%v", cacheOrgMembersIdPrefix, id))
+	}
+	if err := m.DelCacheCtx(ctx, keys...); err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+func (m *customOrgOwnershipTransfersModel) transition(ctx context.Context, session sqlx.Session, data *OrgOwnershipTransfers, status string) (int64, error) {
+	query := fmt.Sprintf("update %s set `status` = ?, `responded_at` = ? where `id` = ? and `status` = ?", m.table)
+	result, err := session.ExecCtx(ctx, query, status, time.Now(), data.Id, TransferStatusPending)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,57 @@
+package model
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zeromicro/go-zero/core/stores/cache"
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+var _ OrgRolesModel = (*customOrgRolesModel)(nil)
+
+type (
+	// OrgRolesModel is an interface to be customized, add more methods here,
+	// and implement the added methods in customOrgRolesModel.
+	OrgRolesModel interface {
+		orgRolesModel
+		FindAll(ctx context.Context) ([]*OrgRoles, error)
+		FindPermissions(ctx context.Context, id int64) ([]string, error)
+	}
+
+	customOrgRolesModel struct {
+		*defaultOrgRolesModel
+	}
+)
+
+// NewOrgRolesModel returns a model for the database table.
+func NewOrgRolesModel(conn sqlx.SqlConn, c cache.CacheConf, opts ...cache.Option) OrgRolesModel {
+	m := newOrgRolesModel(conn, c, opts...)
+	m.CachedConn = newCachedConn(conn, c, "org_roles", opts...)
+
+	return &customOrgRolesModel{
+		defaultOrgRolesModel: m,
+	}
+}
+
+// FindAll retrieves all organization roles, highest level first
+func (m *customOrgRolesModel) FindAll(ctx context.Context) ([]*OrgRoles, error) {
+	var roles []*OrgRoles
+	query := fmt.Sprintf("select %s from %s order by `level` desc", orgRolesRows, m.table)
+	err := m.QueryRowsNoCacheCtx(ctx, &roles, query)
+	if err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+// FindPermissions retrieves the permissions granted to an organization role via org_role_permissions
+func (m *customOrgRolesModel) FindPermissions(ctx context.Context, id int64) ([]string, error) {
+	var permissions []string
+	query := "select `permission` from `org_role_permissions` where `org_role_id` = ? order by `permission`"
+	err := m.QueryRowsNoCacheCtx(ctx, &permissions, query, id)
+	if err != nil {
+		return nil, err
+	}
+	return permissions, nil
+}
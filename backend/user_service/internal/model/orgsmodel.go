@@ -2,7 +2,10 @@ package model
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
+	"time"
+
 	"github.com/zeromicro/go-zero/core/stores/cache"
 	"github.com/zeromicro/go-zero/core/stores/sqlx"
 )
@@ -15,7 +18,17 @@ type (
 	OrgsModel interface {
 		orgsModel
 		FindAll(ctx context.Context) ([]*Orgs, error)
+		FindPage(ctx context.Context, page KeysetPage, limit int) ([]*Orgs, error)
 		FindOneByName(ctx context.Context, name string) (*Orgs, error)
+		FindByMemberUserId(ctx context.Context, userId uint64) ([]*Orgs, error)
+		FindByOwnerId(ctx context.Context, ownerId uint64) ([]*Orgs, error)
+		FindDeletedByOwnerId(ctx context.Context, ownerId uint64, deletedAfter time.Time) ([]*Orgs, error)
+		FindDeletedBefore(ctx context.Context, deletedBefore time.Time, limit int) ([]*Orgs, error)
+		InsertWithOwner(ctx context.Context, data *Orgs, ownerRoleId int64) (uint64, error)
+		UpdateWithVersion(ctx context.Context, data *Orgs) error
+		SoftDelete(ctx context.Context, id uint64) error
+		Restore(ctx context.Context, id uint64) error
+		Purge(ctx context.Context, id uint64, deletedBefore time.Time) (bool, error)
 	}
 
 	customOrgsModel struct {
@@ -25,12 +38,15 @@ type (
 
 // NewOrgsModel returns a model for the database table.
 func NewOrgsModel(conn sqlx.SqlConn, c cache.CacheConf, opts ...cache.Option) OrgsModel {
+	m := newOrgsModel(conn, c, opts...)
+	m.CachedConn = newCachedConn(conn, c, "orgs", opts...)
+
 	return &customOrgsModel{
-		defaultOrgsModel: newOrgsModel(conn, c, opts...),
+		defaultOrgsModel: m,
 	}
 }
 
-// FindAll retrieves all organizations from the database
+// FindAll retrieves all organizations from the database, including soft-deleted ones
 func (m *customOrgsModel) FindAll(ctx context.Context) ([]*Orgs, error) {
 	var orgs []*Orgs
 	query := fmt.Sprintf("select %s from %s order by created_at desc", orgsRows, m.table)
@@ -41,13 +57,220 @@ func (m *customOrgsModel) FindAll(ctx context.Context) ([]*Orgs, error) {
 	return orgs, nil
 }
 
-// FindOneByName retrieves an organization by name
+// FindPage retrieves a page of all organizations in the order of FindAll (newest first)
+func (m *customOrgsModel) FindPage(ctx context.Context, page KeysetPage, limit int) ([]*Orgs, error) {
+	desc := page.orderDirection(true)
+	direction := "desc"
+	if !desc {
+		direction = "asc"
+	}
+
+	var (
+		where string
+		args  []any
+	)
+	if page.After != nil {
+		where, args = keysetCond("`created_at`", false, desc, *page.After)
+		where = "where " + where
+	}
+	query := fmt.Sprintf("select %s from %s %s order by `created_at` %s, `id` %s limit ?", orgsRows, m.table, where, direction, direction)
+	args = append(args, limit)
+
+	var orgs []*Orgs
+	if err := m.QueryRowsNoCacheCtx(ctx, &orgs, query, args...); err != nil {
+		return nil, err
+	}
+	return restoreOrder(page, orgs), nil
+}
+
+// FindOneByName retrieves an active organization by name
 func (m *customOrgsModel) FindOneByName(ctx context.Context, name string) (*Orgs, error) {
 	var org Orgs
-	query := fmt.Sprintf("select %s from %s where `name` = ? limit 1", orgsRows, m.table)
+	query := fmt.Sprintf("select %s from %s where `name` = ? and `deleted_at` is null limit 1", orgsRows, m.table)
 	err := m.QueryRowNoCacheCtx(ctx, &org, query, name)
 	if err != nil {
 		return nil, err
 	}
 	return &org, nil
 }
+
+// FindByMemberUserId retrieves the active organizations a user belongs to via org_members
+func (m *customOrgsModel) FindByMemberUserId(ctx context.Context, userId uint64) ([]*Orgs, error) {
+	var orgs []*Orgs
+	query := fmt.Sprintf("select %s from %s where `id` in (select `org_id` from `org_members` where `user_id` = ?) and `deleted_at` is null order by created_at desc",
+		orgsRows, m.table)
+	err := m.QueryRowsNoCacheCtx(ctx, &orgs, query, userId)
+	if err != nil {
+		return nil, err
+	}
+	return orgs, nil
+}
+
+// FindByOwnerId retrieves the organizations owned by a user, including soft-deleted ones
+func (m *customOrgsModel) FindByOwnerId(ctx context.Context, ownerId uint64) ([]*Orgs, error) {
+	var orgs []*Orgs
+	query := fmt.Sprintf("select %s from %s where `owner_id` = ? order by created_at desc", orgsRows, m.table)
+	err := m.QueryRowsNoCacheCtx(ctx, &orgs, query, ownerId)
+	if err != nil {
+		return nil, err
+	}
+	return orgs, nil
+}
+
+// FindDeletedByOwnerId retrieves the organizations a user soft-deleted after deletedAfter (still restorable)
+func (m *customOrgsModel) FindDeletedByOwnerId(ctx context.Context, ownerId uint64, deletedAfter time.Time) ([]*Orgs, error) {
+	var orgs []*Orgs
+	query := fmt.Sprintf("select %s from %s where `owner_id` = ? and `deleted_at` > ? order by `deleted_at` desc", orgsRows, m.table)
+	err := m.QueryRowsNoCacheCtx(ctx, &orgs, query, ownerId, deletedAfter)
+	if err != nil {
+		return nil, err
+	}
+	return orgs, nil
+}
+
+// FindDeletedBefore retrieves soft-deleted organizations whose restore window has passed
+func (m *customOrgsModel) FindDeletedBefore(ctx context.Context, deletedBefore time.Time, limit int) ([]*Orgs, error) {
+	var orgs []*Orgs
+	query := fmt.Sprintf("select %s from %s where `deleted_at` is not null and `deleted_at` <= ? order by `deleted_at` limit ?", orgsRows, m.table)
+	err := m.QueryRowsNoCacheCtx(ctx, &orgs, query, deletedBefore, limit)
+	if err != nil {
+		return nil, err
+	}
+	return orgs, nil
+}
+
+// InsertWithOwner creates an organization and registers its owner as a member in one transaction
+func (m *customOrgsModel) InsertWithOwner(ctx context.Context, data *Orgs, ownerRoleId int64) (uint64, error) {
+	var orgId uint64
+	err := m.TransactCtx(ctx, func(ctx context.Context, session sqlx.Session) error {
+		query := fmt.Sprintf("insert into %s (%s) values (?, ?, ?, ?)", m.table, orgsRowsExpectAutoSet)
+		result, err := session.ExecCtx(ctx, query, data.Name, data.OwnerId, data.DeletedAt, data.Version)
+		if err != nil {
+			return err
+		}
+		id, err := result.LastInsertId()
+		if err != nil {
+			return err
+		}
+		orgId = uint64(id)
+
+		query = fmt.Sprintf("insert into `org_members` (%s) values (?, ?, ?)", orgMembersRowsExpectAutoSet)
+		_, err = session.ExecCtx(ctx, query, orgId, data.OwnerId, ownerRoleId)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	return orgId, nil
+}
+
+// UpdateWithVersion updates an organization only if its version is still data.Version,
+// returning ErrVersionConflict otherwise. data.Version is advanced on success.
+func (m *customOrgsModel) UpdateWithVersion(ctx context.Context, data *Orgs) error {
+	orgsIdKey := fmt.Sprintf("%s%v", cacheOrgsIdPrefix, data.Id)
+	result, err := m.ExecCtx(ctx, func(ctx context.Context, conn sqlx.SqlConn) (sql.Result, error) {
+		query := fmt.Sprintf("update %s set `name` = ?, `owner_id` = ?, `deleted_at` = ?, `version` = `version` + 1 where `id` = ? and `version` = ?", m.table)
+		return conn.ExecCtx(ctx, query, data.Name, data.OwnerId, data.DeletedAt, data.Id, data.Version)
+	}, orgsIdKey)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return ErrVersionConflict
+	}
+
+	data.Version++
+	return nil
+}
+
+// SoftDelete marks an organization as deleted; it can be restored until it is purged
+func (m *customOrgsModel) SoftDelete(ctx context.Context, id uint64) error {
+	return m.setDeletedAt(ctx, id, "`deleted_at` is null", time.Now())
+}
+
+// Restore clears the deletion mark of a soft-deleted organization
+func (m *customOrgsModel) Restore(ctx context.Context, id uint64) error {
+	return m.setDeletedAt(ctx, id, "`deleted_at` is not null", nil)
+}
+
+func (m *customOrgsModel) setDeletedAt(ctx context.Context, id uint64, cond string, deletedAt any) error {
+	orgsIdKey := fmt.Sprintf("%s%v", cacheOrgsIdPrefix, id)
+	_, err := m.ExecCtx(ctx, func(ctx context.Context, conn sqlx.SqlConn) (sql.Result, error) {
+		query := fmt.Sprintf("update %s set `deleted_at` = ?, `version` = `version` + 1 where `id` = ? and %s", m.table, cond)
+		return conn.ExecCtx(ctx, query, deletedAt, id)
+	}, orgsIdKey)
+	return err
+}
+
+// Purge permanently deletes a soft-deleted organization together with its memberships,
+// invitations, ownership transfers and API keys. It reports false when the organization is not
+// soft-deleted at or before deletedBefore (e.g. it was restored in the meantime).
+func (m *customOrgsModel) Purge(ctx context.Context, id uint64, deletedBefore time.Time) (bool, error) {
+	var (
+		purged      bool
+		memberIds   []uint64
+		invitations []*OrgInvitations
+		transferIds []uint64
+		apiKeys     []*OrgApiKeys
+	)
+	err := m.TransactCtx(ctx, func(ctx context.Context, session sqlx.Session) error {
+		var locked []uint64
+		query := fmt.Sprintf("select `id` from %s where `id` = ? and `deleted_at` is not null and `deleted_at` <= ? for update", m.table)
+		if err := session.QueryRowsCtx(ctx, &locked, query, id, deletedBefore); err != nil {
+			return err
+		}
+		if len(locked) == 0 {
+			return nil
+		}
+
+		if err := session.QueryRowsCtx(ctx, &memberIds, "select `id` from `org_members` where `org_id` = ?", id); err != nil {
+			return err
+		}
+		query = fmt.Sprintf("select %s from `org_invitations` where `org_id` = ?", orgInvitationsRows)
+		if err := session.QueryRowsCtx(ctx, &invitations, query, id); err != nil {
+			return err
+		}
+		if err := session.QueryRowsCtx(ctx, &transferIds, "select `id` from `org_ownership_transfers` where `org_id` = ?", id); err != nil {
+			return err
+		}
+		query = fmt.Sprintf("select %s from `org_api_keys` where `org_id` = ?", orgApiKeysRows)
+		if err := session.QueryRowsCtx(ctx, &apiKeys, query, id); err != nil {
+			return err
+		}
+
+		for _, table := range []string{"`org_members`", "`org_invitations`", "`org_ownership_transfers`", "`org_api_keys`"} {
+			if _, err := session.ExecCtx(ctx, fmt.Sprintf("delete from %s where `org_id` = ?", table), id); err != nil {
+				return err
+			}
+		}
+		if _, err := session.ExecCtx(ctx, fmt.Sprintf("delete from %s where `id` = ?", m.table), id); err != nil {
+			return err
+		}
+		purged = true
+		return nil
+	})
+	if err != nil || !purged {
+		return false, err
+	}
+
+	keys := []string{fmt.Sprintf("%s%v", cacheOrgsIdPrefix, id)}
+	for _, memberId := range memberIds {
+		keys = append(keys, fmt.Sprintf("%s%v", cacheOrgMembersIdPrefix, memberId))
+	}
+	for _, inv := range invitations {
+		keys = append(keys,
+			fmt.Sprintf("%s%v", cacheOrgInvitationsIdPrefix, inv.Id),
+			fmt.Sprintf("%s%v", cacheOrgInvitationsTokenHashPrefix, inv.TokenHash))
+	}
+	for _, transferId := range transferIds {
+		keys = append(keys, fmt.Sprintf("%s%v", cacheOrgOwnershipTransfersIdPrefix, transferId))
+	}
+	for _, key := range apiKeys {
+		keys = append(keys,
+			fmt.Sprintf("%s%v", cacheOrgApiKeysIdPrefix, key.Id),
+			fmt.Sprintf("%s%v", cacheOrgApiKeysPrefixPrefix, key.Prefix))
+	}
+	return true, m.DelCacheCtx(ctx, keys...)
+}
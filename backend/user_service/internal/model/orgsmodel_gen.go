@@ -41,11 +41,13 @@ type (
 	}
 
 	Orgs struct {
-		Id        uint64    `db:"id"`
-		Name      string    `db:"name"`
-		OwnerId   uint64    `db:"owner_id"`
-		CreatedAt time.Time `db:"created_at"`
-		UpdatedAt time.Time `db:"updated_at"`
+		Id        uint64       `db:"id"`
+		Name      string       `db:"name"`
+		OwnerId   uint64       `db:"owner_id"`
+		CreatedAt time.Time    `db:"created_at"`
+		UpdatedAt time.Time    `db:"updated_at"`
+		DeletedAt sql.NullTime `db:"deleted_at"`
+		Version   uint64       `db:"version"`
 	}
 )
 
@@ -85,8 +87,8 @@ func (m *defaultOrgsModel) FindOne(ctx context.Context, id uint64) (*Orgs, error
 func (m *defaultOrgsModel) Insert(ctx context.Context, data *Orgs) (sql.Result, error) {
 	orgsIdKey := fmt.Sprintf("%s%v", cacheOrgsIdPrefix, data.Id)
 	ret, err := m.ExecCtx(ctx, func(ctx context.Context, conn sqlx.SqlConn) (result sql.Result, err error) {
-		query := fmt.Sprintf("insert into %s (%s) values (?, ?)", m.table, orgsRowsExpectAutoSet)
-		return conn.ExecCtx(ctx, query, data.Name, data.OwnerId)
+		query := fmt.Sprintf("insert into %s (%s) values (?, ?, ?, ?)", m.table, orgsRowsExpectAutoSet)
+		return conn.ExecCtx(ctx, query, data.Name, data.OwnerId, data.DeletedAt, data.Version)
 	}, orgsIdKey)
 	return ret, err
 }
@@ -95,7 +97,7 @@ func (m *defaultOrgsModel) Update(ctx context.Context, data *Orgs) error {
 	orgsIdKey := fmt.Sprintf("%s%v", cacheOrgsIdPrefix, data.Id)
 	_, err := m.ExecCtx(ctx, func(ctx context.Context, conn sqlx.SqlConn) (result sql.Result, err error) {
 		query := fmt.Sprintf("update %s set %s where `id` = ?", m.table, orgsRowsWithPlaceHolder)
-		return conn.ExecCtx(ctx, query, data.Name, data.OwnerId, data.Id)
+		return conn.ExecCtx(ctx, query, data.Name, data.OwnerId, data.DeletedAt, data.Version, data.Id)
 	}, orgsIdKey)
 	return err
 }
@@ -58,7 +58,7 @@ func NewRolesModel(conn sqlx.SqlConn, c cache.CacheConf, opts ...cache.Option) R
 
 func newRolesModel(conn sqlx.SqlConn, c cache.CacheConf, opts ...cache.Option) *defaultRolesModel {
     return &defaultRolesModel{
-        CachedConn: sqlc.NewConn(conn, c, opts...),
+        CachedConn: newCachedConn(conn, c, "roles", opts...),
         table:      "`roles`",
     }
 }
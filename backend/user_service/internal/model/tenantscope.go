@@ -0,0 +1,48 @@
+package model
+
+import (
+	"context"
+	"errors"
+
+	"github.com/winyx/backend/common/tenant"
+)
+
+// ErrTenantRequired テナント（操作対象の組織）が無いコンテキストで組織スコープのクエリを実行した
+var ErrTenantRequired = errors.New("tenant context is required for org-scoped queries")
+
+// TenantScope restricts queries on org-owned tables to the organization of the request
+type TenantScope struct {
+	OrgId uint64
+}
+
+// TenantScopeFromContext returns the scope of the tenant placed in the context by the tenant middleware.
+// Without a tenant it fails closed with ErrTenantRequired instead of querying every organization.
+func TenantScopeFromContext(ctx context.Context) (TenantScope, error) {
+	orgId, ok := tenant.OrgIdFromContext(ctx)
+	if !ok {
+		return TenantScope{}, ErrTenantRequired
+	}
+
+	return TenantScope{OrgId: uint64(orgId)}, nil
+}
+
+// Where prepends the org_id condition to a where clause (without the "where" keyword).
+// An empty cond yields the org_id condition only.
+func (s TenantScope) Where(cond string, args ...any) (string, []any) {
+	scopedArgs := append([]any{s.OrgId}, args...)
+	if cond == "" {
+		return "`org_id` = ?", scopedArgs
+	}
+
+	return "`org_id` = ? and (" + cond + ")", scopedArgs
+}
+
+// Check verifies a row loaded without the scope (e.g. by primary key).
+// Rows of other organizations are reported as ErrNotFound so their existence is not disclosed.
+func (s TenantScope) Check(orgId uint64) error {
+	if orgId != s.OrgId {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package model
+
+import (
+	"context"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+type (
+	// UnitOfWork runs statements of several models in one database transaction.
+	// Cache keys touched inside the transaction are evicted only after it commits,
+	// so a rolled back change never drops (or repopulates) the cache with uncommitted rows.
+	UnitOfWork struct {
+		conn sqlx.SqlConn
+	}
+
+	// Tx is the transaction passed to the *Tx methods of the models.
+	Tx struct {
+		session   sqlx.Session
+		evictions []eviction
+	}
+
+	eviction struct {
+		cache cacheEvicter
+		keys  []string
+	}
+
+	cacheEvicter interface {
+		DelCacheCtx(ctx context.Context, keys ...string) error
+	}
+)
+
+// NewUnitOfWork returns a unit of work on the given connection.
+func NewUnitOfWork(conn sqlx.SqlConn) *UnitOfWork {
+	return &UnitOfWork{conn: conn}
+}
+
+// Do runs fn in a transaction. The transaction is rolled back when fn returns an error
+// and committed otherwise; the cache keys registered by the models are evicted after commit.
+func (u *UnitOfWork) Do(ctx context.Context, fn func(ctx context.Context, tx *Tx) error) error {
+	tx := &Tx{}
+	err := u.conn.TransactCtx(ctx, func(ctx context.Context, session sqlx.Session) error {
+		tx.session = session
+		return fn(ctx, tx)
+	})
+	if err != nil {
+		return err
+	}
+
+	tx.evict(ctx)
+	return nil
+}
+
+// Session returns the session of the transaction for statements without a model method.
+func (tx *Tx) Session() sqlx.Session {
+	return tx.session
+}
+
+// evictAfterCommit registers cache keys to delete once the transaction has committed.
+func (tx *Tx) evictAfterCommit(cache cacheEvicter, keys ...string) {
+	if len(keys) == 0 {
+		return
+	}
+	tx.evictions = append(tx.evictions, eviction{cache: cache, keys: keys})
+}
+
+func (tx *Tx) evict(ctx context.Context) {
+	// コミット済みの変更は取り消せないため、削除に失敗したキーは TTL での失効に任せる
+	ctx = context.WithoutCancel(ctx)
+	for _, e := range tx.evictions {
+		if err := e.cache.DelCacheCtx(ctx, e.keys...); err != nil {
+			logx.WithContext(ctx).Errorf("Failed to evict cache keys %v after commit: %v", e.keys, err)
+		}
+	}
+}
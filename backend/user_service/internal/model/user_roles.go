@@ -4,6 +4,7 @@ import (
     "context"
     "database/sql"
     "fmt"
+    "strings"
     "time"
 
     "github.com/zeromicro/go-zero/core/stores/cache"
@@ -20,9 +21,13 @@ type (
         userRolesModel
         FindByUserId(ctx context.Context, userId int64) ([]*UserRoles, error)
         FindByUserIdWithRole(ctx context.Context, userId int64) ([]*UserRoleInfo, error)
+        FindByUserIds(ctx context.Context, userIds []int64) ([]*UserRoleInfo, error)
         DeleteByUserIdAndRoleId(ctx context.Context, userId, roleId int64) error
         DeleteByUserId(ctx context.Context, userId int64) error
+        ReplaceByUserIdTx(ctx context.Context, tx *Tx, userId int64, roleIds []int64, assignedBy int64) error
+        DeleteByUserIdTx(ctx context.Context, tx *Tx, userId int64) error
         CheckUserRole(ctx context.Context, userId int64, roleName string) (bool, error)
+        HasPermission(ctx context.Context, userId int64, resource, action string) (bool, error)
     }
 
     UserRoles struct {
@@ -70,7 +75,7 @@ func NewUserRolesModel(conn sqlx.SqlConn, c cache.CacheConf, opts ...cache.Optio
 
 func newUserRolesModel(conn sqlx.SqlConn, c cache.CacheConf, opts ...cache.Option) *defaultUserRolesModel {
     return &defaultUserRolesModel{
-        CachedConn: sqlc.NewConn(conn, c, opts...),
+        CachedConn: newCachedConn(conn, c, "user_roles", opts...),
         table:      "`user_roles`",
     }
 }
@@ -132,6 +137,34 @@ func (m *customUserRolesModel) FindByUserIdWithRole(ctx context.Context, userId
     }
 }
 
+// FindByUserIds returns the roles of all the given users with one query, ordered by user and assignment.
+// Users without roles have no rows.
+func (m *customUserRolesModel) FindByUserIds(ctx context.Context, userIds []int64) ([]*UserRoleInfo, error) {
+    var resp []*UserRoleInfo
+    if len(userIds) == 0 {
+        return resp, nil
+    }
+
+    args := make([]any, len(userIds))
+    for i, id := range userIds {
+        args[i] = id
+    }
+    query := fmt.Sprintf(`
+        select ur.id, ur.user_id, ur.role_id, ur.assigned_by, ur.created_at,
+               r.name as role_name, r.description as role_description
+        from %s ur
+        join roles r on ur.role_id = r.id
+        where ur.user_id in (%s)
+        order by ur.user_id, ur.id`, m.table, strings.Repeat("?,", len(userIds)-1)+"?")
+    err := m.QueryRowsNoCacheCtx(ctx, &resp, query, args...)
+    switch err {
+    case nil:
+        return resp, nil
+    default:
+        return nil, err
+    }
+}
+
 func (m *customUserRolesModel) CheckUserRole(ctx context.Context, userId int64, roleName string) (bool, error) {
     var count int
     query := fmt.Sprintf(`
@@ -146,6 +179,22 @@ func (m *customUserRolesModel) CheckUserRole(ctx context.Context, userId int64,
     return count > 0, nil
 }
 
+// HasPermission ロールに付与された権限（role_permissions）で resource:action を許可されているか
+func (m *customUserRolesModel) HasPermission(ctx context.Context, userId int64, resource, action string) (bool, error) {
+    var count int
+    query := fmt.Sprintf(`
+        select count(*)
+        from %s ur
+        join role_permissions rp on ur.role_id = rp.role_id
+        join permissions p on rp.permission_id = p.id
+        where ur.user_id = ? and p.resource = ? and p.action = ?`, m.table)
+    err := m.QueryRowNoCacheCtx(ctx, &count, query, userId, resource, action)
+    if err != nil {
+        return false, err
+    }
+    return count > 0, nil
+}
+
 func (m *customUserRolesModel) DeleteByUserIdAndRoleId(ctx context.Context, userId, roleId int64) error {
     userRolesUserIdKey := fmt.Sprintf("%s%v", cacheUserRolesUserIdPrefix, userId)
     _, err := m.ExecCtx(ctx, func(ctx context.Context, conn sqlx.SqlConn) (result sql.Result, err error) {
@@ -164,6 +213,45 @@ func (m *customUserRolesModel) DeleteByUserId(ctx context.Context, userId int64)
 	return err
 }
 
+// ReplaceByUserIdTx replaces the roles of a user with roleIds in the transaction.
+// assignedBy 0 records no assigner.
+func (m *customUserRolesModel) ReplaceByUserIdTx(ctx context.Context, tx *Tx, userId int64, roleIds []int64, assignedBy int64) error {
+    if err := m.DeleteByUserIdTx(ctx, tx, userId); err != nil {
+        return err
+    }
+
+    var assigner any
+    if assignedBy > 0 {
+        assigner = assignedBy
+    }
+    now := time.Now()
+    query := fmt.Sprintf("insert into %s (%s) values (?, ?, ?, ?)", m.table, userRolesRowsExpectAutoSet)
+    for _, roleId := range roleIds {
+        if _, err := tx.session.ExecCtx(ctx, query, userId, roleId, assigner, now); err != nil {
+            return err
+        }
+    }
+    return nil
+}
+
+// DeleteByUserIdTx deletes the roles of a user in the transaction.
+func (m *customUserRolesModel) DeleteByUserIdTx(ctx context.Context, tx *Tx, userId int64) error {
+    var ids []int64
+    if err := tx.session.QueryRowsCtx(ctx, &ids, fmt.Sprintf("select `id` from %s where `user_id` = ? for update", m.table), userId); err != nil {
+        return err
+    }
+    if _, err := tx.session.ExecCtx(ctx, fmt.Sprintf("delete from %s where `user_id` = ?", m.table), userId); err != nil {
+        return err
+    }
+
+    keys := []string{fmt.Sprintf("%s%v", cacheUserRolesUserIdPrefix, userId)}
+    for _, id := range ids {
+        keys = append(keys, fmt.Sprintf("%s%v", cacheUserRolesIdPrefix, id))
+    }
+    tx.evictAfterCommit(m, keys...)
+    return nil
+}
+
 func (m *defaultUserRolesModel) Update(ctx context.Context, data *UserRoles) error {
     userRolesIdKey := fmt.Sprintf("%s%v", cacheUserRolesIdPrefix, data.Id)
     userRolesUserIdKey := fmt.Sprintf("%s%v", cacheUserRolesUserIdPrefix, data.UserId)
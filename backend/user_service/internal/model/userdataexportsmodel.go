@@ -0,0 +1,89 @@
+package model
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+// データエクスポートの状態
+const (
+	ExportStatusPending   = "pending"
+	ExportStatusRunning   = "running"
+	ExportStatusCompleted = "completed"
+	ExportStatusFailed    = "failed"
+)
+
+var _ UserDataExportsModel = (*customUserDataExportsModel)(nil)
+
+type (
+	// UserDataExportsModel is an interface to be customized, add more methods here,
+	// and implement the added methods in customUserDataExportsModel.
+	UserDataExportsModel interface {
+		userDataExportsModel
+		Start(ctx context.Context, data *UserDataExports) (bool, error)
+		FindActiveByUserId(ctx context.Context, userId uint64) (*UserDataExports, error)
+		FindByUserId(ctx context.Context, userId uint64) ([]*UserDataExports, error)
+	}
+
+	customUserDataExportsModel struct {
+		*defaultUserDataExportsModel
+	}
+)
+
+// NewUserDataExportsModel returns a model for the database table.
+// Like the import jobs, an export row is polled while it runs and is not cached.
+func NewUserDataExportsModel(conn sqlx.SqlConn) UserDataExportsModel {
+	return &customUserDataExportsModel{
+		defaultUserDataExportsModel: newUserDataExportsModel(conn),
+	}
+}
+
+// Start moves a pending export to running and sets StartedAt, reporting false when it is no longer pending.
+func (m *customUserDataExportsModel) Start(ctx context.Context, data *UserDataExports) (bool, error) {
+	query := fmt.Sprintf("update %s set `status` = ?, `started_at` = now() where `id` = ? and `status` = ?", m.table)
+	result, err := m.conn.ExecCtx(ctx, query, ExportStatusRunning, data.Id, ExportStatusPending)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if affected == 0 {
+		return false, nil
+	}
+
+	current, err := m.FindOne(ctx, data.Id)
+	if err != nil {
+		return false, err
+	}
+	*data = *current
+	return true, nil
+}
+
+// FindActiveByUserId retrieves the latest pending or running export of a user.
+func (m *customUserDataExportsModel) FindActiveByUserId(ctx context.Context, userId uint64) (*UserDataExports, error) {
+	query := fmt.Sprintf("select %s from %s where `user_id` = ? and `status` in (?, ?) order by `id` desc limit 1", userDataExportsRows, m.table)
+	var resp UserDataExports
+	err := m.conn.QueryRowCtx(ctx, &resp, query, userId, ExportStatusPending, ExportStatusRunning)
+	switch err {
+	case nil:
+		return &resp, nil
+	case sqlx.ErrNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, err
+	}
+}
+
+// FindByUserId retrieves all exports of a user, newest first.
+func (m *customUserDataExportsModel) FindByUserId(ctx context.Context, userId uint64) ([]*UserDataExports, error) {
+	query := fmt.Sprintf("select %s from %s where `user_id` = ? order by `id` desc", userDataExportsRows, m.table)
+	var resp []*UserDataExports
+	if err := m.conn.QueryRowsCtx(ctx, &resp, query, userId); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
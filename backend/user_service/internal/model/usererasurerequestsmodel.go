@@ -0,0 +1,124 @@
+package model
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+// 消去リクエストの状態
+const (
+	ErasureStatusPending   = "pending"
+	ErasureStatusRejected  = "rejected"
+	ErasureStatusCancelled = "cancelled"
+	ErasureStatusCompleted = "completed"
+)
+
+var _ UserErasureRequestsModel = (*customUserErasureRequestsModel)(nil)
+
+type (
+	// UserErasureRequestsModel is an interface to be customized, add more methods here,
+	// and implement the added methods in customUserErasureRequestsModel.
+	UserErasureRequestsModel interface {
+		userErasureRequestsModel
+		FindPendingByUserId(ctx context.Context, userId uint64) (*UserErasureRequests, error)
+		FindByUserId(ctx context.Context, userId uint64) ([]*UserErasureRequests, error)
+		FindByStatus(ctx context.Context, status string, limit, offset int) ([]*UserErasureRequests, error)
+		CountByStatus(ctx context.Context, status string) (int64, error)
+		Close(ctx context.Context, data *UserErasureRequests) (bool, error)
+		CompleteTx(ctx context.Context, tx *Tx, data *UserErasureRequests) (bool, error)
+	}
+
+	customUserErasureRequestsModel struct {
+		*defaultUserErasureRequestsModel
+	}
+)
+
+// NewUserErasureRequestsModel returns a model for the database table.
+// Requests are read by their requester and reviewers only, so they are not cached.
+func NewUserErasureRequestsModel(conn sqlx.SqlConn) UserErasureRequestsModel {
+	return &customUserErasureRequestsModel{
+		defaultUserErasureRequestsModel: newUserErasureRequestsModel(conn),
+	}
+}
+
+// FindPendingByUserId retrieves the pending request of a user.
+func (m *customUserErasureRequestsModel) FindPendingByUserId(ctx context.Context, userId uint64) (*UserErasureRequests, error) {
+	query := fmt.Sprintf("select %s from %s where `user_id` = ? and `status` = ? order by `id` desc limit 1", userErasureRequestsRows, m.table)
+	var resp UserErasureRequests
+	err := m.conn.QueryRowCtx(ctx, &resp, query, userId, ErasureStatusPending)
+	switch err {
+	case nil:
+		return &resp, nil
+	case sqlx.ErrNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, err
+	}
+}
+
+// FindByUserId retrieves all requests of a user, newest first.
+func (m *customUserErasureRequestsModel) FindByUserId(ctx context.Context, userId uint64) ([]*UserErasureRequests, error) {
+	query := fmt.Sprintf("select %s from %s where `user_id` = ? order by `id` desc", userErasureRequestsRows, m.table)
+	var resp []*UserErasureRequests
+	if err := m.conn.QueryRowsCtx(ctx, &resp, query, userId); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// FindByStatus retrieves requests in the status, oldest first so that reviewers handle them in order.
+func (m *customUserErasureRequestsModel) FindByStatus(ctx context.Context, status string, limit, offset int) ([]*UserErasureRequests, error) {
+	query := fmt.Sprintf("select %s from %s where `status` = ? order by `id` limit ? offset ?", userErasureRequestsRows, m.table)
+	var resp []*UserErasureRequests
+	if err := m.conn.QueryRowsCtx(ctx, &resp, query, status, limit, offset); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// CountByStatus counts requests in the status.
+func (m *customUserErasureRequestsModel) CountByStatus(ctx context.Context, status string) (int64, error) {
+	var count int64
+	query := fmt.Sprintf("select count(*) from %s where `status` = ?", m.table)
+	err := m.conn.QueryRowCtx(ctx, &count, query, status)
+	return count, err
+}
+
+// Close moves a pending request to data.Status (rejected or cancelled) together with the review fields.
+// It reports false when the request is no longer pending.
+func (m *customUserErasureRequestsModel) Close(ctx context.Context, data *UserErasureRequests) (bool, error) {
+	query := fmt.Sprintf("update %s set `status` = ?, `reviewed_by` = ?, `review_note` = ?, `reviewed_at` = ? where `id` = ? and `status` = ?", m.table)
+	result, err := m.conn.ExecCtx(ctx, query, data.Status, data.ReviewedBy, data.ReviewNote, data.ReviewedAt, data.Id, ErasureStatusPending)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+// CompleteTx moves a pending request to completed in the transaction, storing the review fields,
+// CompletedAt and the certificate. The reasons entered by the requester are cleared as part of the erasure,
+// including those of earlier cancelled or rejected requests.
+// It reports false when the request is no longer pending.
+func (m *customUserErasureRequestsModel) CompleteTx(ctx context.Context, tx *Tx, data *UserErasureRequests) (bool, error) {
+	data.Status = ErasureStatusCompleted
+	data.Reason = ""
+
+	query := fmt.Sprintf("update %s set `status` = ?, `reason` = '', `reviewed_by` = ?, `review_note` = ?, `reviewed_at` = ?, `completed_at` = ?, `certificate` = ?, `certificate_hash` = ? where `id` = ? and `status` = ?", m.table)
+	result, err := tx.session.ExecCtx(ctx, query, data.Status, data.ReviewedBy, data.ReviewNote, data.ReviewedAt, data.CompletedAt, data.Certificate, data.CertificateHash, data.Id, ErasureStatusPending)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil || affected == 0 {
+		return false, err
+	}
+
+	query = fmt.Sprintf("update %s set `reason` = '' where `user_id` = ? and `reason` <> ''", m.table)
+	if _, err := tx.session.ExecCtx(ctx, query, data.UserId); err != nil {
+		return false, err
+	}
+	return true, nil
+}
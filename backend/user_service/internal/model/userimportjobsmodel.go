@@ -0,0 +1,67 @@
+package model
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+// インポートジョブの状態
+const (
+	ImportStatusPending   = "pending"
+	ImportStatusRunning   = "running"
+	ImportStatusCompleted = "completed"
+	ImportStatusFailed    = "failed"
+)
+
+var _ UserImportJobsModel = (*customUserImportJobsModel)(nil)
+
+type (
+	// UserImportJobsModel is an interface to be customized, add more methods here,
+	// and implement the added methods in customUserImportJobsModel.
+	UserImportJobsModel interface {
+		userImportJobsModel
+		Start(ctx context.Context, data *UserImportJobs) (bool, error)
+	}
+
+	customUserImportJobsModel struct {
+		*defaultUserImportJobsModel
+	}
+)
+
+// NewUserImportJobsModel returns a model for the database table.
+// A job row is polled while it runs, so it is not cached.
+func NewUserImportJobsModel(conn sqlx.SqlConn) UserImportJobsModel {
+	return &customUserImportJobsModel{
+		defaultUserImportJobsModel: newUserImportJobsModel(conn),
+	}
+}
+
+// Start moves a pending job to running and sets StartedAt.
+// It reports false when the job is no longer pending, so a job is never run twice.
+func (m *customUserImportJobsModel) Start(ctx context.Context, data *UserImportJobs) (bool, error) {
+	query := fmt.Sprintf("update %s set `status` = ?, `started_at` = now() where `id` = ? and `status` = ?", m.table)
+	result, err := m.conn.ExecCtx(ctx, query, ImportStatusRunning, data.Id, ImportStatusPending)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if affected == 0 {
+		return false, nil
+	}
+
+	return true, m.reload(ctx, data)
+}
+
+func (m *customUserImportJobsModel) reload(ctx context.Context, data *UserImportJobs) error {
+	current, err := m.FindOne(ctx, data.Id)
+	if err != nil {
+		return err
+	}
+	*data = *current
+	return nil
+}
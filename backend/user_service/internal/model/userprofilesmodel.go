@@ -1,8 +1,13 @@
 package model
 
 import (
+	"context"
+	"fmt"
+	"strings"
+
 	"github.com/zeromicro/go-zero/core/stores/cache"
 	"github.com/zeromicro/go-zero/core/stores/sqlx"
+	"github.com/zeromicro/go-zero/core/stringx"
 )
 
 var _ UserProfilesModel = (*customUserProfilesModel)(nil)
@@ -12,6 +17,11 @@ type (
 	// and implement the added methods in customUserProfilesModel.
 	UserProfilesModel interface {
 		userProfilesModel
+		FindByUserIds(ctx context.Context, userIds []uint64) ([]*UserProfiles, error)
+		LockByUserIdTx(ctx context.Context, tx *Tx, userId uint64) (*UserProfiles, error)
+		UpsertTx(ctx context.Context, tx *Tx, data *UserProfiles) error
+		DeleteByUserIdTx(ctx context.Context, tx *Tx, userId uint64) error
+		CountByAvatarUrl(ctx context.Context, avatarUrl string) (int64, error)
 	}
 
 	customUserProfilesModel struct {
@@ -21,7 +31,116 @@ type (
 
 // NewUserProfilesModel returns a model for the database table.
 func NewUserProfilesModel(conn sqlx.SqlConn, c cache.CacheConf, opts ...cache.Option) UserProfilesModel {
+	m := newUserProfilesModel(conn, c, opts...)
+	m.CachedConn = newCachedConn(conn, c, "user_profiles", opts...)
+
 	return &customUserProfilesModel{
-		defaultUserProfilesModel: newUserProfilesModel(conn, c, opts...),
+		defaultUserProfilesModel: m,
+	}
+}
+
+// FindByUserIds returns the profiles of the given users with one query, bypassing the per-row cache.
+// Users without a profile are missing from the result.
+func (m *customUserProfilesModel) FindByUserIds(ctx context.Context, userIds []uint64) ([]*UserProfiles, error) {
+	var resp []*UserProfiles
+	if len(userIds) == 0 {
+		return resp, nil
+	}
+
+	args := make([]any, len(userIds))
+	for i, id := range userIds {
+		args[i] = id
+	}
+	query := fmt.Sprintf("select %s from %s where `user_id` in (%s)", userProfilesRows, m.table,
+		strings.Repeat("?,", len(userIds)-1)+"?")
+	if err := m.QueryRowsNoCacheCtx(ctx, &resp, query, args...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// LockByUserIdTx reads the profile of a user and locks the row until the transaction ends.
+func (m *customUserProfilesModel) LockByUserIdTx(ctx context.Context, tx *Tx, userId uint64) (*UserProfiles, error) {
+	var resp UserProfiles
+	query := fmt.Sprintf("select %s from %s where `user_id` = ? limit 1 for update", userProfilesRows, m.table)
+	err := tx.session.QueryRowCtx(ctx, &resp, query, userId)
+	switch err {
+	case nil:
+		return &resp, nil
+	case sqlx.ErrNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, err
+	}
+}
+
+// UpsertTx inserts the profile of data.UserId or overwrites the existing one in the transaction,
+// advancing its version. data.Id and data.Version are set to those of the stored row.
+func (m *customUserProfilesModel) UpsertTx(ctx context.Context, tx *Tx, data *UserProfiles) error {
+	columns := stringx.Remove(userProfilesFieldNames, "`id`", "`user_id`", "`created_at`", "`updated_at`", "`version`")
+	assignments := make([]string, 0, len(columns)+2)
+	// 既存の行を更新した場合も LAST_INSERT_ID() でその行の id を返させる
+	assignments = append(assignments, "`id` = last_insert_id(`id`)", "`version` = `version` + 1")
+	for _, column := range columns {
+		assignments = append(assignments, fmt.Sprintf("%s = values(%s)", column, column))
+	}
+
+	query := fmt.Sprintf("insert into %s (%s) values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) on duplicate key update %s",
+		m.table, userProfilesRowsExpectAutoSet, strings.Join(assignments, ", "))
+	ret, err := tx.session.ExecCtx(ctx, query, data.UserId, data.AvatarUrl, data.Bio, data.Phone, data.Address, data.BirthDate,
+		data.Gender, data.Occupation, data.Website, data.SocialLinks, data.Preferences, 0)
+	if err != nil {
+		return err
+	}
+	id, err := ret.LastInsertId()
+	if err != nil {
+		return err
+	}
+	// 挿入した行は 0、既存の行は更新前の値に 1 を足した値になる
+	if affected, err := ret.RowsAffected(); err != nil {
+		return err
+	} else if affected == 1 {
+		data.Version = 0
+	} else {
+		data.Version++
+	}
+	data.Id = uint64(id)
+
+	tx.evictAfterCommit(m,
+		fmt.Sprintf("%s%v", cacheWinyxCoreUserProfilesIdPrefix, data.Id),
+		fmt.Sprintf("%s%v", cacheWinyxCoreUserProfilesUserIdPrefix, data.UserId),
+	)
+	return nil
+}
+
+// DeleteByUserIdTx deletes the profile of a user in the transaction.
+func (m *customUserProfilesModel) DeleteByUserIdTx(ctx context.Context, tx *Tx, userId uint64) error {
+	var ids []uint64
+	if err := tx.session.QueryRowsCtx(ctx, &ids, fmt.Sprintf("select `id` from %s where `user_id` = ? for update", m.table), userId); err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	if _, err := tx.session.ExecCtx(ctx, fmt.Sprintf("delete from %s where `user_id` = ?", m.table), userId); err != nil {
+		return err
+	}
+
+	keys := []string{fmt.Sprintf("%s%v", cacheWinyxCoreUserProfilesUserIdPrefix, userId)}
+	for _, id := range ids {
+		keys = append(keys, fmt.Sprintf("%s%v", cacheWinyxCoreUserProfilesIdPrefix, id))
+	}
+	tx.evictAfterCommit(m, keys...)
+	return nil
+}
+
+// CountByAvatarUrl counts the profiles that show the given avatar.
+// Avatar files are named after their content, so users who uploaded the same image share them.
+func (m *customUserProfilesModel) CountByAvatarUrl(ctx context.Context, avatarUrl string) (int64, error) {
+	var count int64
+	query := fmt.Sprintf("select count(*) from %s where `avatar_url` = ?", m.table)
+	if err := m.QueryRowNoCacheCtx(ctx, &count, query, avatarUrl); err != nil {
+		return 0, err
 	}
+	return count, nil
 }
@@ -43,20 +43,21 @@ type (
 	}
 
 	UserProfiles struct {
-		Id          uint64    `db:"id"`
-		UserId      uint64    `db:"user_id"`      // ユーザーID
-		AvatarUrl   string    `db:"avatar_url"`   // アバター画像URL
-		Bio         string    `db:"bio"`          // 自己紹介
-		Phone       string    `db:"phone"`        // 電話番号
-		Address     string    `db:"address"`      // 住所
-		BirthDate   time.Time `db:"birth_date"`   // 生年月日
-		Gender      string    `db:"gender"`       // 性別
-		Occupation  string    `db:"occupation"`   // 職業
-		Website     string    `db:"website"`      // ウェブサイト
-		SocialLinks string    `db:"social_links"` // ソーシャルメディアリンク
-		Preferences string    `db:"preferences"`  // ユーザー設定
-		CreatedAt   time.Time `db:"created_at"`   // 作成日時
-		UpdatedAt   time.Time `db:"updated_at"`   // 更新日時
+		Id          uint64       `db:"id"`
+		UserId      uint64       `db:"user_id"`      // ユーザーID
+		AvatarUrl   string       `db:"avatar_url"`   // アバター画像URL
+		Bio         string       `db:"bio"`          // 自己紹介
+		Phone       string       `db:"phone"`        // 電話番号
+		Address     string       `db:"address"`      // 住所
+		BirthDate   sql.NullTime `db:"birth_date"`   // 生年月日
+		Gender      string       `db:"gender"`       // 性別
+		Occupation  string       `db:"occupation"`   // 職業
+		Website     string       `db:"website"`      // ウェブサイト
+		SocialLinks string       `db:"social_links"` // ソーシャルメディアリンク
+		Preferences string       `db:"preferences"`  // ユーザー設定
+		CreatedAt   time.Time    `db:"created_at"`   // 作成日時
+		UpdatedAt   time.Time    `db:"updated_at"`   // 更新日時
+		Version     uint64       `db:"version"`      // 楽観的排他制御用のバージョン
 	}
 )
 
@@ -123,8 +124,8 @@ func (m *defaultUserProfilesModel) Insert(ctx context.Context, data *UserProfile
 	winyxCoreUserProfilesIdKey := fmt.Sprintf("%s%v", cacheWinyxCoreUserProfilesIdPrefix, data.Id)
 	winyxCoreUserProfilesUserIdKey := fmt.Sprintf("%s%v", cacheWinyxCoreUserProfilesUserIdPrefix, data.UserId)
 	ret, err := m.ExecCtx(ctx, func(ctx context.Context, conn sqlx.SqlConn) (result sql.Result, err error) {
-		query := fmt.Sprintf("insert into %s (%s) values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)", m.table, userProfilesRowsExpectAutoSet)
-		return conn.ExecCtx(ctx, query, data.UserId, data.AvatarUrl, data.Bio, data.Phone, data.Address, data.BirthDate, data.Gender, data.Occupation, data.Website, data.SocialLinks, data.Preferences)
+		query := fmt.Sprintf("insert into %s (%s) values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)", m.table, userProfilesRowsExpectAutoSet)
+		return conn.ExecCtx(ctx, query, data.UserId, data.AvatarUrl, data.Bio, data.Phone, data.Address, data.BirthDate, data.Gender, data.Occupation, data.Website, data.SocialLinks, data.Preferences, data.Version)
 	}, winyxCoreUserProfilesIdKey, winyxCoreUserProfilesUserIdKey)
 	return ret, err
 }
@@ -139,7 +140,7 @@ func (m *defaultUserProfilesModel) Update(ctx context.Context, newData *UserProf
 	winyxCoreUserProfilesUserIdKey := fmt.Sprintf("%s%v", cacheWinyxCoreUserProfilesUserIdPrefix, data.UserId)
 	_, err = m.ExecCtx(ctx, func(ctx context.Context, conn sqlx.SqlConn) (result sql.Result, err error) {
 		query := fmt.Sprintf("update %s set %s where `id` = ?", m.table, userProfilesRowsWithPlaceHolder)
-		return conn.ExecCtx(ctx, query, newData.UserId, newData.AvatarUrl, newData.Bio, newData.Phone, newData.Address, newData.BirthDate, newData.Gender, newData.Occupation, newData.Website, newData.SocialLinks, newData.Preferences, newData.Id)
+		return conn.ExecCtx(ctx, query, newData.UserId, newData.AvatarUrl, newData.Bio, newData.Phone, newData.Address, newData.BirthDate, newData.Gender, newData.Occupation, newData.Website, newData.SocialLinks, newData.Preferences, newData.Version, newData.Id)
 	}, winyxCoreUserProfilesIdKey, winyxCoreUserProfilesUserIdKey)
 	return err
 }
@@ -3,7 +3,9 @@ package model
 import (
     "context"
     "database/sql"
+    "errors"
     "fmt"
+    "strings"
     "time"
 
     "github.com/zeromicro/go-zero/core/stores/cache"
@@ -19,18 +21,58 @@ type (
     UsersModel interface {
         usersModel
         FindAll(ctx context.Context, limit, offset int) ([]*Users, error)
+        FindByIds(ctx context.Context, ids []uint64) ([]*Users, error)
         Count(ctx context.Context) (int64, error)
         FindByStatus(ctx context.Context, status int8, limit, offset int) ([]*Users, error)
+        Search(ctx context.Context, filter UserFilter) ([]*Users, error)
+        CountMatching(ctx context.Context, filter UserFilter) (int64, error)
+        TouchLastLogin(ctx context.Context, id uint64, at time.Time) error
+        InsertTx(ctx context.Context, tx *Tx, data *Users) (sql.Result, error)
+        LockTx(ctx context.Context, tx *Tx, id uint64) (*Users, error)
+        UpdateTx(ctx context.Context, tx *Tx, data *Users) error
+        DeleteTx(ctx context.Context, tx *Tx, id uint64) error
+        FindDeleted(ctx context.Context, id uint64) (*Users, error)
+        FindDeletedByEmail(ctx context.Context, email string) (*Users, error)
+        FindDeletedBefore(ctx context.Context, deletedBefore time.Time, after *Keyset, limit int) ([]*Users, error)
+        LockDeletedTx(ctx context.Context, tx *Tx, id uint64, deletedBefore time.Time) (*Users, error)
+        SoftDeleteTx(ctx context.Context, tx *Tx, id uint64) error
+        AnonymizeTx(ctx context.Context, tx *Tx, id uint64) error
+        Restore(ctx context.Context, id uint64) error
     }
 
     Users struct {
-        Id        uint64    `db:"id"`
-        Name      string    `db:"name"`      // ユーザー名
-        Email     string    `db:"email"`     // メールアドレス
-        Password  string    `db:"password"`  // ハッシュ化されたパスワード
-        Status    int8      `db:"status"`    // ステータス: 0=無効, 1=有効
-        CreatedAt time.Time `db:"created_at"` // 作成日時
-        UpdatedAt time.Time `db:"updated_at"` // 更新日時
+        Id          uint64       `db:"id"`
+        Name        string       `db:"name"`          // ユーザー名
+        Email       string       `db:"email"`         // メールアドレス
+        Password    string       `db:"password"`      // ハッシュ化されたパスワード
+        Status      int8         `db:"status"`        // ステータス: 0=無効, 1=有効
+        CreatedAt   time.Time    `db:"created_at"`    // 作成日時
+        UpdatedAt   time.Time    `db:"updated_at"`    // 更新日時
+        Version     uint64       `db:"version"`       // 楽観的排他制御用のバージョン
+        LastLoginAt sql.NullTime `db:"last_login_at"` // 最終ログイン日時（未ログインは NULL）
+        DeletedAt   sql.NullTime `db:"deleted_at"`    // 削除日時（ソフトデリート済みの場合のみ）
+    }
+
+    // UserFilter narrows Search and CountMatching. Zero values are ignored.
+    // Query is a partial match on name or email; RoleName and OrgId match users
+    // holding the role or belonging to the organization. Deleted selects the
+    // soft-deleted users instead of the live ones.
+    UserFilter struct {
+        Query       string
+        Deleted     bool
+        Status      *int8
+        RoleName    string
+        OrgId       uint64
+        CreatedFrom time.Time
+        CreatedTo   time.Time
+
+        // Sort is one of the UserSort* columns (id when empty); ties are broken by id.
+        // Limit, Offset and Page are only used by Search; Offset is ignored when Page.After is set.
+        Sort   string
+        Desc   bool
+        Limit  int
+        Offset int
+        Page   KeysetPage
     }
 
     usersModel interface {
@@ -60,7 +102,7 @@ func NewUsersModel(conn sqlx.SqlConn, c cache.CacheConf, opts ...cache.Option) U
 
 func newUsersModel(conn sqlx.SqlConn, c cache.CacheConf, opts ...cache.Option) *defaultUsersModel {
     return &defaultUsersModel{
-        CachedConn: sqlc.NewConn(conn, c, opts...),
+        CachedConn: newCachedConn(conn, c, "users", opts...),
         table:      "`users`",
     }
 }
@@ -79,7 +121,7 @@ func (m *defaultUsersModel) FindOne(ctx context.Context, id uint64) (*Users, err
     usersIdKey := fmt.Sprintf("%s%v", cacheUsersIdPrefix, id)
     var resp Users
     err := m.QueryRowCtx(ctx, &resp, usersIdKey, func(ctx context.Context, conn sqlx.SqlConn, v any) error {
-        query := fmt.Sprintf("select %s from %s where `id` = ? limit 1", usersRows, m.table)
+        query := fmt.Sprintf("select %s from %s where `id` = ? and `deleted_at` is null limit 1", usersRows, m.table)
         return conn.QueryRowCtx(ctx, v, query, id)
     })
     switch err {
@@ -96,7 +138,7 @@ func (m *defaultUsersModel) FindOneByEmail(ctx context.Context, email string) (*
     usersEmailKey := fmt.Sprintf("%s%v", cacheUsersEmailPrefix, email)
     var resp Users
     err := m.QueryRowIndexCtx(ctx, &resp, usersEmailKey, m.formatPrimary, func(ctx context.Context, conn sqlx.SqlConn, v any) (i any, e error) {
-        query := fmt.Sprintf("select %s from %s where `email` = ? limit 1", usersRows, m.table)
+        query := fmt.Sprintf("select %s from %s where `email` = ? and `deleted_at` is null limit 1", usersRows, m.table)
         if err := conn.QueryRowCtx(ctx, &resp, query, email); err != nil {
             return nil, err
         }
@@ -116,7 +158,7 @@ func (m *defaultUsersModel) Update(ctx context.Context, data *Users) error {
     usersIdKey := fmt.Sprintf("%s%v", cacheUsersIdPrefix, data.Id)
     usersEmailKey := fmt.Sprintf("%s%v", cacheUsersEmailPrefix, data.Email)
     _, err := m.ExecCtx(ctx, func(ctx context.Context, conn sqlx.SqlConn) (result sql.Result, err error) {
-        query := fmt.Sprintf("update %s set %s where `id` = ?", m.table, usersRowsWithPlaceHolder)
+        query := fmt.Sprintf("update %s set %s, `version` = `version` + 1 where `id` = ?", m.table, usersRowsWithPlaceHolder)
         return conn.ExecCtx(ctx, query, data.Name, data.Email, data.Password, data.Status, data.UpdatedAt, data.Id)
     }, usersIdKey, usersEmailKey)
     return err
@@ -142,14 +184,15 @@ func (m *defaultUsersModel) formatPrimary(primary any) string {
 }
 
 func (m *defaultUsersModel) queryPrimary(ctx context.Context, conn sqlx.SqlConn, v, primary any) error {
-    query := fmt.Sprintf("select %s from %s where `id` = ? limit 1", usersRows, m.table)
+    query := fmt.Sprintf("select %s from %s where `id` = ? and `deleted_at` is null limit 1", usersRows, m.table)
     return conn.QueryRowCtx(ctx, v, query, primary)
 }
 
-// Custom methods for user management
+// Custom methods for user management.
+// Soft-deleted users are excluded from every lookup except the FindDeleted* methods.
 func (m *customUsersModel) FindAll(ctx context.Context, limit, offset int) ([]*Users, error) {
     var resp []*Users
-    query := fmt.Sprintf("select %s from %s order by id desc limit ? offset ?", usersRows, m.table)
+    query := fmt.Sprintf("select %s from %s where `deleted_at` is null order by id desc limit ? offset ?", usersRows, m.table)
     err := m.QueryRowsNoCacheCtx(ctx, &resp, query, limit, offset)
     switch err {
     case nil:
@@ -159,16 +202,36 @@ func (m *customUsersModel) FindAll(ctx context.Context, limit, offset int) ([]*U
     }
 }
 
+// FindByIds returns the given users with one query, bypassing the per-row cache.
+// Unknown and soft-deleted users are missing from the result.
+func (m *customUsersModel) FindByIds(ctx context.Context, ids []uint64) ([]*Users, error) {
+    var resp []*Users
+    if len(ids) == 0 {
+        return resp, nil
+    }
+
+    args := make([]any, len(ids))
+    for i, id := range ids {
+        args[i] = id
+    }
+    query := fmt.Sprintf("select %s from %s where `id` in (%s) and `deleted_at` is null", usersRows, m.table,
+        strings.Repeat("?,", len(ids)-1)+"?")
+    if err := m.QueryRowsNoCacheCtx(ctx, &resp, query, args...); err != nil {
+        return nil, err
+    }
+    return resp, nil
+}
+
 func (m *customUsersModel) Count(ctx context.Context) (int64, error) {
     var count int64
-    query := fmt.Sprintf("select count(*) from %s", m.table)
+    query := fmt.Sprintf("select count(*) from %s where `deleted_at` is null", m.table)
     err := m.QueryRowNoCacheCtx(ctx, &count, query)
     return count, err
 }
 
 func (m *customUsersModel) FindByStatus(ctx context.Context, status int8, limit, offset int) ([]*Users, error) {
     var resp []*Users
-    query := fmt.Sprintf("select %s from %s where `status` = ? order by id desc limit ? offset ?", usersRows, m.table)
+    query := fmt.Sprintf("select %s from %s where `status` = ? and `deleted_at` is null order by id desc limit ? offset ?", usersRows, m.table)
     err := m.QueryRowsNoCacheCtx(ctx, &resp, query, status, limit, offset)
     switch err {
     case nil:
@@ -178,9 +241,460 @@ func (m *customUsersModel) FindByStatus(ctx context.Context, status int8, limit,
     }
 }
 
+// Sortable columns of Search
+const (
+    UserSortName        = "name"
+    UserSortEmail       = "email"
+    UserSortCreatedAt   = "created_at"
+    UserSortLastLoginAt = "last_login_at"
+)
+
+var userSortColumns = map[string]string{
+    UserSortName:        "`name`",
+    UserSortEmail:       "`email`",
+    UserSortCreatedAt:   "`created_at`",
+    UserSortLastLoginAt: "`last_login_at`",
+}
+
+// IsUserSortColumn reports whether Search can sort by the column
+func IsUserSortColumn(column string) bool {
+    _, ok := userSortColumns[column]
+    return ok
+}
+
+// where builds the condition shared by Search and CountMatching.
+// Every value is passed as a placeholder argument; only fixed SQL fragments are concatenated.
+func (f UserFilter) where() (string, []any) {
+    conds := []string{"`deleted_at` is null"}
+    if f.Deleted {
+        conds[0] = "`deleted_at` is not null"
+    }
+    var args []any
+    if f.Query != "" {
+        pattern := "%" + escapeLike(f.Query) + "%"
+        conds = append(conds, "(`name` like ? or `email` like ?)")
+        args = append(args, pattern, pattern)
+    }
+    if f.Status != nil {
+        conds = append(conds, "`status` = ?")
+        args = append(args, *f.Status)
+    }
+    if f.RoleName != "" {
+        conds = append(conds, "exists (select 1 from `user_roles` ur join `roles` r on r.`id` = ur.`role_id` where ur.`user_id` = `users`.`id` and r.`name` = ?)")
+        args = append(args, f.RoleName)
+    }
+    if f.OrgId > 0 {
+        conds = append(conds, "exists (select 1 from `org_members` om where om.`user_id` = `users`.`id` and om.`org_id` = ?)")
+        args = append(args, f.OrgId)
+    }
+    if !f.CreatedFrom.IsZero() {
+        conds = append(conds, "`created_at` >= ?")
+        args = append(args, f.CreatedFrom)
+    }
+    if !f.CreatedTo.IsZero() {
+        conds = append(conds, "`created_at` < ?")
+        args = append(args, f.CreatedTo)
+    }
+
+    return "where " + strings.Join(conds, " and "), args
+}
+
+// orderBy falls back to id for unknown columns so that the value never reaches the SQL as is
+func (f UserFilter) orderBy(desc bool) string {
+    direction := "asc"
+    if desc {
+        direction = "desc"
+    }
+    column, ok := userSortColumns[f.Sort]
+    if !ok {
+        return "`id` " + direction
+    }
+    return fmt.Sprintf("%s %s, `id` %s", column, direction, direction)
+}
+
+// SortValue returns the value of the Search sort column, to be used as a Keyset.Value
+func (u *Users) SortValue(sort string) any {
+    switch sort {
+    case UserSortName:
+        return u.Name
+    case UserSortEmail:
+        return u.Email
+    case UserSortCreatedAt:
+        return u.CreatedAt
+    case UserSortLastLoginAt:
+        if u.LastLoginAt.Valid {
+            return u.LastLoginAt.Time
+        }
+    }
+    return nil
+}
+
+// escapeLike escapes the wildcard characters of a like pattern
+func escapeLike(s string) string {
+    return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+// Search retrieves users matching the filter in the requested order
+func (m *customUsersModel) Search(ctx context.Context, filter UserFilter) ([]*Users, error) {
+    where, args := filter.where()
+    desc := filter.Page.orderDirection(filter.Desc)
+    offset := filter.Offset
+    if filter.Page.After != nil {
+        cond, condArgs := keysetCond(userSortColumns[filter.Sort], filter.Sort == UserSortLastLoginAt, desc, *filter.Page.After)
+        where += " and " + cond
+        args = append(args, condArgs...)
+        offset = 0
+    }
+    query := fmt.Sprintf("select %s from %s %s order by %s limit ? offset ?", usersRows, m.table, where, filter.orderBy(desc))
+    args = append(args, filter.Limit, offset)
+
+    var resp []*Users
+    if err := m.QueryRowsNoCacheCtx(ctx, &resp, query, args...); err != nil {
+        return nil, err
+    }
+    return restoreOrder(filter.Page, resp), nil
+}
+
+// CountMatching counts the users matching the filter
+func (m *customUsersModel) CountMatching(ctx context.Context, filter UserFilter) (int64, error) {
+    where, args := filter.where()
+    query := fmt.Sprintf("select count(*) from %s %s", m.table, where)
+
+    var count int64
+    err := m.QueryRowNoCacheCtx(ctx, &count, query, args...)
+    return count, err
+}
+
+// TouchLastLogin records a successful login. It is not an edit of the user,
+// so neither updated_at nor version changes.
+func (m *customUsersModel) TouchLastLogin(ctx context.Context, id uint64, at time.Time) error {
+    usersIdKey := fmt.Sprintf("%s%v", cacheUsersIdPrefix, id)
+    _, err := m.ExecCtx(ctx, func(ctx context.Context, conn sqlx.SqlConn) (sql.Result, error) {
+        query := fmt.Sprintf("update %s set `last_login_at` = ?, `updated_at` = `updated_at` where `id` = ?", m.table)
+        return conn.ExecCtx(ctx, query, at, id)
+    }, usersIdKey)
+    return err
+}
+
+// InsertTx inserts a user in the transaction.
+func (m *customUsersModel) InsertTx(ctx context.Context, tx *Tx, data *Users) (sql.Result, error) {
+    query := fmt.Sprintf("insert into %s (%s) values (?, ?, ?, ?, ?, ?)", m.table, usersRowsExpectAutoSet)
+    ret, err := tx.session.ExecCtx(ctx, query, data.Name, data.Email, data.Password, data.Status, data.CreatedAt, data.UpdatedAt)
+    if err != nil {
+        return nil, err
+    }
+
+    // 未登録のメールアドレスで引かれた際のキャッシュを消す
+    tx.evictAfterCommit(m, fmt.Sprintf("%s%v", cacheUsersEmailPrefix, data.Email))
+    return ret, nil
+}
+
+// LockTx reads a user and locks the row until the transaction ends.
+func (m *customUsersModel) LockTx(ctx context.Context, tx *Tx, id uint64) (*Users, error) {
+    var resp Users
+    err := tx.session.QueryRowCtx(ctx, &resp, fmt.Sprintf("select %s from %s where `id` = ? and `deleted_at` is null for update", usersRows, m.table), id)
+    switch err {
+    case nil:
+        return &resp, nil
+    case sqlx.ErrNotFound:
+        return nil, ErrNotFound
+    default:
+        return nil, err
+    }
+}
+
+// UpdateTx updates a user in the transaction and advances its version, evicting the cache of both the old and the new email.
+func (m *customUsersModel) UpdateTx(ctx context.Context, tx *Tx, data *Users) error {
+    var oldEmail string
+    err := tx.session.QueryRowCtx(ctx, &oldEmail, fmt.Sprintf("select `email` from %s where `id` = ? for update", m.table), data.Id)
+    switch err {
+    case nil:
+    case sqlx.ErrNotFound:
+        return ErrNotFound
+    default:
+        return err
+    }
+
+    query := fmt.Sprintf("update %s set %s, `version` = `version` + 1 where `id` = ?", m.table, usersRowsWithPlaceHolder)
+    if _, err := tx.session.ExecCtx(ctx, query, data.Name, data.Email, data.Password, data.Status, data.UpdatedAt, data.Id); err != nil {
+        return err
+    }
+
+    tx.evictAfterCommit(m,
+        fmt.Sprintf("%s%v", cacheUsersIdPrefix, data.Id),
+        fmt.Sprintf("%s%v", cacheUsersEmailPrefix, oldEmail),
+        fmt.Sprintf("%s%v", cacheUsersEmailPrefix, data.Email),
+    )
+    data.Version++
+    return nil
+}
+
+// ErrUserOwnsOrgs 組織のオーナーであるユーザーは削除できない
+var ErrUserOwnsOrgs = errors.New("user owns organizations")
+
+// DeleteTx permanently deletes a user in the transaction and cancels the pending ownership transfers to the user.
+// It returns ErrUserOwnsOrgs while the user still owns an organization, soft-deleted ones included
+// (orgs.owner_id is ON DELETE RESTRICT).
+// Rows referencing the user (memberships, roles, profile) should be deleted through their models
+// in the same transaction beforehand so that their cache is evicted too.
+func (m *customUsersModel) DeleteTx(ctx context.Context, tx *Tx, id uint64) error {
+    email, transferIds, err := m.detachTx(ctx, tx, id, false)
+    if err != nil {
+        return err
+    }
+
+    if _, err := tx.session.ExecCtx(ctx, fmt.Sprintf("delete from %s where `id` = ?", m.table), id); err != nil {
+        return err
+    }
+
+    tx.evictAfterCommit(m, userCacheKeys(id, email, transferIds)...)
+    return nil
+}
+
+// SoftDeleteTx marks a user as deleted in the transaction and cancels the pending ownership transfers to the user.
+// The row and everything referencing it are kept so that the user can be restored; the email stays taken until the purge.
+// It returns ErrUserOwnsOrgs while the user owns an organization that is not soft-deleted.
+func (m *customUsersModel) SoftDeleteTx(ctx context.Context, tx *Tx, id uint64) error {
+    email, transferIds, err := m.detachTx(ctx, tx, id, true)
+    if err != nil {
+        return err
+    }
+
+    query := fmt.Sprintf("update %s set `deleted_at` = ?, `version` = `version` + 1 where `id` = ?", m.table)
+    if _, err := tx.session.ExecCtx(ctx, query, time.Now(), id); err != nil {
+        return err
+    }
+
+    tx.evictAfterCommit(m, userCacheKeys(id, email, transferIds)...)
+    return nil
+}
+
+// detachTx locks a user that is about to be deleted, checks the organizations it owns and cancels
+// the pending ownership transfers to it. With liveOnly, soft-deleted users are reported as ErrNotFound
+// and only the organizations that are not soft-deleted block the deletion.
+func (m *customUsersModel) detachTx(ctx context.Context, tx *Tx, id uint64, liveOnly bool) (string, []uint64, error) {
+    query := fmt.Sprintf("select `email` from %s where `id` = ? for update", m.table)
+    ownedQuery := "select `id` from `orgs` where `owner_id` = ? for update"
+    if liveOnly {
+        query = fmt.Sprintf("select `email` from %s where `id` = ? and `deleted_at` is null for update", m.table)
+        ownedQuery = "select `id` from `orgs` where `owner_id` = ? and `deleted_at` is null for update"
+    }
+
+    var email string
+    err := tx.session.QueryRowCtx(ctx, &email, query, id)
+    switch err {
+    case nil:
+    case sqlx.ErrNotFound:
+        return "", nil, ErrNotFound
+    default:
+        return "", nil, err
+    }
+
+    var owned []uint64
+    if err := tx.session.QueryRowsCtx(ctx, &owned, ownedQuery, id); err != nil {
+        return "", nil, err
+    }
+    if len(owned) > 0 {
+        return "", nil, ErrUserOwnsOrgs
+    }
+
+    var transferIds []uint64
+    if err := tx.session.QueryRowsCtx(ctx, &transferIds, "select `id` from `org_ownership_transfers` where `to_user_id` = ? and `status` = 'pending' for update", id); err != nil {
+        return "", nil, err
+    }
+    if len(transferIds) > 0 {
+        if _, err := tx.session.ExecCtx(ctx, "update `org_ownership_transfers` set `status` = 'cancelled', `responded_at` = ? where `to_user_id` = ? and `status` = 'pending'", time.Now(), id); err != nil {
+            return "", nil, err
+        }
+    }
+    return email, transferIds, nil
+}
+
+// 匿名化したユーザーの名前とメールアドレス
+const (
+    ErasedUserName    = "削除済みユーザー"
+    erasedEmailFormat = "erased-%d@erased.invalid"
+    // notErased is the SQL counterpart of !Erased(), used to keep erased users out of the purge
+    notErased = "`email` <> concat('erased-', `id`, '@erased.invalid')"
+)
+
+// Erased reports whether the personal data of the user has been erased by AnonymizeTx.
+func (u *Users) Erased() bool {
+    return u.Email == fmt.Sprintf(erasedEmailFormat, u.Id)
+}
+
+// AnonymizeTx overwrites the personal data of a user in the transaction and marks it as deleted.
+// The row itself is kept so that audit logs and the erasure record still point at a valid id;
+// the name and email are replaced, the password can no longer match and the session history
+// and password reset tokens of the user are deleted. Invitations sent to the email are readdressed
+// to the anonymized one, and those still pending are revoked.
+// Like SoftDeleteTx it returns ErrUserOwnsOrgs while the user owns an organization that is not soft-deleted.
+func (m *customUsersModel) AnonymizeTx(ctx context.Context, tx *Tx, id uint64) error {
+    email, transferIds, err := m.detachTx(ctx, tx, id, true)
+    if err != nil {
+        return err
+    }
+
+    now := time.Now()
+    erasedEmail := fmt.Sprintf(erasedEmailFormat, id)
+    query := fmt.Sprintf("update %s set `name` = ?, `email` = ?, `password` = '', `status` = 0, `last_login_at` = null, `updated_at` = ?, `deleted_at` = ?, `version` = `version` + 1 where `id` = ?", m.table)
+    if _, err := tx.session.ExecCtx(ctx, query, ErasedUserName, erasedEmail, now, now, id); err != nil {
+        return err
+    }
+    if _, err := tx.session.ExecCtx(ctx, "delete from `user_session_history` where `user_id` = ?", id); err != nil {
+        return err
+    }
+    if _, err := tx.session.ExecCtx(ctx, "delete from `password_resets` where `user_id` = ?", id); err != nil {
+        return err
+    }
+    invitationKeys, err := anonymizeInvitationsTx(ctx, tx, email, erasedEmail)
+    if err != nil {
+        return err
+    }
+
+    keys := append(userCacheKeys(id, email, transferIds), fmt.Sprintf("%s%v", cacheUsersEmailPrefix, erasedEmail))
+    tx.evictAfterCommit(m, append(keys, invitationKeys...)...)
+    return nil
+}
+
+// anonymizeInvitationsTx readdresses the invitations sent to email and returns their cache keys.
+// The invitations are kept for the organizations' records; the pending ones are revoked first so that
+// a leaked token cannot be accepted afterwards.
+func anonymizeInvitationsTx(ctx context.Context, tx *Tx, email, erasedEmail string) ([]string, error) {
+    var invitations []struct {
+        Id        uint64 `db:"id"`
+        TokenHash string `db:"token_hash"`
+    }
+    if err := tx.session.QueryRowsCtx(ctx, &invitations, "select `id`, `token_hash` from `org_invitations` where `email` = ? for update", email); err != nil {
+        return nil, err
+    }
+    if len(invitations) == 0 {
+        return nil, nil
+    }
+
+    if _, err := tx.session.ExecCtx(ctx, "update `org_invitations` set `status` = 'revoked', `responded_at` = ? where `email` = ? and `status` = 'pending'", time.Now(), email); err != nil {
+        return nil, err
+    }
+    if _, err := tx.session.ExecCtx(ctx, "update `org_invitations` set `email` = ? where `email` = ?", erasedEmail, email); err != nil {
+        return nil, err
+    }
+
+    keys := make([]string, 0, len(invitations)*2)
+    for _, inv := range invitations {
+        keys = append(keys,
+            fmt.Sprintf("%s%v", cacheOrgInvitationsIdPrefix, inv.Id),
+            fmt.Sprintf("%s%v", cacheOrgInvitationsTokenHashPrefix, inv.TokenHash))
+    }
+    return keys, nil
+}
+
+func userCacheKeys(id uint64, email string, transferIds []uint64) []string {
+    keys := []string{
+        fmt.Sprintf("%s%v", cacheUsersIdPrefix, id),
+        fmt.Sprintf("%s%v", cacheUsersEmailPrefix, email),
+    }
+    for _, transferId := range transferIds {
+        keys = append(keys, fmt.Sprintf("%s%v", cacheOrgOwnershipTransfersIdPrefix, transferId))
+    }
+    return keys
+}
+
+// Restore clears the deletion mark of a soft-deleted user, returning ErrNotFound
+// when the user does not exist or is not soft-deleted.
+func (m *customUsersModel) Restore(ctx context.Context, id uint64) error {
+    user, err := m.FindDeleted(ctx, id)
+    if err != nil {
+        return err
+    }
+
+    usersIdKey := fmt.Sprintf("%s%v", cacheUsersIdPrefix, id)
+    usersEmailKey := fmt.Sprintf("%s%v", cacheUsersEmailPrefix, user.Email)
+    result, err := m.ExecCtx(ctx, func(ctx context.Context, conn sqlx.SqlConn) (sql.Result, error) {
+        query := fmt.Sprintf("update %s set `deleted_at` = null, `version` = `version` + 1 where `id` = ? and `deleted_at` is not null", m.table)
+        return conn.ExecCtx(ctx, query, id)
+    }, usersIdKey, usersEmailKey)
+    if err != nil {
+        return err
+    }
+    if affected, err := result.RowsAffected(); err != nil {
+        return err
+    } else if affected == 0 {
+        return ErrNotFound
+    }
+    return nil
+}
+
+// FindDeleted retrieves a soft-deleted user. Deleted users are not cached.
+func (m *customUsersModel) FindDeleted(ctx context.Context, id uint64) (*Users, error) {
+    var resp Users
+    query := fmt.Sprintf("select %s from %s where `id` = ? and `deleted_at` is not null limit 1", usersRows, m.table)
+    err := m.QueryRowNoCacheCtx(ctx, &resp, query, id)
+    switch err {
+    case nil:
+        return &resp, nil
+    case sqlc.ErrNotFound:
+        return nil, ErrNotFound
+    default:
+        return nil, err
+    }
+}
+
+// FindDeletedByEmail retrieves the soft-deleted user holding the email, which cannot be
+// registered again until that user is purged.
+func (m *customUsersModel) FindDeletedByEmail(ctx context.Context, email string) (*Users, error) {
+    var resp Users
+    query := fmt.Sprintf("select %s from %s where `email` = ? and `deleted_at` is not null limit 1", usersRows, m.table)
+    err := m.QueryRowNoCacheCtx(ctx, &resp, query, email)
+    switch err {
+    case nil:
+        return &resp, nil
+    case sqlc.ErrNotFound:
+        return nil, ErrNotFound
+    default:
+        return nil, err
+    }
+}
+
+// LockDeletedTx reads a user soft-deleted at or before deletedBefore and locks the row until the transaction ends.
+// It returns ErrNotFound when the user is not (or no longer) in that state, e.g. it was restored in the meantime.
+// Erased users are never returned: their anonymized row is kept for good.
+func (m *customUsersModel) LockDeletedTx(ctx context.Context, tx *Tx, id uint64, deletedBefore time.Time) (*Users, error) {
+    var resp Users
+    query := fmt.Sprintf("select %s from %s where `id` = ? and `deleted_at` is not null and `deleted_at` <= ? and %s for update", usersRows, m.table, notErased)
+    err := tx.session.QueryRowCtx(ctx, &resp, query, id, deletedBefore)
+    switch err {
+    case nil:
+        return &resp, nil
+    case sqlx.ErrNotFound:
+        return nil, ErrNotFound
+    default:
+        return nil, err
+    }
+}
+
+// FindDeletedBefore retrieves soft-deleted users whose restore window has passed, excluding erased users.
+// Users are ordered by (deleted_at, id); a non-nil after skips the users up to that position,
+// so rows that could not be purged do not keep coming back in the next batch.
+func (m *customUsersModel) FindDeletedBefore(ctx context.Context, deletedBefore time.Time, after *Keyset, limit int) ([]*Users, error) {
+    conds := []string{"`deleted_at` is not null", "`deleted_at` <= ?", notErased}
+    args := []any{deletedBefore}
+    if after != nil {
+        cond, condArgs := keysetCond("`deleted_at`", false, false, *after)
+        conds = append(conds, cond)
+        args = append(args, condArgs...)
+    }
+
+    var resp []*Users
+    query := fmt.Sprintf("select %s from %s where %s order by `deleted_at`, `id` limit ?", usersRows, m.table, strings.Join(conds, " and "))
+    if err := m.QueryRowsNoCacheCtx(ctx, &resp, query, append(args, limit)...); err != nil {
+        return nil, err
+    }
+    return resp, nil
+}
+
 var (
-    usersFieldNames          = "id,name,email,password,status,created_at,updated_at"
-    usersRows                = "id,name,email,password,status,created_at,updated_at"
+    usersFieldNames          = "id,name,email,password,status,created_at,updated_at,version,last_login_at,deleted_at"
+    usersRows                = "id,name,email,password,status,created_at,updated_at,version,last_login_at,deleted_at"
     usersRowsExpectAutoSet   = "name,email,password,status,created_at,updated_at"
     usersRowsWithPlaceHolder = "name=?,email=?,password=?,status=?,updated_at=?"
     
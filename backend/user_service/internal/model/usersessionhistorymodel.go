@@ -0,0 +1,40 @@
+package model
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+var _ UserSessionHistoryModel = (*customUserSessionHistoryModel)(nil)
+
+type (
+	// UserSessionHistoryModel is an interface to be customized, add more methods here,
+	// and implement the added methods in customUserSessionHistoryModel.
+	UserSessionHistoryModel interface {
+		userSessionHistoryModel
+		FindByUserId(ctx context.Context, userId uint64) ([]*UserSessionHistory, error)
+	}
+
+	customUserSessionHistoryModel struct {
+		*defaultUserSessionHistoryModel
+	}
+)
+
+// NewUserSessionHistoryModel returns a model for the database table.
+func NewUserSessionHistoryModel(conn sqlx.SqlConn) UserSessionHistoryModel {
+	return &customUserSessionHistoryModel{
+		defaultUserSessionHistoryModel: newUserSessionHistoryModel(conn),
+	}
+}
+
+// FindByUserId retrieves the sessions of a user, newest first.
+func (m *customUserSessionHistoryModel) FindByUserId(ctx context.Context, userId uint64) ([]*UserSessionHistory, error) {
+	query := fmt.Sprintf("select %s from %s where `user_id` = ? order by `login_at` desc, `id` desc", userSessionHistoryRows, m.table)
+	var resp []*UserSessionHistory
+	if err := m.conn.QueryRowsCtx(ctx, &resp, query, userId); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
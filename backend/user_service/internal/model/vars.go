@@ -1,7 +1,22 @@
 package model
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/winyx/backend/common/metrics"
+
+	"github.com/zeromicro/go-zero/core/stores/cache"
+	"github.com/zeromicro/go-zero/core/stores/sqlc"
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
 
 var (
 	ErrNotFound = errors.New("record not found")
-)
\ No newline at end of file
+	// ErrVersionConflict 楽観的排他制御で、読み込んだ後に他の更新が行われていた
+	ErrVersionConflict = errors.New("version conflict")
+)
+
+// newCachedConn キャッシュのヒット/ミスをテーブル名ラベルで計測する CachedConn を作成
+func newCachedConn(conn sqlx.SqlConn, c cache.CacheConf, table string, opts ...cache.Option) sqlc.CachedConn {
+	return sqlc.NewConnWithCache(conn, metrics.NewCache(table, c, opts...))
+}
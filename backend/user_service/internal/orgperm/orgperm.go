@@ -0,0 +1,250 @@
+// Package orgperm 組織スコープの権限判定（「ユーザー X は組織 O で権限 P を持つか」）
+//
+// 組織内ロール（org_roles）と権限（org_role_permissions）はグローバルな roles とは独立しており、
+// システム管理者（roles の admin）は所属に関係なく全組織の全権限を持つ。
+// API キーでの操作は、キーの組織でキーの作成者が持つ権限のうちスコープに含まれるものに限られる。
+package orgperm
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"time"
+
+	"user_service/internal/model"
+
+	"github.com/winyx/backend/common/auth"
+
+	"github.com/zeromicro/go-zero/core/collection"
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// 組織内ロール（org_roles.name）
+const (
+	RoleOwner  = "owner"
+	RoleAdmin  = "admin"
+	RoleMember = "member"
+	RoleViewer = "viewer"
+)
+
+// 組織スコープの権限（org_role_permissions.permission）
+const (
+	OrgView           = "org:view"
+	OrgUpdate         = "org:update"
+	OrgDelete         = "org:delete"
+	OrgTransfer       = "org:transfer"
+	MembersView       = "members:view"
+	MembersManage     = "members:manage"
+	InvitationsManage = "invitations:manage"
+	ResourcesRead     = "resources:read"
+	ResourcesWrite    = "resources:write"
+	ApiKeysManage     = "api_keys:manage"
+)
+
+// システム管理者のグローバルロール
+const systemAdminRole = "admin"
+
+// ロール毎の権限は頻繁に変わらないため、プロセス内で短時間キャッシュする
+const permissionCacheExpiry = time.Minute
+
+// Grant ユーザーが組織内で持つロールと権限
+type Grant struct {
+	OrgId       uint64
+	UserId      uint64
+	Member      *model.OrgMembers // 非メンバーの場合は nil
+	Role        *model.OrgRoles   // 非メンバーの場合は nil
+	SystemAdmin bool
+
+	permissions map[string]bool
+}
+
+// IsMember 組織のメンバーか
+func (g *Grant) IsMember() bool {
+	return g.Member != nil
+}
+
+// RoleName 組織内ロール名（非メンバーは空）
+func (g *Grant) RoleName() string {
+	if g.Role == nil {
+		return ""
+	}
+	return g.Role.Name
+}
+
+// Has 権限を持つか（システム管理者は常に true）
+func (g *Grant) Has(permission string) bool {
+	return g.SystemAdmin || g.permissions[permission]
+}
+
+// Permissions 組織内ロールで付与された権限一覧（システム管理者の上書きは含まない）
+func (g *Grant) Permissions() []string {
+	permissions := make([]string, 0, len(g.permissions))
+	for permission := range g.permissions {
+		permissions = append(permissions, permission)
+	}
+	sort.Strings(permissions)
+
+	return permissions
+}
+
+// Resolver 組織メンバーシップとロール定義から権限を解決する
+type Resolver struct {
+	members   model.OrgMembersModel
+	roles     model.OrgRolesModel
+	userRoles model.UserRolesModel
+	cache     *collection.Cache
+}
+
+// NewResolver 新しい権限リゾルバーを作成
+func NewResolver(members model.OrgMembersModel, roles model.OrgRolesModel, userRoles model.UserRolesModel) *Resolver {
+	cache, err := collection.NewCache(permissionCacheExpiry, collection.WithName("org-role-permissions"))
+	logx.Must(err)
+
+	return &Resolver{
+		members:   members,
+		roles:     roles,
+		userRoles: userRoles,
+		cache:     cache,
+	}
+}
+
+// Resolve ユーザーの組織内ロールと権限を取得
+// 非メンバーでもエラーにはせず、権限を持たない Grant を返す
+func (r *Resolver) Resolve(ctx context.Context, orgId, userId uint64) (*Grant, error) {
+	systemAdmin, err := r.IsSystemAdmin(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	grant := &Grant{
+		OrgId:       orgId,
+		UserId:      userId,
+		SystemAdmin: systemAdmin,
+	}
+
+	// API キーは発行元の組織以外では非メンバーとして扱う
+	if keyOrgId, ok := apiKeyOrgId(ctx); ok && keyOrgId != orgId {
+		return grant, nil
+	}
+
+	member, err := r.members.FindOneByOrgIdUserId(ctx, orgId, userId)
+	switch {
+	case errors.Is(err, model.ErrNotFound):
+		return grant, nil
+	case err != nil:
+		return nil, err
+	}
+
+	role, permissions, err := r.rolePermissions(ctx, member.RoleId)
+	if err != nil {
+		return nil, err
+	}
+
+	grant.Member = member
+	grant.Role = role
+	grant.permissions = restrictToScopes(ctx, permissions)
+	return grant, nil
+}
+
+// HasPermission ユーザーが組織内で権限を持つか
+func (r *Resolver) HasPermission(ctx context.Context, orgId, userId uint64, permission string) (bool, error) {
+	grant, err := r.Resolve(ctx, orgId, userId)
+	if err != nil {
+		return false, err
+	}
+
+	return grant.Has(permission), nil
+}
+
+// Memberships ユーザーが所属する全組織のロールと権限
+func (r *Resolver) Memberships(ctx context.Context, userId uint64) ([]*Grant, error) {
+	members, err := r.members.FindByUserId(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	grants := make([]*Grant, 0, len(members))
+	for _, member := range members {
+		role, permissions, err := r.rolePermissions(ctx, member.RoleId)
+		if err != nil {
+			return nil, err
+		}
+		grants = append(grants, &Grant{
+			OrgId:       member.OrgId,
+			UserId:      userId,
+			Member:      member,
+			Role:        role,
+			permissions: permissions,
+		})
+	}
+
+	return grants, nil
+}
+
+// IsSystemAdmin グローバルロールでシステム管理者か
+// API キーでの操作にはシステム管理者の権限を与えない
+func (r *Resolver) IsSystemAdmin(ctx context.Context, userId uint64) (bool, error) {
+	if _, ok := auth.ApiKeyIdFromContext(ctx); ok {
+		return false, nil
+	}
+
+	return r.userRoles.CheckUserRole(ctx, int64(userId), systemAdminRole)
+}
+
+// apiKeyOrgId API キーで認証されたリクエストの場合、キーの組織IDを返す
+func apiKeyOrgId(ctx context.Context) (uint64, bool) {
+	if _, ok := auth.ApiKeyIdFromContext(ctx); !ok {
+		return 0, false
+	}
+
+	orgId, _ := auth.OrgIdFromContext(ctx)
+	return uint64(orgId), true
+}
+
+// restrictToScopes API キーの場合はロールの権限をキーのスコープで絞り込む
+// キャッシュ済みのロール権限は共有されているため、絞り込み結果は新しい map で返す
+func restrictToScopes(ctx context.Context, permissions map[string]bool) map[string]bool {
+	if _, ok := auth.ApiKeyIdFromContext(ctx); !ok {
+		return permissions
+	}
+
+	restricted := make(map[string]bool)
+	for _, scope := range auth.ScopesFromContext(ctx) {
+		if permissions[scope] {
+			restricted[scope] = true
+		}
+	}
+
+	return restricted
+}
+
+type cachedRole struct {
+	role        *model.OrgRoles
+	permissions map[string]bool
+}
+
+func (r *Resolver) rolePermissions(ctx context.Context, roleId int64) (*model.OrgRoles, map[string]bool, error) {
+	v, err := r.cache.Take(strconv.FormatInt(roleId, 10), func() (any, error) {
+		role, err := r.roles.FindOne(ctx, roleId)
+		if err != nil {
+			return nil, err
+		}
+		names, err := r.roles.FindPermissions(ctx, roleId)
+		if err != nil {
+			return nil, err
+		}
+
+		permissions := make(map[string]bool, len(names))
+		for _, name := range names {
+			permissions[name] = true
+		}
+		return &cachedRole{role: role, permissions: permissions}, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cached := v.(*cachedRole)
+	return cached.role, cached.permissions, nil
+}
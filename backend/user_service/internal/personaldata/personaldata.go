@@ -0,0 +1,168 @@
+// Package personaldata は本人に提供する個人データのアーカイブ（データポータビリティ）を組み立てる
+//
+// アーカイブはアカウント・プロフィール・ロール・組織のメンバーシップ・セッション履歴の各項目からなる。
+// JSON では 1 つのオブジェクトに、ZIP では項目ごとの JSON ファイルにまとめる。
+// パスワードのハッシュやトークンのハッシュなど、本人にも渡さない値は含めない。
+package personaldata
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+// Format アーカイブの形式
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatZIP  Format = "zip"
+)
+
+var ErrUnsupportedFormat = errors.New("形式は json または zip を指定してください")
+
+// ParseFormat 形式の指定を解釈する
+func ParseFormat(name string) (Format, error) {
+	switch Format(strings.ToLower(name)) {
+	case FormatJSON:
+		return FormatJSON, nil
+	case FormatZIP:
+		return FormatZIP, nil
+	default:
+		return "", ErrUnsupportedFormat
+	}
+}
+
+// ContentType ダウンロードのレスポンスの Content-Type
+func (f Format) ContentType() string {
+	if f == FormatZIP {
+		return "application/zip"
+	}
+	return "application/json; charset=utf-8"
+}
+
+// Extension ダウンロードのファイル名の拡張子
+func (f Format) Extension() string {
+	if f == FormatZIP {
+		return "zip"
+	}
+	return "json"
+}
+
+// Archive 1 人分の個人データ
+type Archive struct {
+	GeneratedAt    string          `json:"generated_at"`
+	Account        Account         `json:"account"`
+	Profile        *Profile        `json:"profile"` // プロフィール未登録の場合は null
+	Roles          []Role          `json:"roles"`
+	OrgMemberships []OrgMembership `json:"org_memberships"`
+	SessionHistory []Session       `json:"session_history"`
+}
+
+// Account users テーブルの項目（パスワードは含めない）
+type Account struct {
+	Id          uint64 `json:"id"`
+	Name        string `json:"name"`
+	Email       string `json:"email"`
+	Status      int8   `json:"status"`
+	CreatedAt   string `json:"created_at"`
+	UpdatedAt   string `json:"updated_at"`
+	LastLoginAt string `json:"last_login_at,omitempty"`
+}
+
+// Profile user_profiles テーブルの項目
+type Profile struct {
+	AvatarUrl   string          `json:"avatar_url,omitempty"`
+	Bio         string          `json:"bio,omitempty"`
+	Phone       string          `json:"phone,omitempty"`
+	Address     string          `json:"address,omitempty"`
+	BirthDate   string          `json:"birth_date,omitempty"`
+	Gender      string          `json:"gender,omitempty"`
+	Occupation  string          `json:"occupation,omitempty"`
+	Website     string          `json:"website,omitempty"`
+	SocialLinks json.RawMessage `json:"social_links,omitempty"`
+	Preferences json.RawMessage `json:"preferences,omitempty"`
+	CreatedAt   string          `json:"created_at"`
+	UpdatedAt   string          `json:"updated_at"`
+}
+
+// Role 付与されているシステムロール
+type Role struct {
+	Name       string `json:"name"`
+	AssignedAt string `json:"assigned_at"`
+}
+
+// OrgMembership 所属している組織と組織内ロール
+type OrgMembership struct {
+	OrgId    uint64 `json:"org_id"`
+	OrgName  string `json:"org_name"`
+	Role     string `json:"role"`
+	JoinedAt string `json:"joined_at"`
+}
+
+// Session ログインの履歴（トークンのハッシュは含めない）
+type Session struct {
+	IpAddress string `json:"ip_address,omitempty"`
+	UserAgent string `json:"user_agent,omitempty"`
+	LoginAt   string `json:"login_at"`
+	LogoutAt  string `json:"logout_at,omitempty"`
+	ExpiresAt string `json:"expires_at"`
+	Status    string `json:"status"`
+}
+
+// Encode アーカイブを指定の形式で書き出す
+func (a *Archive) Encode(format Format) ([]byte, error) {
+	if format != FormatZIP {
+		return marshal(a)
+	}
+
+	// ZIP は項目ごとのファイルに分け、生成日時をエントリの更新日時にする
+	modified, err := time.Parse(time.RFC3339, a.GeneratedAt)
+	if err != nil {
+		modified = time.Now()
+	}
+	entries := []struct {
+		name  string
+		value any
+	}{
+		{"account.json", a.Account},
+		{"profile.json", a.Profile},
+		{"roles.json", a.Roles},
+		{"org_memberships.json", a.OrgMemberships},
+		{"session_history.json", a.SessionHistory},
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, entry := range entries {
+		data, err := marshal(entry.value)
+		if err != nil {
+			return nil, err
+		}
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: entry.name, Method: zip.Deflate, Modified: modified})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(data); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func marshal(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
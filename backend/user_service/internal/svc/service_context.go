@@ -1,36 +1,161 @@
 package svc
 
 import (
+	"context"
+
+	"user_service/internal/apikey"
+	"user_service/internal/audit"
+	"user_service/internal/blob"
 	"user_service/internal/config"
+	"user_service/internal/cursor"
+	"user_service/internal/dataloader"
+	"user_service/internal/job"
 	"user_service/internal/model"
+	"user_service/internal/orgperm"
+
+	"github.com/winyx/backend/common/featureflag"
+	"github.com/winyx/backend/common/maintenance"
+	"github.com/winyx/backend/common/notify"
+	"github.com/winyx/backend/common/rpc"
+	"github.com/winyx/backend/common/tenant"
 
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/stores/redis"
 	"github.com/zeromicro/go-zero/core/stores/sqlx"
+	"github.com/zeromicro/go-zero/rest"
 )
 
 type ServiceContext struct {
-	Config             config.Config
-	Conn               sqlx.SqlConn
-	DB                 sqlx.SqlConn // test_api互換のため
-	UsersModel         model.UsersModel
-	UserProfilesModel  model.UserProfilesModel
-	RolesModel         model.RolesModel
-	UserRolesModel     model.UserRolesModel
-	OrgsModel          model.OrgsModel
-	OrgMembersModel    model.OrgMembersModel
+	Config                config.Config
+	Conn                  sqlx.SqlConn
+	DB                    sqlx.SqlConn // test_api互換のため
+	UnitOfWork            *model.UnitOfWork
+	UsersModel            model.UsersModel
+	UserProfilesModel     model.UserProfilesModel
+	RolesModel            model.RolesModel
+	UserRolesModel        model.UserRolesModel
+	OrgsModel             model.OrgsModel
+	OrgMembersModel       model.OrgMembersModel
+	OrgRolesModel         model.OrgRolesModel
+	FeatureFlagsModel     model.FeatureFlagsModel
+	OrgInvitationsModel   model.OrgInvitationsModel
+	OrgTransfersModel     model.OrgOwnershipTransfersModel
+	OrgApiKeysModel       model.OrgApiKeysModel
+	AuditLogsModel        model.AuditLogsModel
+	UserImportJobsModel   model.UserImportJobsModel
+	UserSessionsModel     model.UserSessionHistoryModel
+	DataExportsModel      model.UserDataExportsModel
+	ErasureRequestsModel  model.UserErasureRequestsModel
+	Redis                 *redis.Redis
+	Maintenance           *maintenance.Store
+	FeatureFlags          *featureflag.Client
+	OrgPermissions        *orgperm.Resolver
+	ServiceAuthMiddleware rest.Middleware
+	TenantMiddleware      rest.Middleware
+	ApiKeys               *apikey.Authenticator
+	Notifier              notify.Notifier
+	Blobs                 blob.Store // アバター画像などの保存先
+	OrgPurger             *job.OrgPurger
+	UserPurger            *job.UserPurger
+	Audit                 *audit.Writer
+	Cursors               *cursor.Codec
+	Loaders               *dataloader.Loaders
 }
 
 func NewServiceContext(c config.Config) *ServiceContext {
 	conn := sqlx.NewMysql(c.Mysql.DataSource)
-	
-	return &ServiceContext{
-		Config:            c,
-		Conn:              conn,
-		DB:                conn, // 別名として設定
-		UsersModel:        model.NewUsersModel(conn, c.CacheConf),
-		UserProfilesModel: model.NewUserProfilesModel(conn, c.CacheConf),
-		RolesModel:        model.NewRolesModel(conn, c.CacheConf),
-		UserRolesModel:    model.NewUserRolesModel(conn, c.CacheConf),
-		OrgsModel:         model.NewOrgsModel(conn, c.CacheConf),
-		OrgMembersModel:   model.NewOrgMembersModel(conn, c.CacheConf),
+	rds := redis.MustNewRedis(c.Redis)
+
+	svcCtx := &ServiceContext{
+		Config:                c,
+		Conn:                  conn,
+		DB:                    conn, // 別名として設定
+		UnitOfWork:            model.NewUnitOfWork(conn),
+		UsersModel:            model.NewUsersModel(conn, c.CacheConf),
+		UserProfilesModel:     model.NewUserProfilesModel(conn, c.CacheConf),
+		RolesModel:            model.NewRolesModel(conn, c.CacheConf),
+		UserRolesModel:        model.NewUserRolesModel(conn, c.CacheConf),
+		OrgsModel:             model.NewOrgsModel(conn, c.CacheConf),
+		OrgMembersModel:       model.NewOrgMembersModel(conn, c.CacheConf),
+		OrgRolesModel:         model.NewOrgRolesModel(conn, c.CacheConf),
+		FeatureFlagsModel:     model.NewFeatureFlagsModel(conn, c.CacheConf),
+		OrgInvitationsModel:   model.NewOrgInvitationsModel(conn, c.CacheConf),
+		OrgTransfersModel:     model.NewOrgOwnershipTransfersModel(conn, c.CacheConf),
+		OrgApiKeysModel:       model.NewOrgApiKeysModel(conn, c.CacheConf),
+		AuditLogsModel:        model.NewAuditLogsModel(conn),
+		UserImportJobsModel:   model.NewUserImportJobsModel(conn),
+		UserSessionsModel:     model.NewUserSessionHistoryModel(conn),
+		DataExportsModel:      model.NewUserDataExportsModel(conn),
+		ErasureRequestsModel:  model.NewUserErasureRequestsModel(conn),
+		Redis:                 rds,
+		Maintenance:           maintenance.NewStore(rds, c.Maintenance.Key),
+		ServiceAuthMiddleware: rpc.NewServiceAuth(c.ServiceAuth).Handle,
+		Notifier:              notify.MustNewNotifier(c.Notifier),
+		Blobs:                 blob.MustNewStore(c.Blob),
+		Cursors:               cursor.NewCodec(c.Cursor.SigningSecret(c.Auth.AccessSecret)),
+	}
+
+	// 起動時に MySQL のフラグを Redis へ書き出してから SDK を初期化する
+	if err := svcCtx.PublishFeatureFlags(context.Background()); err != nil {
+		logx.Errorf("フィーチャーフラグの同期に失敗しました: %v", err)
+	}
+	svcCtx.FeatureFlags = featureflag.NewClient(rds, c.FeatureFlags)
+	svcCtx.OrgPermissions = orgperm.NewResolver(svcCtx.OrgMembersModel, svcCtx.OrgRolesModel, svcCtx.UserRolesModel)
+	svcCtx.Audit = audit.NewWriter(svcCtx.AuditLogsModel, c.Audit.DigestKey(c.Auth.AccessSecret))
+	svcCtx.Loaders = dataloader.New(svcCtx.UserRolesModel, svcCtx.UserProfilesModel)
+	svcCtx.OrgPurger = job.NewOrgPurger(svcCtx.OrgsModel, svcCtx.Audit, rds, c.Org)
+	svcCtx.UserPurger = job.NewUserPurger(job.UserPurgeModels{
+		UnitOfWork:   svcCtx.UnitOfWork,
+		Users:        svcCtx.UsersModel,
+		UserRoles:    svcCtx.UserRolesModel,
+		UserProfiles: svcCtx.UserProfilesModel,
+		OrgMembers:   svcCtx.OrgMembersModel,
+		Orgs:         svcCtx.OrgsModel,
+	}, svcCtx.Audit, rds, c.User)
+	svcCtx.TenantMiddleware = tenant.NewMiddleware(svcCtx.tenantMember).Handle
+	svcCtx.ApiKeys = apikey.NewAuthenticator(svcCtx.OrgApiKeysModel, svcCtx.OrgsModel, svcCtx.OrgMembersModel,
+		svcCtx.UsersModel, c.Auth.AccessSecret)
+
+	return svcCtx
+}
+
+// Start バックグラウンドジョブを開始
+func (s *ServiceContext) Start() {
+	s.OrgPurger.Start()
+	s.UserPurger.Start()
+}
+
+// Stop バックグラウンドジョブを停止
+func (s *ServiceContext) Stop() {
+	s.OrgPurger.Stop()
+	s.UserPurger.Stop()
+}
+
+// PublishFeatureFlags MySQL のフラグ一覧を Redis に書き出し、各サービスへ変更を通知する
+func (s *ServiceContext) PublishFeatureFlags(ctx context.Context) error {
+	rows, err := s.FeatureFlagsModel.FindAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	flags := make([]featureflag.Flag, 0, len(rows))
+	for _, row := range rows {
+		flag, err := row.ToFlag()
+		if err != nil {
+			logx.WithContext(ctx).Errorf("フィーチャーフラグを読み飛ばします: %v", err)
+			continue
+		}
+		flags = append(flags, flag)
 	}
+
+	if err := featureflag.Publish(ctx, s.Redis, s.Config.FeatureFlags.Key, flags); err != nil {
+		return err
+	}
+
+	// 自サービスのキャッシュは監視間隔を待たずに更新する
+	if s.FeatureFlags != nil {
+		s.FeatureFlags.Refresh(ctx)
+	}
+
+	return nil
 }
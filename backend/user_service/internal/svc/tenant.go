@@ -0,0 +1,40 @@
+package svc
+
+import (
+	"context"
+	"errors"
+
+	"user_service/internal/model"
+
+	"github.com/winyx/backend/common/tenant"
+)
+
+// tenantMember テナントミドルウェア向けに組織への所属を OrgMembersModel で確認する
+// 削除済み（復元待ち）の組織は所属していないものとして扱う
+func (s *ServiceContext) tenantMember(ctx context.Context, orgId, userId int64) (string, error) {
+	org, err := s.OrgsModel.FindOne(ctx, uint64(orgId))
+	if err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			return "", tenant.ErrNotMember
+		}
+		return "", err
+	}
+	if org.DeletedAt.Valid {
+		return "", tenant.ErrNotMember
+	}
+
+	member, err := s.OrgMembersModel.FindOneByOrgIdUserId(ctx, org.Id, uint64(userId))
+	if err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			return "", tenant.ErrNotMember
+		}
+		return "", err
+	}
+
+	role, err := s.OrgRolesModel.FindOne(ctx, member.RoleId)
+	if err != nil {
+		return "", err
+	}
+
+	return role.Name, nil
+}
@@ -6,7 +6,96 @@ package types
 type AddOrgMemberReq struct {
 	OrgId    int64  `path:"id"`
 	UserId   int64  `json:"user_id"`
-	RoleName string `json:"role_name"` // "admin", "member" など
+	RoleName string `json:"role_name"` // "admin", "member", "viewer"
+}
+
+type AuditChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+type AuditLog struct {
+	Id            int64                  `json:"id"`
+	ActorUserId   int64                  `json:"actor_user_id"` // 0 は未認証（ログイン失敗など）またはシステム
+	ActorApiKeyId int64                  `json:"actor_api_key_id,omitempty"`
+	OrgId         int64                  `json:"org_id,omitempty"`
+	Action        string                 `json:"action"`
+	TargetType    string                 `json:"target_type"`
+	TargetId      string                 `json:"target_id"`
+	Diff          map[string]AuditChange `json:"diff"`
+	Ip            string                 `json:"ip"`
+	UserAgent     string                 `json:"user_agent"`
+	RequestId     string                 `json:"request_id"`
+	Hash          string                 `json:"hash"`
+	OccurredAt    string                 `json:"occurred_at"`
+}
+
+type AuditLogListReq struct {
+	ActorUserId int64  `form:"actor_user_id,optional"`
+	OrgId       int64  `form:"org_id,optional"`
+	Action      string `form:"action,optional"`
+	TargetType  string `form:"target_type,optional"`
+	TargetId    string `form:"target_id,optional"`
+	From        string `form:"from,optional"` // RFC3339（この日時以降）
+	To          string `form:"to,optional"`   // RFC3339（この日時より前）
+	Cursor      string `form:"cursor,optional"`
+	Limit       int    `form:"limit,optional,default=50,range=[1:200]"`
+}
+
+type AuditLogListRes struct {
+	Logs       []AuditLog `json:"logs"`
+	NextCursor string     `json:"next_cursor,omitempty"` // 続きが無い場合は空
+	PrevCursor string     `json:"prev_cursor,omitempty"` // 先頭ページでは空
+}
+
+type AuditLogVerifyReq struct {
+	AfterId int64 `form:"after_id,optional"`
+	Limit   int   `form:"limit,optional,default=1000,range=[1:10000]"`
+}
+
+type AuditLogVerifyRes struct {
+	Valid    bool   `json:"valid"`
+	Checked  int    `json:"checked"`
+	LastId   int64  `json:"last_id"`
+	Complete bool   `json:"complete"` // チェーンの末尾まで検証した
+	BrokenId int64  `json:"broken_id,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+type AvatarFileReq struct {
+	Name string `path:"name"` // "<SHA-256>_<サイズ>.<jpg|png>"
+}
+
+type AvatarReq struct {
+	IfMatch string `header:"If-Match,optional"` // 指定した場合のみ現在のプロフィールの ETag と照合する
+}
+
+type AvatarRes struct {
+	Profile    UserProfileData   `json:"profile"`
+	Thumbnails []AvatarThumbnail `json:"thumbnails"` // 小さい順。profile.avatar_url はこのうち 256 のもの
+	Etag       string            `json:"etag"`       // ETag ヘッダーと同じ値
+}
+
+type AvatarThumbnail struct {
+	Size int    `json:"size"` // 一辺の画素数（正方形）
+	Url  string `json:"url"`
+}
+
+type CheckOrgPermissionReq struct {
+	UserId     int64  `json:"user_id"`
+	OrgId      int64  `json:"org_id"`
+	Permission string `json:"permission"`
+}
+
+type CheckPermissionReq struct {
+	UserId   int64  `json:"user_id"`
+	Resource string `json:"resource"`
+	Action   string `json:"action"`
+}
+
+type CheckPermissionRes struct {
+	Allowed bool   `json:"allowed"`
+	Reason  string `json:"reason,omitempty"` // 拒否理由
 }
 
 type CommonRes struct {
@@ -14,14 +103,143 @@ type CommonRes struct {
 	Success bool   `json:"success"`
 }
 
+type CreateFeatureFlagReq struct {
+	Key               string           `json:"key"`
+	Description       string           `json:"description,optional"`
+	Enabled           bool             `json:"enabled,optional"`
+	Rules             FeatureFlagRules `json:"rules,optional"`
+	RolloutPercentage int              `json:"rollout_percentage,default=100,range=[0:100]"` // 段階的ロールアウト率（％）
+}
+
 type CreateOrgReq struct {
 	Name string `json:"name" validate:"required,min=2,max=100"`
 }
 
+type CreateOrgApiKeyReq struct {
+	OrgId      int64    `path:"id"`
+	Name       string   `json:"name"`
+	Scopes     []string `json:"scopes"` // 組織内権限（例: org:view, resources:read）。作成者が持つ権限のみ指定可
+	ExpireDays int      `json:"expire_days,optional,range=[0:3650]"`
+}
+
+type CreateOrgApiKeyRes struct {
+	ApiKey OrgApiKey `json:"api_key"`
+	Key    string    `json:"key"` // Authorization: Bearer に指定するキー。再表示はできない
+}
+
+type CreateOrgInvitationReq struct {
+	OrgId       int64  `path:"id"`
+	Email       string `json:"email"`
+	RoleName    string `json:"role_name"` // "admin", "member", "viewer"
+	ExpireHours int    `json:"expire_hours,optional,range=[0:720]"`
+}
+
+type DataExport struct {
+	Id         int64  `json:"id"`
+	Status     string `json:"status"` // pending / running / completed / failed
+	Format     string `json:"format"`
+	Size       int64  `json:"size"`             // アーカイブのバイト数
+	Sha256     string `json:"sha256,omitempty"` // ダウンロードしたファイルの検証用
+	Error      string `json:"error,omitempty"`
+	ExpiresAt  string `json:"expires_at,omitempty"` // ダウンロードの期限
+	CreatedAt  string `json:"created_at"`
+	StartedAt  string `json:"started_at,omitempty"`
+	FinishedAt string `json:"finished_at,omitempty"`
+}
+
+type DataExportIdReq struct {
+	Id int64 `path:"id"`
+}
+
+type DataExportReq struct {
+	Format string `json:"format,optional,default=zip,options=json|zip"`
+}
+
+type ErasureRequest struct {
+	Id              int64  `json:"id"`
+	UserId          int64  `json:"user_id"`
+	Status          string `json:"status"` // pending / rejected / cancelled / completed
+	Reason          string `json:"reason,omitempty"`
+	ReviewedBy      int64  `json:"reviewed_by,omitempty"`
+	ReviewNote      string `json:"review_note,omitempty"`
+	ReviewedAt      string `json:"reviewed_at,omitempty"`
+	CompletedAt     string `json:"completed_at,omitempty"`
+	Certificate     string `json:"certificate,omitempty"`      // 完了記録（JSON の文字列。certificate_hash はこの文字列の SHA-256）
+	CertificateHash string `json:"certificate_hash,omitempty"` // 監査ログの privacy.erasure_complete にも記録される
+	CreatedAt       string `json:"created_at"`
+}
+
+type ErasureRequestIdReq struct {
+	Id int64 `path:"id"`
+}
+
+type ErasureRequestListReq struct {
+	Status string `form:"status,optional,default=pending,options=pending|rejected|cancelled|completed"`
+	Limit  int    `form:"limit,optional,default=50,range=[1:200]"`
+	Offset int    `form:"offset,optional,range=[0:]"`
+}
+
+type ErasureRequestListRes struct {
+	Requests []ErasureRequest `json:"requests"`
+	Total    int64            `json:"total"`
+}
+
+type ErasureRequestReq struct {
+	Password string `json:"password"` // 本人確認のため現在のパスワードを再入力する
+	Reason   string `json:"reason,optional"`
+}
+
+type ErasureReviewReq struct {
+	Id   int64  `path:"id"`
+	Note string `json:"note,optional"`
+}
+
+type FeatureFlag struct {
+	Key               string           `json:"key"`
+	Description       string           `json:"description"`
+	Enabled           bool             `json:"enabled"`
+	Rules             FeatureFlagRules `json:"rules"`
+	RolloutPercentage int              `json:"rollout_percentage"`
+	CreatedAt         string           `json:"created_at"`
+	UpdatedAt         string           `json:"updated_at"`
+}
+
+type FeatureFlagKeyReq struct {
+	Key string `path:"key"`
+}
+
+type FeatureFlagListRes struct {
+	Flags []FeatureFlag `json:"flags"`
+}
+
+type FeatureFlagRules struct {
+	UserIds []int64  `json:"user_ids,optional"` // 対象ユーザーID
+	Roles   []string `json:"roles,optional"`    // 対象ロール名
+	OrgIds  []int64  `json:"org_ids,optional"`  // 対象組織ID
+}
+
+type FeaturesRes struct {
+	Features map[string]bool `json:"features"` // フラグキー毎の評価結果
+}
+
 type GetOrgReq struct {
 	Id int64 `path:"id"`
 }
 
+type HealthRes struct {
+	Status    string `json:"status"`     // healthy / degraded
+	Database  string `json:"database"`   // up / down
+	Version   string `json:"version"`    // ビルドバージョン
+	Commit    string `json:"commit"`     // ビルド元のコミット
+	BuildTime string `json:"build_time"` // ビルド日時
+	Dirty     bool   `json:"dirty"`      // 未コミットの変更を含むビルドか
+	Timestamp int64  `json:"timestamp"`
+}
+
+type InvitationTokenReq struct {
+	Token string `json:"token"`
+}
+
 type LoginReq struct {
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required"`
@@ -38,14 +256,99 @@ type Org struct {
 	OwnerId   int64  `json:"owner_id"`
 	CreatedAt string `json:"created_at"`
 	UpdatedAt string `json:"updated_at"`
+	DeletedAt string `json:"deleted_at,omitempty"` // 削除済み（復元可能期間中）の場合のみ
+	Etag      string `json:"etag"`                 // 更新時に If-Match で送る値（ETag ヘッダーと同じ）
 }
 
-type RegisterReq struct {
-	Name     string `json:"name" validate:"required,min=2,max=50"`
-	Email    string `json:"email" validate:"required,email"`
+type OrgApiKey struct {
+	Id         int64    `json:"id"`
+	OrgId      int64    `json:"org_id"`
+	Name       string   `json:"name"`
+	Prefix     string   `json:"prefix"` // キーの先頭部分（どのキーかの識別用）
+	Scopes     []string `json:"scopes"`
+	CreatedBy  int64    `json:"created_by"`
+	ExpiresAt  string   `json:"expires_at,omitempty"`
+	LastUsedAt string   `json:"last_used_at,omitempty"`
+	CreatedAt  string   `json:"created_at"`
+}
+
+type OrgApiKeyListRes struct {
+	ApiKeys []OrgApiKey `json:"api_keys"`
+}
+
+type OrgApiKeyReq struct {
+	OrgId int64 `path:"id"`
+	KeyId int64 `path:"keyId"`
+}
+
+type OrgMembershipInfo struct {
+	OrgId       int64    `json:"org_id"`
+	OrgName     string   `json:"org_name"`
+	Role        string   `json:"role"` // owner / admin / member / viewer
+	Permissions []string `json:"permissions"`
+}
+
+type OrgInvitation struct {
+	Id        int64  `json:"id"`
+	OrgId     int64  `json:"org_id"`
+	Email     string `json:"email"`
+	RoleName  string `json:"role_name"`
+	Status    string `json:"status"` // pending / accepted / declined / revoked / expired
+	InvitedBy int64  `json:"invited_by"`
+	ExpiresAt string `json:"expires_at"`
+	CreatedAt string `json:"created_at"`
+}
+
+type OrgInvitationListRes struct {
+	Invitations []OrgInvitation `json:"invitations"`
+}
+
+type OrgInvitationReq struct {
+	OrgId        int64 `path:"id"`
+	InvitationId int64 `path:"invitationId"`
+}
+
+type OrgListReq struct {
+	Cursor string `form:"cursor,optional"`
+	Limit  int    `form:"limit,optional,range=[0:200]"`
+}
+
+type OrgTransfer struct {
+	Id         int64  `json:"id"`
+	OrgId      int64  `json:"org_id"`
+	FromUserId int64  `json:"from_user_id"`
+	ToUserId   int64  `json:"to_user_id"`
+	Status     string `json:"status"` // pending / accepted / declined / cancelled / expired
+	ExpiresAt  string `json:"expires_at"`
+	CreatedAt  string `json:"created_at"`
+}
+
+type PasswordSetupReq struct {
+	Token    string `json:"token"`
 	Password string `json:"password" validate:"required,min=6"`
 }
 
+type PatchOrgReq struct {
+	Id      int64  `path:"id"`
+	IfMatch string `header:"If-Match,optional"` // 取得時の ETag（必須。省略時は 428）
+}
+
+type ProfilePatchReq struct {
+	IfMatch string `header:"If-Match,optional"` // 指定した場合のみ現在の ETag と照合する
+}
+
+type ProfileRes struct {
+	Profile UserProfileData `json:"profile"`
+	Etag    string          `json:"etag"` // ETag ヘッダーと同じ値
+}
+
+type RegisterReq struct {
+	Name            string `json:"name" validate:"required,min=2,max=50"`
+	Email           string `json:"email" validate:"required,email"`
+	Password        string `json:"password" validate:"required,min=6"`
+	InvitationToken string `json:"invitation_token,optional"` // 招待メールのトークン。指定した場合のみ招待先の組織に参加する
+}
+
 type RegisterRes struct {
 	Id    int64  `json:"id"`
 	Name  string `json:"name"`
@@ -65,9 +368,57 @@ type Response struct {
 	Message string `json:"message"`
 }
 
+type TenantMember struct {
+	UserId   int64  `json:"user_id"`
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	Role     string `json:"role"`
+	JoinedAt string `json:"joined_at"`
+}
+
+type TenantMemberListReq struct {
+	Cursor string `form:"cursor,optional"`
+	Limit  int    `form:"limit,optional,range=[0:200]"`
+}
+
+type TenantMemberListRes struct {
+	Members    []TenantMember `json:"members"`
+	NextCursor string         `json:"next_cursor,omitempty"` // 続きが無い場合は空
+	PrevCursor string         `json:"prev_cursor,omitempty"` // 先頭ページでは空
+}
+
+type TenantRes struct {
+	Org         Org      `json:"org"`
+	Role        string   `json:"role"`        // owner / admin / member / viewer
+	Permissions []string `json:"permissions"` // 組織内ロールで付与された権限
+}
+
+type TransferOrgReq struct {
+	OrgId      int64 `path:"id"`
+	NewOwnerId int64 `json:"new_owner_id"`
+}
+
+type UpdateFeatureFlagReq struct {
+	Key               string           `path:"key"`
+	Description       string           `json:"description,optional"`
+	Enabled           bool             `json:"enabled"`
+	Rules             FeatureFlagRules `json:"rules,optional"`
+	RolloutPercentage int              `json:"rollout_percentage,range=[0:100]"`
+}
+
 type UpdateOrgReq struct {
-	Id   int64  `path:"id"`
-	Name string `json:"name" validate:"required,min=2,max=100"`
+	Id      int64  `path:"id"`
+	IfMatch string `header:"If-Match,optional"` // 取得時の ETag（必須。省略時は 428）
+	Name    string `json:"name" validate:"required,min=2,max=100"`
+}
+
+type UserBasicInfo struct {
+	UserId int64               `json:"user_id"`
+	Name   string              `json:"name"`
+	Email  string              `json:"email"`
+	Status string              `json:"status"`
+	Roles  []string            `json:"roles"` // グローバルロール
+	Orgs   []OrgMembershipInfo `json:"orgs"`  // 所属組織と組織内ロール
 }
 
 type UserCreateReq struct {
@@ -99,15 +450,65 @@ type UserDetailRes struct {
 	User UserInfo `json:"user"`
 }
 
+type UserExportReq struct {
+	Format      string `form:"format,optional,default=csv,options=csv|ndjson"`
+	Q           string `form:"q,optional"`
+	Status      string `form:"status,optional"`
+	Role        string `form:"role,optional"`
+	OrgId       int64  `form:"org_id,optional"`
+	CreatedFrom string `form:"created_from,optional"`
+	CreatedTo   string `form:"created_to,optional"`
+}
+
+type UserImportJob struct {
+	Id              int64                 `json:"id"`
+	Status          string                `json:"status"` // pending / running / completed / failed
+	Format          string                `json:"format"`
+	DryRun          bool                  `json:"dry_run"`
+	SendInvitations bool                  `json:"send_invitations"`
+	TotalRows       int64                 `json:"total_rows"`
+	ProcessedRows   int64                 `json:"processed_rows"`
+	ValidRows       int64                 `json:"valid_rows"` // dry_run では登録される予定の行数
+	CreatedRows     int64                 `json:"created_rows"`
+	SkippedRows     int64                 `json:"skipped_rows"`
+	FailedRows      int64                 `json:"failed_rows"`
+	Rows            []UserImportRowResult `json:"rows"`
+	Error           string                `json:"error,omitempty"` // ジョブ全体が失敗した理由
+	CreatedBy       int64                 `json:"created_by"`
+	CreatedAt       string                `json:"created_at"`
+	StartedAt       string                `json:"started_at,omitempty"`
+	FinishedAt      string                `json:"finished_at,omitempty"`
+}
+
+type UserImportJobReq struct {
+	Id int64 `path:"id"`
+}
+
+type UserImportReq struct {
+	Format          string `form:"format,optional"`
+	DryRun          bool   `form:"dry_run,optional"`          // 検証のみ行い、ユーザーは登録しない
+	SendInvitations bool   `form:"send_invitations,optional"` // 登録したユーザーにパスワード設定用のメールを送る
+}
+
+type UserImportRowResult struct {
+	Row    int               `json:"row"`
+	Email  string            `json:"email"`
+	Status string            `json:"status"` // skipped: 登録済み・ファイル内で重複 / failed: 入力の誤り・登録の失敗
+	Errors map[string]string `json:"errors"` // 項目名 → 理由
+}
+
 type UserInfo struct {
-	UserId    int64            `json:"user_id"`
-	Name      string           `json:"name"`
-	Email     string           `json:"email"`
-	Status    string           `json:"status"`
-	Roles     []string         `json:"roles,omitempty"`
-	Profile   *UserProfileData `json:"profile,omitempty"`
-	CreatedAt string           `json:"created_at"`
-	UpdatedAt string           `json:"updated_at"`
+	UserId      int64            `json:"user_id"`
+	Name        string           `json:"name"`
+	Email       string           `json:"email"`
+	Status      string           `json:"status"`
+	Roles       []string         `json:"roles,omitempty"`
+	Profile     *UserProfileData `json:"profile,omitempty"`
+	CreatedAt   string           `json:"created_at"`
+	UpdatedAt   string           `json:"updated_at"`
+	LastLoginAt string           `json:"last_login_at,omitempty"` // 未ログインの場合は省略
+	DeletedAt   string           `json:"deleted_at,omitempty"`    // 削除済み（復元可能期間中）の場合のみ
+	Etag        string           `json:"etag,omitempty"`          // 更新時に If-Match で送る値（ETag ヘッダーと同じ）
 }
 
 type UserInfoRes struct {
@@ -117,18 +518,36 @@ type UserInfoRes struct {
 }
 
 type UserListReq struct {
-	Page  int64 `form:"page,optional,default=1"`
-	Limit int64 `form:"limit,optional,default=10"`
+	Page        int64  `form:"page,optional,default=1"`
+	Limit       int64  `form:"limit,optional,default=10"`
+	Q           string `form:"q,optional"`            // 名前・メールアドレスの部分一致
+	Status      string `form:"status,optional"`       // active / inactive（1 / 0 も可）
+	Role        string `form:"role,optional"`         // ロール名
+	OrgId       int64  `form:"org_id,optional"`       // 所属している組織
+	CreatedFrom string `form:"created_from,optional"` // 作成日時の下限（YYYY-MM-DD または RFC 3339）
+	CreatedTo   string `form:"created_to,optional"`   // 作成日時の上限（日付のみの場合はその日を含む）
+	Sort        string `form:"sort,optional"`         // name / email / created_at / last_login_at
+	Order       string `form:"order,optional"`        // asc（既定）/ desc
+	Cursor      string `form:"cursor,optional"`       // 前回の next_cursor / prev_cursor（指定時は page を無視し total は数えない）
+	Deleted     bool   `form:"deleted,optional"`      // true の場合は削除済み（復元可能期間中）のユーザーを返す
 }
 
 type UserListRes struct {
-	Users []UserInfo `json:"users"`
-	Total int64      `json:"total"`
-	Page  int64      `json:"page"`
-	Limit int64      `json:"limit"`
+	Users      []UserInfo `json:"users"`
+	Total      int64      `json:"total"`
+	Page       int64      `json:"page"`
+	Limit      int64      `json:"limit"`
+	NextCursor string     `json:"next_cursor,omitempty"` // 続きが無い場合は空
+	PrevCursor string     `json:"prev_cursor,omitempty"` // 先頭ページでは空
+}
+
+type UserPatchReq struct {
+	UserId  int64  `path:"id"`
+	IfMatch string `header:"If-Match,optional"` // 取得時の ETag（必須。省略時は 428）
 }
 
 type UserProfileData struct {
+	AvatarUrl   string `json:"avatar_url,optional"` // 読み取り専用（POST /api/user/avatar で設定する）
 	Bio         string `json:"bio,optional"`
 	Phone       string `json:"phone,optional"`
 	Address     string `json:"address,optional"`
@@ -139,8 +558,13 @@ type UserProfileData struct {
 	SocialLinks string `json:"social_links,optional"`
 }
 
+type UserRestoreReq struct {
+	UserId int64 `path:"id"`
+}
+
 type UserUpdateReq struct {
 	UserId  int64            `path:"id"`
+	IfMatch string           `header:"If-Match,optional"` // 取得時の ETag（必須。省略時は 428）
 	Name    string           `json:"name" validate:"required"`
 	Email   string           `json:"email" validate:"required,email"`
 	Status  string           `json:"status,optional"`
@@ -151,3 +575,14 @@ type UserUpdateReq struct {
 type UserUpdateRes struct {
 	User UserInfo `json:"user"`
 }
+
+type ValidateUserReq struct {
+	UserId int64  `json:"user_id"`
+	Token  string `json:"token,optional"`
+}
+
+type ValidateUserRes struct {
+	Valid   bool           `json:"valid"`
+	User    *UserBasicInfo `json:"user,omitempty"`
+	Message string         `json:"message"`
+}
@@ -4,12 +4,18 @@ import (
 	"flag"
 	"fmt"
 
+	"user_service/internal/apikey"
+	"user_service/internal/audit"
 	"user_service/internal/config"
 	"user_service/internal/handler"
+	"user_service/internal/middleware"
 	"user_service/internal/svc"
 
+	"github.com/winyx/backend/common/buildinfo"
+	"github.com/winyx/backend/common/metrics"
 	"github.com/zeromicro/go-zero/core/conf"
 	"github.com/zeromicro/go-zero/rest"
+	"github.com/zeromicro/go-zero/rest/router"
 )
 
 var configFile = flag.String("f", "etc/user_service-api.yaml", "the config file")
@@ -20,12 +26,22 @@ func main() {
 	var c config.Config
 	conf.MustLoad(*configFile, &c)
 
-	server := rest.MustNewServer(c.RestConf)
+	ctx := svc.NewServiceContext(c)
+
+	// Authorization: Bearer wx_... の API キーは JWT 認証の手前で変換する
+	server := rest.MustNewServer(c.RestConf, rest.WithRouter(apikey.NewRouter(router.NewRouter(), ctx.ApiKeys)))
 	defer server.Stop()
 
-	ctx := svc.NewServiceContext(c)
+	ctx.Start()
+	defer ctx.Stop()
+	server.Use(audit.Middleware)
+	server.Use(ctx.Loaders.Middleware)
+	server.Use(middleware.NewMaintenanceMiddleware(ctx).Handle)
 	handler.RegisterHandlers(server, ctx)
+	metrics.RegisterHandler(server)
+	buildinfo.RegisterHandler(server)
 
-	fmt.Printf("Starting server at %s:%d...\n", c.Host, c.Port)
+	build := buildinfo.Get()
+	fmt.Printf("Starting server at %s:%d (version %s, commit %s)...\n", c.Host, c.Port, build.Version, build.ShortCommit())
 	server.Start()
 }
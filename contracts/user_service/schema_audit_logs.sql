@@ -0,0 +1,57 @@
+-- 監査ログ（ログイン・ロール付与・ユーザー削除・組織の変更などセキュリティ上重要な操作の記録）
+--
+-- 追記のみのテーブルで、UPDATE / DELETE はトリガーで拒否する
+-- 各行の hash は「直前の行の hash + 行の内容」の SHA-256 で、行の改ざん・削除・挿入は
+-- GET /api/v1/admin/audit-logs/verify で検出できる
+CREATE TABLE `audit_logs` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `actor_user_id` bigint(20) unsigned NOT NULL DEFAULT 0,    -- 0 は未認証（ログイン失敗など）
+  `actor_api_key_id` bigint(20) unsigned NOT NULL DEFAULT 0, -- API キー経由の操作のみ
+  `org_id` bigint(20) unsigned NOT NULL DEFAULT 0,           -- 組織に関する操作のみ
+  `action` varchar(64) NOT NULL,                             -- 例: auth.login / user.delete / org.member_add
+  `target_type` varchar(32) NOT NULL DEFAULT '',
+  `target_id` varchar(64) NOT NULL DEFAULT '',
+  `diff` text NOT NULL, -- {"項目": {"before": ..., "after": ...}}。hash の計算対象のため JSON 型にはしない（MySQL が正規化して値が変わる）
+  `ip` varchar(45) NOT NULL DEFAULT '',
+  `user_agent` varchar(255) NOT NULL DEFAULT '',
+  `request_id` varchar(64) NOT NULL DEFAULT '',
+  `prev_hash` char(64) NOT NULL,
+  `hash` char(64) NOT NULL,
+  `occurred_at` timestamp(6) NOT NULL, -- hash の計算対象のためアプリケーションで設定する
+  PRIMARY KEY (`id`),
+  KEY `idx_actor_user_id` (`actor_user_id`),
+  KEY `idx_org_id` (`org_id`),
+  KEY `idx_action` (`action`),
+  KEY `idx_target` (`target_type`, `target_id`),
+  KEY `idx_occurred_at` (`occurred_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- ハッシュチェーンの末尾（1行のみ）
+-- 追記時にこの行をロックして直前の hash を読むことで、同時に書き込まれてもチェーンが分岐しない
+-- 末尾の行が削除された場合も last_id / last_hash との不一致で検出できる
+CREATE TABLE `audit_log_chain` (
+  `id` tinyint(3) unsigned NOT NULL,
+  `last_id` bigint(20) unsigned NOT NULL DEFAULT 0,
+  `last_hash` char(64) NOT NULL,
+  PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+INSERT INTO `audit_log_chain` (`id`, `last_id`, `last_hash`)
+VALUES (1, 0, REPEAT('0', 64))
+ON DUPLICATE KEY UPDATE id = id;
+
+DELIMITER //
+
+CREATE TRIGGER `audit_logs_no_update` BEFORE UPDATE ON `audit_logs`
+FOR EACH ROW
+BEGIN
+  SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'audit_logs is append-only';
+END//
+
+CREATE TRIGGER `audit_logs_no_delete` BEFORE DELETE ON `audit_logs`
+FOR EACH ROW
+BEGIN
+  SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'audit_logs is append-only';
+END//
+
+DELIMITER ;
@@ -0,0 +1,18 @@
+-- ダッシュボード用の権限（schema_extension.sql の permissions / role_permissions を前提とする）
+-- dashboard_service は UserServiceClient.CheckPermission で以下を確認する
+--   dashboard:view   参照系API（admin / moderator）
+--   dashboard:manage メンテナンス設定などの更新系API（admin のみ）
+INSERT INTO permissions (name, resource, action, description) VALUES
+('ダッシュボード閲覧', 'dashboard', 'view', '監視ダッシュボードを参照する権限'),
+('ダッシュボード管理', 'dashboard', 'manage', 'メンテナンス設定などダッシュボードから運用操作を行う権限')
+ON DUPLICATE KEY UPDATE
+    description = VALUES(description),
+    updated_at = CURRENT_TIMESTAMP;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+CROSS JOIN permissions p
+WHERE p.resource = 'dashboard'
+  AND (r.name = 'admin' OR (r.name = 'moderator' AND p.action = 'view'))
+ON DUPLICATE KEY UPDATE created_at = created_at;
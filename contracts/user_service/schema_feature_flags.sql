@@ -0,0 +1,21 @@
+-- フィーチャーフラグ
+-- rules はターゲティングルール（{"user_ids": [...], "roles": [...], "org_ids": [...]}）
+CREATE TABLE `feature_flags` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `flag_key` varchar(100) NOT NULL,
+  `description` varchar(255) NOT NULL DEFAULT '',
+  `enabled` tinyint(1) NOT NULL DEFAULT 0,
+  `rules` json NOT NULL,
+  `rollout_percentage` tinyint(3) unsigned NOT NULL DEFAULT 100,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_flag_key` (`flag_key`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- ダッシュボードが従来固定で返していた機能フラグ
+INSERT INTO `feature_flags` (`flag_key`, `description`, `enabled`, `rules`, `rollout_percentage`) VALUES
+  ('monitoring', 'サービス監視', 1, '{}', 100),
+  ('metrics', 'メトリクス収集', 1, '{}', 100),
+  ('health_check', 'ヘルスチェック', 1, '{}', 100),
+  ('api_stats', 'API使用統計', 1, '{}', 100);
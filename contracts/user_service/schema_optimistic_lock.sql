@@ -0,0 +1,12 @@
+-- 楽観的排他制御用のバージョン
+--
+-- 更新のたびに version を 1 増やし、API は ETag として返す。
+-- PUT は If-Match で取得時の ETag を受け取り、version が変わっていれば 412 を返す
+ALTER TABLE `users`
+  ADD COLUMN `version` bigint(20) unsigned NOT NULL DEFAULT 0;
+
+ALTER TABLE `user_profiles`
+  ADD COLUMN `version` bigint(20) unsigned NOT NULL DEFAULT 0;
+
+ALTER TABLE `orgs`
+  ADD COLUMN `version` bigint(20) unsigned NOT NULL DEFAULT 0;
@@ -0,0 +1,28 @@
+-- 組織の API キー（連携システムなどの機械クライアント用）
+-- キーは "wx_<prefix>_<secret>" 形式で、作成時に一度だけ表示する
+-- prefix はキーの検索と一覧表示に使い、key_hash はキー全体の SHA-256。キー自体は保存しない
+-- scopes はカンマ区切りの組織内権限（org_role_permissions.permission のうち作成者が持つもの）
+CREATE TABLE `org_api_keys` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `org_id` bigint(20) unsigned NOT NULL,
+  `name` varchar(100) NOT NULL,
+  `prefix` varchar(16) NOT NULL,
+  `key_hash` char(64) NOT NULL,
+  `scopes` varchar(500) NOT NULL DEFAULT '',
+  `created_by` bigint(20) unsigned NOT NULL, -- キーで操作するユーザー（作成者の権限とスコープの両方を満たす操作のみ可能）
+  `expires_at` timestamp NULL DEFAULT NULL,  -- NULL は無期限
+  `last_used_at` timestamp NULL DEFAULT NULL,
+  `revoked_at` timestamp NULL DEFAULT NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_prefix` (`prefix`),
+  KEY `idx_org_id` (`org_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- API キーの管理は組織オーナー・組織管理者（schema_org_scoped_roles.sql の権限に追加）
+INSERT INTO `org_role_permissions` (`org_role_id`, `permission`)
+SELECT r.id, 'api_keys:manage'
+FROM `org_roles` r
+WHERE r.name IN ('owner', 'admin')
+ON DUPLICATE KEY UPDATE created_at = created_at;
@@ -0,0 +1,20 @@
+-- 組織への招待
+-- token_hash は招待トークン（メールで送付）の SHA-256。トークン自体は保存しない
+-- status: pending / accepted / declined / revoked / expired
+CREATE TABLE `org_invitations` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `org_id` bigint(20) unsigned NOT NULL,
+  `email` varchar(255) NOT NULL,
+  `role_id` bigint(20) NOT NULL, -- org_roles.id（schema_org_scoped_roles.sql）
+  `token_hash` char(64) NOT NULL,
+  `status` varchar(20) NOT NULL DEFAULT 'pending',
+  `invited_by` bigint(20) unsigned NOT NULL,
+  `responded_at` timestamp NULL DEFAULT NULL,
+  `expires_at` timestamp NOT NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_token_hash` (`token_hash`),
+  KEY `idx_org_status` (`org_id`, `status`),
+  KEY `idx_email_status` (`email`, `status`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
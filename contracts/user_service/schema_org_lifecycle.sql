@@ -0,0 +1,32 @@
+-- 組織のソフトデリートと所有権移譲
+--
+-- 削除した組織は deleted_at を設定して一定期間（Org.RestoreDays）復元可能とし、
+-- 期間を過ぎたものはバックグラウンドジョブがメンバー・招待・移譲依頼ごと物理削除する
+ALTER TABLE `orgs`
+  ADD COLUMN `deleted_at` timestamp NULL DEFAULT NULL,
+  ADD KEY `idx_deleted_at` (`deleted_at`),
+  ADD KEY `idx_owner_id` (`owner_id`);
+
+-- 所有権移譲の依頼（新オーナーが承諾するまで owner_id は変わらない）
+-- status: pending / accepted / declined / cancelled / expired
+CREATE TABLE `org_ownership_transfers` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `org_id` bigint(20) unsigned NOT NULL,
+  `from_user_id` bigint(20) unsigned NOT NULL,
+  `to_user_id` bigint(20) unsigned NOT NULL,
+  `status` varchar(20) NOT NULL DEFAULT 'pending',
+  `responded_at` timestamp NULL DEFAULT NULL,
+  `expires_at` timestamp NOT NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `idx_org_status` (`org_id`, `status`),
+  KEY `idx_to_user_status` (`to_user_id`, `status`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- 所有権移譲はオーナーのみ（schema_org_scoped_roles.sql の権限に追加）
+INSERT INTO `org_role_permissions` (`org_role_id`, `permission`)
+SELECT r.id, 'org:transfer'
+FROM `org_roles` r
+WHERE r.name = 'owner'
+ON DUPLICATE KEY UPDATE created_at = created_at;
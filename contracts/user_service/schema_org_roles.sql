@@ -0,0 +1,23 @@
+-- 組織内ロールとメンバーシップの制約
+-- 組織メンバーには roles テーブルの admin / member を付与する（作成者は admin として自動登録）
+-- ※ schema_org_scoped_roles.sql 適用後は org_roles を参照する
+INSERT INTO roles (name, description) VALUES
+('member', '組織メンバー - 所属組織の参照のみ可能')
+ON DUPLICATE KEY UPDATE
+    description = VALUES(description),
+    updated_at = CURRENT_TIMESTAMP;
+
+-- 同じユーザーを同じ組織へ重複登録しない
+ALTER TABLE `org_members`
+  ADD UNIQUE KEY `uk_org_user` (`org_id`, `user_id`),
+  ADD KEY `idx_user_id` (`user_id`);
+
+-- 既存組織のオーナーを組織管理者として登録
+INSERT INTO `org_members` (`org_id`, `user_id`, `role_id`)
+SELECT o.id, o.owner_id, r.id
+FROM `orgs` o
+CROSS JOIN roles r
+WHERE r.name = 'admin'
+  AND NOT EXISTS (
+    SELECT 1 FROM `org_members` m WHERE m.org_id = o.id AND m.user_id = o.owner_id
+  );
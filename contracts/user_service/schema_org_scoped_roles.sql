@@ -0,0 +1,113 @@
+-- 組織スコープのロールと権限
+-- org_members.role_id / org_invitations.role_id はグローバルな roles ではなく org_roles を参照する
+-- （システム管理者と「ある組織の管理者」を区別するため）
+--
+-- ロール毎の権限:
+--   viewer  組織・メンバーの参照、組織リソースの参照
+--   member  viewer + 組織リソースの作成・更新
+--   admin   member + 組織情報の更新、メンバー・招待の管理
+--   owner   admin + 組織の削除・所有権移譲（1組織に1人。org:transfer は schema_org_lifecycle.sql で追加）
+CREATE TABLE `org_roles` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `name` varchar(50) NOT NULL,
+  `description` varchar(255) NOT NULL DEFAULT '',
+  `level` int NOT NULL DEFAULT 0, -- 大きいほど上位のロール
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_name` (`name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE `org_role_permissions` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `org_role_id` bigint(20) NOT NULL,
+  `permission` varchar(100) NOT NULL, -- "resource:action"
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_role_permission` (`org_role_id`, `permission`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+INSERT INTO `org_roles` (`name`, `description`, `level`) VALUES
+('owner', '組織オーナー - 組織の削除を含む全操作が可能', 40),
+('admin', '組織管理者 - 組織情報の更新とメンバー・招待の管理が可能', 30),
+('member', '組織メンバー - 組織リソースの作成・更新が可能', 20),
+('viewer', '閲覧者 - 組織の参照のみ可能', 10)
+ON DUPLICATE KEY UPDATE
+    description = VALUES(description),
+    level = VALUES(level),
+    updated_at = CURRENT_TIMESTAMP;
+
+INSERT INTO `org_role_permissions` (`org_role_id`, `permission`)
+SELECT r.id, p.permission
+FROM `org_roles` r
+JOIN (
+    SELECT 'org:view' AS permission, 10 AS min_level
+    UNION ALL SELECT 'members:view', 10
+    UNION ALL SELECT 'resources:read', 10
+    UNION ALL SELECT 'resources:write', 20
+    UNION ALL SELECT 'org:update', 30
+    UNION ALL SELECT 'members:manage', 30
+    UNION ALL SELECT 'invitations:manage', 30
+    UNION ALL SELECT 'org:delete', 40
+) p ON r.level >= p.min_level
+ON DUPLICATE KEY UPDATE created_at = created_at;
+
+-- schema_extension_org.sql で作成した環境では role_id が roles を参照する外部キーがあるため外す
+SET @fk := (
+    SELECT CONSTRAINT_NAME FROM information_schema.KEY_COLUMN_USAGE
+    WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'org_members'
+      AND COLUMN_NAME = 'role_id' AND REFERENCED_TABLE_NAME = 'roles'
+    LIMIT 1
+);
+SET @sql := IF(@fk IS NULL, 'DO 0', CONCAT('ALTER TABLE `org_members` DROP FOREIGN KEY `', @fk, '`'));
+PREPARE stmt FROM @sql;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+-- 既存データの移行（再実行しても結果は変わらない）
+-- 移行前の role_id（roles.id）を org_role_migration に一度だけ控え、付け替えはその控えから行う。
+-- 控えた行は付け替え済みの印（migrated_at）を付けるため、再実行時に移行後に変更したロールを戻すことはない
+CREATE TABLE IF NOT EXISTS `org_role_migration` (
+  `source` varchar(20) NOT NULL,         -- org_members / org_invitations
+  `row_id` bigint(20) NOT NULL,
+  `legacy_role_id` bigint(20) NOT NULL,  -- 移行前の roles.id
+  `migrated_at` timestamp NULL DEFAULT NULL,
+  PRIMARY KEY (`source`, `row_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS `org_role_migration_state` (
+  `name` varchar(50) NOT NULL,
+  `copied_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- 控えは初回のみ取る（2回目以降の role_id は既に org_roles.id のため）
+START TRANSACTION;
+INSERT INTO `org_role_migration` (`source`, `row_id`, `legacy_role_id`)
+SELECT 'org_members', m.id, m.role_id FROM `org_members` m
+WHERE NOT EXISTS (SELECT 1 FROM `org_role_migration_state` WHERE `name` = 'org_scoped_roles');
+INSERT INTO `org_role_migration` (`source`, `row_id`, `legacy_role_id`)
+SELECT 'org_invitations', i.id, i.role_id FROM `org_invitations` i
+WHERE NOT EXISTS (SELECT 1 FROM `org_role_migration_state` WHERE `name` = 'org_scoped_roles');
+INSERT IGNORE INTO `org_role_migration_state` (`name`) VALUES ('org_scoped_roles');
+COMMIT;
+
+-- roles の owner / admin / member / viewer は同名の org_roles へ付け替える（招待は owner を除く）。
+-- それ以外（user・moderator など）や既に無いロールは、番号が org_roles の上位ロールと重なって権限が上がらないよう viewer にする
+UPDATE `org_members` m
+JOIN `org_role_migration` l ON l.source = 'org_members' AND l.row_id = m.id AND l.migrated_at IS NULL
+LEFT JOIN roles r ON r.id = l.legacy_role_id
+JOIN `org_roles` o ON o.name = IF(r.name IN ('owner', 'admin', 'member', 'viewer'), r.name, 'viewer')
+SET m.role_id = o.id, l.migrated_at = CURRENT_TIMESTAMP;
+
+UPDATE `org_invitations` i
+JOIN `org_role_migration` l ON l.source = 'org_invitations' AND l.row_id = i.id AND l.migrated_at IS NULL
+LEFT JOIN roles r ON r.id = l.legacy_role_id
+JOIN `org_roles` o ON o.name = IF(r.name IN ('admin', 'member', 'viewer'), r.name, 'viewer')
+SET i.role_id = o.id, l.migrated_at = CURRENT_TIMESTAMP;
+
+-- 組織作成者は owner にする（現在のオーナーに合わせるだけなので再実行しても変わらない）
+UPDATE `org_members` m
+JOIN `orgs` g ON m.org_id = g.id AND m.user_id = g.owner_id
+JOIN `org_roles` o ON o.name = 'owner'
+SET m.role_id = o.id;
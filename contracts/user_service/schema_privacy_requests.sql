@@ -0,0 +1,43 @@
+-- 個人データのエクスポートと消去（GDPR のデータポータビリティ・消去権への対応）
+--
+-- エクスポート: 本人が POST /api/v1/users/me/data-exports でジョブを作成し、ユーザー・プロフィール・ロール・
+-- 組織のメンバーシップ・セッション履歴を JSON または ZIP にまとめる。アーカイブ本体は Redis に
+-- Privacy.ExportExpireHours の間だけ保存し、この表には状態と SHA-256 のみ残す
+CREATE TABLE `user_data_exports` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `user_id` bigint(20) unsigned NOT NULL,
+  `status` varchar(20) NOT NULL DEFAULT 'pending', -- pending / running / completed / failed
+  `format` varchar(10) NOT NULL,                   -- json / zip
+  `size` bigint(20) unsigned NOT NULL DEFAULT 0,   -- アーカイブのバイト数
+  `sha256` char(64) NOT NULL DEFAULT '',           -- ダウンロードしたファイルの検証用
+  `error` varchar(500) NOT NULL DEFAULT '',
+  `expires_at` timestamp NULL DEFAULT NULL,        -- ダウンロードの期限
+  `started_at` timestamp NULL DEFAULT NULL,
+  `finished_at` timestamp NULL DEFAULT NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `idx_user_status` (`user_id`, `status`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- 消去: 本人がリクエストし（パスワードで再確認）、本人以外のシステム管理者が承認すると個人データを匿名化する。
+-- 監査ログ（audit_logs）は改ざん検出のため書き換えずに残す。
+-- 完了時は消去した内容を certificate（JSON）に記録し、その SHA-256 を監査ログにも書き込んで
+-- ハッシュチェーンで改ざんを検出できるようにする。user_id は匿名化後のユーザーの ID のまま残る
+CREATE TABLE `user_erasure_requests` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `user_id` bigint(20) unsigned NOT NULL,
+  `status` varchar(20) NOT NULL DEFAULT 'pending', -- pending / rejected / cancelled / completed
+  `reason` varchar(500) NOT NULL DEFAULT '',       -- 本人が入力した理由（完了時に消去する）
+  `reviewed_by` bigint(20) unsigned NOT NULL DEFAULT 0,
+  `review_note` varchar(500) NOT NULL DEFAULT '',
+  `reviewed_at` timestamp NULL DEFAULT NULL,
+  `completed_at` timestamp NULL DEFAULT NULL,
+  `certificate` text NOT NULL,                     -- 完了記録（JSON。ハッシュの検証に使うためバイト列のまま返す）
+  `certificate_hash` char(64) NOT NULL DEFAULT '', -- certificate の SHA-256
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `idx_user_status` (`user_id`, `status`),
+  KEY `idx_status` (`status`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
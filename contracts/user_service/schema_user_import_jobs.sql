@@ -0,0 +1,28 @@
+-- ユーザー一括インポートのジョブ（POST /api/v1/admin/users/import）
+--
+-- アップロードされた CSV / JSON Lines はリクエスト内で解析し、行の検証と登録はバックグラウンドで行う。
+-- 進捗と結果（行ごとのエラー・スキップ理由）はこの行に記録し、GET /api/v1/admin/users/import/:id で参照する。
+-- dry_run のジョブは検証のみ行い、ユーザーは登録しない
+CREATE TABLE `user_import_jobs` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `status` varchar(20) NOT NULL DEFAULT 'pending', -- pending / running / completed / failed
+  `format` varchar(10) NOT NULL,                   -- csv / ndjson
+  `dry_run` tinyint(1) NOT NULL DEFAULT 0,
+  `send_invitations` tinyint(1) NOT NULL DEFAULT 0, -- 登録したユーザーにパスワード設定用のメールを送る
+  `total_rows` int(10) unsigned NOT NULL DEFAULT 0,
+  `processed_rows` int(10) unsigned NOT NULL DEFAULT 0,
+  `valid_rows` int(10) unsigned NOT NULL DEFAULT 0,   -- 検証を通過した行（dry_run では登録される予定の行）
+  `created_rows` int(10) unsigned NOT NULL DEFAULT 0,
+  `skipped_rows` int(10) unsigned NOT NULL DEFAULT 0, -- 登録済み・ファイル内で重複するメールアドレス
+  `failed_rows` int(10) unsigned NOT NULL DEFAULT 0,
+  `row_results` mediumtext NOT NULL, -- 登録しなかった行の理由 [{"row": 行番号, "email": ..., "status": "skipped|failed", "errors": {"項目": "理由"}}]
+  `error` varchar(500) NOT NULL DEFAULT '', -- ジョブ全体が失敗した理由
+  `created_by` bigint(20) unsigned NOT NULL,
+  `started_at` timestamp NULL DEFAULT NULL,
+  `finished_at` timestamp NULL DEFAULT NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `idx_created_by` (`created_by`),
+  KEY `idx_status` (`status`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
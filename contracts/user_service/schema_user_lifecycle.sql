@@ -0,0 +1,8 @@
+-- ユーザーのソフトデリート
+--
+-- 削除したユーザーは deleted_at を設定して一定期間（User.RestoreDays）復元可能とし、
+-- 期間を過ぎたものはバックグラウンドジョブがプロフィール・ロール・メンバーシップごと物理削除する。
+-- email の一意制約は削除済みのユーザーにも効くため、同じメールアドレスは物理削除後に再登録できる
+ALTER TABLE `users`
+  ADD COLUMN `deleted_at` timestamp NULL DEFAULT NULL COMMENT '削除日時',
+  ADD KEY `idx_deleted_at` (`deleted_at`);
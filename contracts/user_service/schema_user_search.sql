@@ -0,0 +1,10 @@
+-- 管理画面のユーザー検索・並べ替え用
+--
+-- last_login_at はログイン成功時に更新する（未ログインのユーザーは NULL）。
+-- 並べ替えに使う列と、ステータスで絞って作成日時順に並べる検索にインデックスを張る。
+-- name / email の部分一致検索は前方一致以外ではインデックスが効かない点に注意
+ALTER TABLE `users`
+  ADD COLUMN `last_login_at` timestamp NULL DEFAULT NULL COMMENT '最終ログイン日時',
+  ADD KEY `idx_name` (`name`),
+  ADD KEY `idx_last_login_at` (`last_login_at`),
+  ADD KEY `idx_status_created_at` (`status`, `created_at`);
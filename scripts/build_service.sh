@@ -0,0 +1,41 @@
+#!/bin/bash
+# バックエンドサービスのビルドスクリプト（ビルド情報を ldflags で埋め込む）
+#
+# 使い方: ./scripts/build_service.sh <user_service|dashboard_service> [version]
+# version 省略時は git describe の結果を使用する
+
+set -e
+
+SCRIPT_DIR="$(cd "$(dirname "$0")" && pwd)"
+BACKEND_DIR="$SCRIPT_DIR/../backend"
+
+SERVICE="$1"
+case "$SERVICE" in
+    user_service)
+        MAIN="userservice.go"
+        ;;
+    dashboard_service)
+        MAIN="dashboard_service.go"
+        ;;
+    *)
+        echo "使い方: $0 <user_service|dashboard_service> [version]"
+        exit 1
+        ;;
+esac
+
+VERSION="${2:-$(git -C "$BACKEND_DIR" describe --tags --always --dirty 2>/dev/null || echo dev)}"
+COMMIT="$(git -C "$BACKEND_DIR" rev-parse HEAD 2>/dev/null || echo unknown)"
+BUILD_TIME="$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+
+PKG="github.com/winyx/backend/common/buildinfo"
+LDFLAGS="-X $PKG.version=$VERSION -X $PKG.commit=$COMMIT -X $PKG.buildTime=$BUILD_TIME"
+
+echo "=== $SERVICE をビルド中 ==="
+echo "バージョン: $VERSION"
+echo "コミット:   $COMMIT"
+echo "ビルド日時: $BUILD_TIME"
+
+cd "$BACKEND_DIR/$SERVICE"
+go build -ldflags "$LDFLAGS" -o "$SERVICE" "$MAIN"
+
+echo "✓ ビルド完了: $BACKEND_DIR/$SERVICE/$SERVICE"
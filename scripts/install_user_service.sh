@@ -13,7 +13,7 @@ SERVICE_DEST="/etc/systemd/system/winyx-user-service.service"
 # バイナリの存在確認
 if [ ! -f "/var/www/winyx/backend/user_service/user_service" ]; then
     echo "エラー: UserServiceバイナリが見つかりません"
-    echo "ビルドしてください: cd /var/www/winyx/backend/user_service && go build -o user_service userservice.go"
+    echo "ビルドしてください: /var/www/winyx/scripts/build_service.sh user_service"
     exit 1
 fi
 